@@ -49,9 +49,9 @@ func createTestDatabase() *mem.Database {
 		{Name: "created_at", Type: sql.Timestamp},
 	})
 	db.AddTable("mytable", table)
-	table.Insert(sql.NewRow("John Doe", "john@doe.com", []string{"555-555-555"}, time.Now()))
-	table.Insert(sql.NewRow("John Doe", "johnalt@doe.com", []string{}, time.Now()))
-	table.Insert(sql.NewRow("Jane Doe", "jane@doe.com", []string{}, time.Now()))
-	table.Insert(sql.NewRow("Evil Bob", "evilbob@gmail.com", []string{"555-666-555", "666-666-666"}, time.Now()))
+	table.Insert(sql.NewEmptyContext(), sql.NewRow("John Doe", "john@doe.com", []string{"555-555-555"}, time.Now()))
+	table.Insert(sql.NewEmptyContext(), sql.NewRow("John Doe", "johnalt@doe.com", []string{}, time.Now()))
+	table.Insert(sql.NewEmptyContext(), sql.NewRow("Jane Doe", "jane@doe.com", []string{}, time.Now()))
+	table.Insert(sql.NewEmptyContext(), sql.NewRow("Evil Bob", "evilbob@gmail.com", []string{"555-666-555", "666-666-666"}, time.Now()))
 	return db
 }
@@ -0,0 +1,177 @@
+// Package csvdb exposes a directory of CSV and TSV files as a database.
+//
+// Every file with a .csv or .tsv extension is a table named after the file
+// without its extension. The schema of a table is read from a schema file
+// next to it, named after the table with a .schema.json extension, or it's
+// inferred from the first rows of the file. Files are read as they are
+// iterated, so they are never loaded in memory at once.
+package csvdb
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/src-d/go-vitess/vt/proto/query"
+)
+
+// DefaultInferRows is the default number of rows read to infer the schema of
+// a file.
+const DefaultInferRows = 100
+
+// schemaFileExt is the extension of the files with the schema of a table.
+const schemaFileExt = ".schema.json"
+
+// Options of a Database.
+type Options struct {
+	// NoHeader is true if the first line of the files holds data instead of
+	// the names of the columns. The columns are then named c1, c2, ... cN.
+	NoHeader bool
+	// InferRows is the number of rows read to infer the schema of the files
+	// without a schema file. If it's 0, DefaultInferRows is used.
+	InferRows int
+	// Writable enables INSERT, which appends rows to the files.
+	Writable bool
+}
+
+// Database is a database whose tables are the CSV and TSV files of a
+// directory.
+type Database struct {
+	name   string
+	tables map[string]sql.Table
+}
+
+// Open opens the files of the given directory as a database with the given
+// name.
+func Open(name, dir string, opts Options) (*Database, error) {
+	if opts.InferRows <= 0 {
+		opts.InferRows = DefaultInferRows
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make(map[string]sql.Table)
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+
+		ext := strings.ToLower(filepath.Ext(f.Name()))
+		var comma rune
+		switch ext {
+		case ".csv":
+			comma = ','
+		case ".tsv":
+			comma = '\t'
+		default:
+			continue
+		}
+
+		name := strings.TrimSuffix(f.Name(), filepath.Ext(f.Name()))
+		if _, ok := tables[name]; ok {
+			return nil, fmt.Errorf("csvdb: more than one file for table %s", name)
+		}
+
+		t, err := newTable(name, filepath.Join(dir, f.Name()), comma, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		tables[name] = t
+	}
+
+	return &Database{name: name, tables: tables}, nil
+}
+
+// Name implements the sql.Database interface.
+func (d *Database) Name() string {
+	return d.name
+}
+
+// Tables implements the sql.Database interface.
+func (d *Database) Tables() map[string]sql.Table {
+	return d.tables
+}
+
+// schemaColumn is a column in a schema file, which holds a JSON array of
+// columns such as:
+//
+//	[{"name": "id", "type": "INT64"}, {"name": "name", "type": "TEXT", "nullable": true}]
+type schemaColumn struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Nullable bool   `json:"nullable"`
+}
+
+// readSchemaFile reads the schema in the given file. It returns a nil schema
+// if the file does not exist.
+func readSchemaFile(path string) (sql.Schema, error) {
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []schemaColumn
+	if err := json.Unmarshal(data, &columns); err != nil {
+		return nil, fmt.Errorf("csvdb: invalid schema file %s: %s", path, err)
+	}
+
+	schema := make(sql.Schema, len(columns))
+	for i, c := range columns {
+		typ, ok := types[strings.ToUpper(c.Type)]
+		if !ok {
+			return nil, fmt.Errorf("csvdb: unknown type %s of column %s in %s", c.Type, c.Name, path)
+		}
+
+		schema[i] = &sql.Column{Name: c.Name, Type: typ, Nullable: c.Nullable}
+	}
+
+	return schema, nil
+}
+
+// types are the column types that can be used in schema files by the name
+// of their query.Type.
+var types = map[string]sql.Type{}
+
+func init() {
+	for _, t := range []sql.Type{
+		sql.Int8,
+		sql.Int16,
+		sql.Int24,
+		sql.Int32,
+		sql.Int64,
+		sql.Uint8,
+		sql.Uint16,
+		sql.Uint24,
+		sql.Uint32,
+		sql.Uint64,
+		sql.Float32,
+		sql.Float64,
+		sql.Timestamp,
+		sql.Date,
+		sql.Datetime(0),
+		sql.Time,
+		sql.Year,
+		sql.Text,
+		sql.Blob,
+		sql.JSON,
+	} {
+		types[query.Type_name[int32(t.Type())]] = t
+	}
+
+	// Booleans are TINYINT(1) values named BOOLEAN. BIT(n) values can't be
+	// read from text, so there is no BIT type.
+	types["BOOLEAN"] = sql.Boolean
+}
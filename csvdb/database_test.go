@@ -0,0 +1,192 @@
+package csvdb
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "csvdb")
+	require.NoError(t, err)
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	return dir
+}
+
+func TestDatabase(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeFiles(t, map[string]string{
+		"people.csv": "id,name,score,active,created\n" +
+			"1,\"Doe, John\",1.5,true,2018-01-02 03:04:05\n" +
+			"2,,2,false,\n",
+		"pets.tsv":   "name\tkind\nrex\tdog\n",
+		"notes.txt":  "not a table",
+		"empty.csv":  "",
+		"other.json": "{}",
+	})
+	defer os.RemoveAll(dir)
+
+	db, err := Open("mydb", dir, Options{})
+	require.NoError(err)
+	require.Equal("mydb", db.Name())
+
+	tables := db.Tables()
+	require.Len(tables, 3)
+
+	people := tables["people"]
+	require.Equal(sql.Schema{
+		{Name: "id", Type: sql.Int64},
+		{Name: "name", Type: sql.Text, Nullable: true},
+		{Name: "score", Type: sql.Float64},
+		{Name: "active", Type: sql.Boolean},
+		{Name: "created", Type: sql.Timestamp, Nullable: true},
+	}, people.Schema())
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), people)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{int64(1), "Doe, John", 1.5, true, time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{int64(2), nil, float64(2), false, nil},
+	}, rows)
+
+	rows, err = sql.NodeToRows(sql.NewEmptyContext(), tables["pets"])
+	require.NoError(err)
+	require.Equal([]sql.Row{{"rex", "dog"}}, rows)
+
+	rows, err = sql.NodeToRows(sql.NewEmptyContext(), tables["empty"])
+	require.NoError(err)
+	require.Len(rows, 0)
+}
+
+func TestDatabase_NoHeader(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeFiles(t, map[string]string{"t.csv": "1,a\n2,b\n"})
+	defer os.RemoveAll(dir)
+
+	db, err := Open("mydb", dir, Options{NoHeader: true})
+	require.NoError(err)
+
+	table := db.Tables()["t"]
+	require.Equal(sql.Schema{
+		{Name: "c1", Type: sql.Int64},
+		{Name: "c2", Type: sql.Text},
+	}, table.Schema())
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), table)
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(1), "a"}, {int64(2), "b"}}, rows)
+}
+
+func TestDatabase_InferRows(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeFiles(t, map[string]string{"t.csv": "n\n1\n2\nfoo\n"})
+	defer os.RemoveAll(dir)
+
+	db, err := Open("mydb", dir, Options{InferRows: 2})
+	require.NoError(err)
+
+	table := db.Tables()["t"]
+	require.Equal(sql.Int64, table.Schema()[0].Type)
+
+	_, err = sql.NodeToRows(sql.NewEmptyContext(), table)
+	require.Error(err)
+}
+
+func TestDatabase_SchemaFile(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeFiles(t, map[string]string{
+		"t.csv": "a,b\n1,2\n",
+		"t.schema.json": `[
+			{"name": "x", "type": "int32"},
+			{"name": "y", "type": "TEXT", "nullable": true}
+		]`,
+	})
+	defer os.RemoveAll(dir)
+
+	db, err := Open("mydb", dir, Options{})
+	require.NoError(err)
+
+	table := db.Tables()["t"]
+	require.Equal(sql.Schema{
+		{Name: "x", Type: sql.Int32},
+		{Name: "y", Type: sql.Text, Nullable: true},
+	}, table.Schema())
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), table)
+	require.NoError(err)
+	require.Equal([]sql.Row{{int32(1), "2"}}, rows)
+
+	for _, typ := range []string{"FOO", "BIT"} {
+		require.NoError(ioutil.WriteFile(
+			filepath.Join(dir, "t.schema.json"),
+			[]byte(`[{"name": "x", "type": "`+typ+`"}]`),
+			0644,
+		))
+
+		_, err = Open("mydb", dir, Options{})
+		require.Error(err)
+	}
+}
+
+func TestTable_Insert(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeFiles(t, map[string]string{
+		"t.csv":  "i,s\n1,a",
+		"u.tsv":  "i\ts\n",
+		"ro.csv": "i\n1\n",
+	})
+	defer os.RemoveAll(dir)
+
+	ctx := sql.NewEmptyContext()
+	db, err := Open("mydb", dir, Options{})
+	require.NoError(err)
+	require.Equal(ErrReadOnly, db.Tables()["ro"].(sql.Inserter).Insert(ctx, sql.NewRow(int64(2))))
+
+	db, err = Open("mydb", dir, Options{Writable: true})
+	require.NoError(err)
+
+	table := db.Tables()["t"].(*Table)
+	iter, err := table.RowIter(ctx)
+	require.NoError(err)
+
+	require.NoError(table.Insert(ctx, sql.NewRow(int64(2), "b, \"c\"")))
+	require.NoError(table.Insert(ctx, sql.NewRow(3, "d")))
+	require.Error(table.Insert(ctx, sql.NewRow(int64(4))))
+	require.Error(table.Insert(ctx, sql.NewRow("x", "y")))
+
+	rows, err := sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(1), "a"}}, rows)
+
+	rows, err = sql.NodeToRows(ctx, table)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{int64(1), "a"},
+		{int64(2), "b, \"c\""},
+		{int64(3), "d"},
+	}, rows)
+
+	table = db.Tables()["u"].(*Table)
+	require.Equal(sql.Text, table.Schema()[0].Type)
+	require.NoError(table.Insert(ctx, sql.NewRow("x", "y")))
+
+	data, err := ioutil.ReadFile(filepath.Join(dir, "u.tsv"))
+	require.NoError(err)
+	require.Equal("i\ts\nx\ty\n", string(data))
+}
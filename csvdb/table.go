@@ -0,0 +1,448 @@
+package csvdb
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// ErrReadOnly is returned when rows are inserted in a table of a database
+// that is not writable.
+var ErrReadOnly = fmt.Errorf("csvdb: database is read-only")
+
+// Table is a CSV or TSV file. Empty fields are NULL in nullable columns.
+type Table struct {
+	name   string
+	path   string
+	schema sql.Schema
+	comma  rune
+	opts   Options
+
+	// mu guards the file while rows are appended to it. Iterators only read
+	// the part of the file that was written when they were created, so they
+	// never see a row half written.
+	mu sync.RWMutex
+}
+
+func newTable(name, path string, comma rune, opts Options) (*Table, error) {
+	t := &Table{name: name, path: path, comma: comma, opts: opts}
+
+	ext := filepath.Ext(path)
+	schema, err := readSchemaFile(strings.TrimSuffix(path, ext) + schemaFileExt)
+	if err != nil {
+		return nil, err
+	}
+
+	if schema == nil {
+		schema, err = t.inferSchema()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	t.schema = schema
+	return t, nil
+}
+
+// Resolved implements the Resolvable interface.
+func (*Table) Resolved() bool {
+	return true
+}
+
+// Name implements the Nameable interface.
+func (t *Table) Name() string {
+	return t.name
+}
+
+// Schema implements the Node interface.
+func (t *Table) Schema() sql.Schema {
+	return t.schema
+}
+
+// Children implements the Node interface.
+func (t *Table) Children() []sql.Node {
+	return []sql.Node{}
+}
+
+// RowIter implements the Node interface. Rows are read from the file as the
+// iterator is advanced.
+func (t *Table) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+
+	f, err := os.Open(t.path)
+	if err != nil {
+		return nil, err
+	}
+
+	fi, err := f.Stat()
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+
+	iter := &rowIter{
+		t: t,
+		f: f,
+		r: t.newReader(io.LimitReader(f, fi.Size())),
+	}
+
+	if !t.opts.NoHeader {
+		if _, err := iter.r.Read(); err != nil && err != io.EOF {
+			_ = f.Close()
+			return nil, fmt.Errorf("csvdb: can't read header of %s: %s", t.path, err)
+		}
+		iter.line++
+	}
+
+	return iter, nil
+}
+
+// TransformUp implements the Transformable interface.
+func (t *Table) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(t)
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (t *Table) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return t
+}
+
+// Insert implements the sql.Inserter interface. The row is appended to the
+// file, or ErrReadOnly is returned if the database is not writable.
+func (t *Table) Insert(ctx *sql.Context, row sql.Row) error {
+	if !t.opts.Writable {
+		return ErrReadOnly
+	}
+
+	if len(row) != len(t.schema) {
+		return fmt.Errorf("insert expected %d values, got %d", len(t.schema), len(row))
+	}
+
+	record := make([]string, len(row))
+	for i, c := range t.schema {
+		if row[i] == nil {
+			if !c.Nullable {
+				return sql.ErrInvalidType
+			}
+			continue
+		}
+
+		if c.Type == sql.JSON {
+			doc, err := c.Type.Convert(row[i])
+			if err != nil {
+				return sql.ErrInvalidType
+			}
+
+			record[i] = c.Type.SQL(doc).ToString()
+			continue
+		}
+
+		v, err := c.Type.Convert(row[i])
+		if err != nil {
+			return sql.ErrInvalidType
+		}
+
+		record[i] = formatValue(v)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	f, err := os.OpenFile(t.path, os.O_RDWR|os.O_APPEND, 0)
+	if err != nil {
+		return err
+	}
+
+	w := bufio.NewWriter(f)
+	if err := endsWithNewline(f); err != nil {
+		if err != errNoNewline {
+			_ = f.Close()
+			return err
+		}
+		_ = w.WriteByte('\n')
+	}
+
+	cw := csv.NewWriter(w)
+	cw.Comma = t.comma
+	if err := cw.Write(record); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if err := w.Flush(); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	return f.Close()
+}
+
+var errNoNewline = fmt.Errorf("csvdb: file does not end with a newline")
+
+// endsWithNewline returns errNoNewline if the file is not empty and its last
+// line is not terminated, so the next row would be appended to it.
+func endsWithNewline(f *os.File) error {
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+
+	if fi.Size() == 0 {
+		return nil
+	}
+
+	var b [1]byte
+	if _, err := f.ReadAt(b[:], fi.Size()-1); err != nil {
+		return err
+	}
+
+	if b[0] != '\n' {
+		return errNoNewline
+	}
+
+	return nil
+}
+
+func (t *Table) newReader(r io.Reader) *csv.Reader {
+	cr := csv.NewReader(r)
+	cr.Comma = t.comma
+	cr.ReuseRecord = true
+	if t.comma == '\t' {
+		cr.LazyQuotes = true
+	}
+
+	return cr
+}
+
+// inferSchema reads the header and the first rows of the file to find the
+// names and types of its columns. A column takes the narrowest of BIGINT,
+// DOUBLE, BOOLEAN, TIMESTAMP and TEXT that can hold all its values, and
+// it's nullable if any of its values is empty.
+func (t *Table) inferSchema() (sql.Schema, error) {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	r := t.newReader(f)
+	var names []string
+	var kinds []kind
+	var nullable []bool
+	for i := 0; i <= t.opts.InferRows; i++ {
+		record, err := r.Read()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return nil, fmt.Errorf("csvdb: can't read %s: %s", t.path, err)
+		}
+
+		if names == nil {
+			names = make([]string, len(record))
+			kinds = make([]kind, len(record))
+			nullable = make([]bool, len(record))
+			for j := range record {
+				if t.opts.NoHeader {
+					names[j] = fmt.Sprintf("c%d", j+1)
+				} else {
+					names[j] = record[j]
+				}
+			}
+
+			if !t.opts.NoHeader {
+				continue
+			}
+		}
+
+		for j, v := range record {
+			if v == "" {
+				nullable[j] = true
+				continue
+			}
+
+			kinds[j] = kinds[j].widen(v)
+		}
+	}
+
+	schema := make(sql.Schema, len(names))
+	for i, name := range names {
+		schema[i] = &sql.Column{
+			Name:     name,
+			Type:     kinds[i].typ(),
+			Nullable: nullable[i],
+		}
+	}
+
+	return schema, nil
+}
+
+// kind is the type inferred for a column from the values seen so far.
+type kind int
+
+const (
+	kindUnknown kind = iota
+	kindInt
+	kindFloat
+	kindBool
+	kindTimestamp
+	kindText
+)
+
+// widen returns the narrowest kind that can hold both the values of k and v.
+// Integers can be widened to floats, and any kind to text.
+func (k kind) widen(v string) kind {
+	switch {
+	case k <= kindInt && isInt(v):
+		return kindInt
+	case k <= kindFloat && isFloat(v):
+		return kindFloat
+	case (k == kindUnknown || k == kindBool) && isBool(v):
+		return kindBool
+	case (k == kindUnknown || k == kindTimestamp) && isTimestamp(v):
+		return kindTimestamp
+	default:
+		return kindText
+	}
+}
+
+func isInt(v string) bool {
+	_, err := strconv.ParseInt(v, 10, 64)
+	return err == nil
+}
+
+func isFloat(v string) bool {
+	_, err := strconv.ParseFloat(v, 64)
+	return err == nil
+}
+
+func isBool(v string) bool {
+	return strings.EqualFold(v, "true") || strings.EqualFold(v, "false")
+}
+
+func isTimestamp(v string) bool {
+	_, err := time.Parse(sql.TimestampLayout, v)
+	return err == nil
+}
+
+func (k kind) typ() sql.Type {
+	switch k {
+	case kindInt:
+		return sql.Int64
+	case kindFloat:
+		return sql.Float64
+	case kindBool:
+		return sql.Boolean
+	case kindTimestamp:
+		return sql.Timestamp
+	default:
+		return sql.Text
+	}
+}
+
+// parseValue parses a field of the file as a value of the given type.
+func parseValue(typ sql.Type, s string) (interface{}, error) {
+	switch typ {
+	case sql.Float32, sql.Float64:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return typ.Convert(n)
+	case sql.Boolean:
+		return strconv.ParseBool(s)
+	case sql.Blob:
+		return []byte(s), nil
+	case sql.JSON:
+		return typ.Convert(s)
+	default:
+		return typ.Convert(s)
+	}
+}
+
+// formatValue formats a value as a field of the file.
+func formatValue(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	case time.Time:
+		return v.Format(sql.TimestampLayout)
+	case time.Duration:
+		return sql.Time.SQL(v).ToString()
+	case float32:
+		return strconv.FormatFloat(float64(v), 'g', -1, 32)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+type rowIter struct {
+	t    *Table
+	f    *os.File
+	r    *csv.Reader
+	line int
+}
+
+func (i *rowIter) Next() (sql.Row, error) {
+	record, err := i.r.Read()
+	if err == io.EOF {
+		return nil, io.EOF
+	}
+
+	i.line++
+	if err != nil {
+		return nil, fmt.Errorf("csvdb: can't read %s: %s", i.t.path, err)
+	}
+
+	schema := i.t.schema
+	if len(record) != len(schema) {
+		return nil, fmt.Errorf(
+			"csvdb: %s:%d: expected %d fields, got %d",
+			i.t.path, i.line, len(schema), len(record),
+		)
+	}
+
+	row := make(sql.Row, len(record))
+	for j, c := range schema {
+		if record[j] == "" && c.Nullable {
+			continue
+		}
+
+		v, err := parseValue(c.Type, record[j])
+		if err != nil {
+			return nil, fmt.Errorf(
+				"csvdb: %s:%d: invalid value for column %s: %q",
+				i.t.path, i.line, c.Name, record[j],
+			)
+		}
+
+		row[j] = v
+	}
+
+	return row, nil
+}
+
+func (i *rowIter) Close() error {
+	return i.f.Close()
+}
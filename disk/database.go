@@ -0,0 +1,409 @@
+// Package disk implements a database stored in a local directory.
+//
+// Every change is appended to a write-ahead log before it's applied, so it
+// survives restarts and crashes. From time to time the whole database is
+// written to a snapshot file and the log is truncated, so the log doesn't
+// grow forever. When a database is opened the snapshot is loaded and the log
+// replayed on top of it. A record torn by a crash at the end of the log is
+// discarded.
+package disk
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+const (
+	logFileName      = "wal"
+	snapshotFileName = "snapshot"
+)
+
+// DefaultSnapshotInterval is the default number of log records after which
+// a snapshot is taken.
+const DefaultSnapshotInterval = 10000
+
+// Options of a Database.
+type Options struct {
+	// SnapshotInterval is the number of log records after which a snapshot
+	// of the database is written and the log is truncated. If it's 0,
+	// DefaultSnapshotInterval is used. If it's negative, snapshots are only
+	// taken when Snapshot is called.
+	SnapshotInterval int
+	// NoSync disables syncing the log to disk after every write. Writes are
+	// faster, but the last ones may be lost if the machine crashes.
+	NoSync bool
+}
+
+// Database is a database stored in a directory. It is safe for concurrent
+// use.
+type Database struct {
+	name string
+	dir  string
+	opts Options
+
+	mu     sync.RWMutex
+	tables map[string]*Table
+	log    *os.File
+	// size is the size of the valid records of the log.
+	size int64
+	// lsn is the log sequence number of the last entry.
+	lsn uint64
+	// records is the number of records in the log since the last snapshot.
+	records int
+	closed  bool
+}
+
+// Open opens the database with the given name stored in dir, creating it if
+// it doesn't exist.
+func Open(name, dir string, opts Options) (*Database, error) {
+	if opts.SnapshotInterval == 0 {
+		opts.SnapshotInterval = DefaultSnapshotInterval
+	}
+
+	if err := os.MkdirAll(dir, 0755); err != nil {
+		return nil, err
+	}
+
+	d := &Database{
+		name:   name,
+		dir:    dir,
+		opts:   opts,
+		tables: make(map[string]*Table),
+	}
+
+	if err := d.loadSnapshot(); err != nil {
+		return nil, err
+	}
+
+	if err := d.replayLog(); err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// loadSnapshot loads the snapshot of the database, if there is one. As
+// snapshots are synced before they replace the previous one, any error in
+// them means the file was damaged.
+func (d *Database) loadSnapshot() error {
+	f, err := os.Open(filepath.Join(d.dir, snapshotFileName))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	for i := 0; ; i++ {
+		payload, err := readRecord(f)
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("disk: can't read snapshot: %s", err)
+		}
+
+		e, err := decodeEntry(payload)
+		if err != nil {
+			return fmt.Errorf("disk: can't read snapshot: %s", err)
+		}
+
+		if i == 0 {
+			if e.op != opSnapshot {
+				return fmt.Errorf("disk: invalid snapshot file")
+			}
+
+			d.lsn = e.lsn
+			continue
+		}
+
+		if err := d.apply(e); err != nil {
+			return err
+		}
+	}
+}
+
+// replayLog applies the entries of the log that are not in the snapshot.
+// The log is truncated after the last valid record, so a record torn by a
+// crash is discarded and new records are written after the valid ones.
+// Records with a valid checksum were committed, so it's an error if they
+// can't be decoded, and the log is left as it is.
+func (d *Database) replayLog() error {
+	f, err := os.OpenFile(filepath.Join(d.dir, logFileName), os.O_RDWR|os.O_CREATE, 0644)
+	if err != nil {
+		return err
+	}
+
+	snapshotLSN := d.lsn
+	for {
+		payload, err := readRecord(f)
+		if err == io.EOF || err == errCorruptRecord {
+			break
+		}
+
+		if err != nil {
+			_ = f.Close()
+			return err
+		}
+
+		e, err := decodeEntry(payload)
+		if err != nil {
+			_ = f.Close()
+			return fmt.Errorf("disk: can't read log: %s", err)
+		}
+
+		d.size += int64(recordHeaderSize + len(payload))
+		d.records++
+		if e.lsn <= snapshotLSN {
+			continue
+		}
+
+		if err := d.apply(e); err != nil {
+			_ = f.Close()
+			return err
+		}
+
+		d.lsn = e.lsn
+	}
+
+	if err := f.Truncate(d.size); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	if _, err := f.Seek(d.size, io.SeekStart); err != nil {
+		_ = f.Close()
+		return err
+	}
+
+	d.log = f
+	return nil
+}
+
+// apply applies an entry to the tables in memory.
+func (d *Database) apply(e *entry) error {
+	switch e.op {
+	case opCreateTable:
+		if _, ok := d.tables[e.table]; ok {
+			return fmt.Errorf("disk: table %s already exists", e.table)
+		}
+
+		d.tables[e.table] = newTable(d, e.table, e.schema)
+	case opInsert:
+		t, ok := d.tables[e.table]
+		if !ok {
+			return fmt.Errorf("disk: table not found: %s", e.table)
+		}
+
+		t.append(e.row)
+	default:
+		return fmt.Errorf("disk: unexpected operation %d", e.op)
+	}
+
+	return nil
+}
+
+// write appends the entry to the log and applies it. It must be called with
+// the lock held.
+func (d *Database) write(e *entry) error {
+	if d.closed {
+		return fmt.Errorf("disk: database %s is closed", d.name)
+	}
+
+	e.lsn = d.lsn + 1
+	payload, err := e.encode()
+	if err != nil {
+		return err
+	}
+
+	if err := writeRecord(d.log, payload); err != nil {
+		// Discard whatever part of the record was written.
+		_ = d.log.Truncate(d.size)
+		_, _ = d.log.Seek(d.size, io.SeekStart)
+		return err
+	}
+
+	if !d.opts.NoSync {
+		if err := d.log.Sync(); err != nil {
+			return err
+		}
+	}
+
+	d.size += int64(recordHeaderSize + len(payload))
+	d.lsn = e.lsn
+	d.records++
+
+	if err := d.apply(e); err != nil {
+		return err
+	}
+
+	if d.opts.SnapshotInterval > 0 && d.records >= d.opts.SnapshotInterval {
+		return d.snapshot()
+	}
+
+	return nil
+}
+
+// Name implements the sql.Database interface.
+func (d *Database) Name() string {
+	return d.name
+}
+
+// Tables implements the sql.Database interface.
+func (d *Database) Tables() map[string]sql.Table {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	tables := make(map[string]sql.Table, len(d.tables))
+	for name, t := range d.tables {
+		tables[name] = t
+	}
+
+	return tables
+}
+
+// CreateTable creates a new table with the given name and schema.
+func (d *Database) CreateTable(name string, schema sql.Schema) (*Table, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if _, ok := d.tables[name]; ok {
+		return nil, fmt.Errorf("disk: table %s already exists", name)
+	}
+
+	err := d.write(&entry{op: opCreateTable, table: name, schema: schema})
+	if err != nil {
+		return nil, err
+	}
+
+	return d.tables[name], nil
+}
+
+// Snapshot writes a snapshot of the database and truncates the log.
+func (d *Database) Snapshot() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return fmt.Errorf("disk: database %s is closed", d.name)
+	}
+
+	return d.snapshot()
+}
+
+// snapshot writes all the tables to a new snapshot file, which replaces the
+// previous one once it's synced, and then truncates the log. If the process
+// crashes before the log is truncated, the entries of the log already in the
+// snapshot are skipped on recovery by their LSN. It must be called with the
+// lock held.
+func (d *Database) snapshot() error {
+	path := filepath.Join(d.dir, snapshotFileName)
+	tmp := path + ".tmp"
+
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	if err := d.writeSnapshot(f); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return err
+	}
+
+	syncDir(d.dir)
+
+	if err := d.log.Truncate(0); err != nil {
+		return err
+	}
+
+	if _, err := d.log.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+
+	d.size = 0
+	d.records = 0
+	return d.log.Sync()
+}
+
+func (d *Database) writeSnapshot(f *os.File) error {
+	header, err := (&entry{lsn: d.lsn, op: opSnapshot}).encode()
+	if err != nil {
+		return err
+	}
+
+	if err := writeRecord(f, header); err != nil {
+		return err
+	}
+
+	names := make([]string, 0, len(d.tables))
+	for name := range d.tables {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		t := d.tables[name]
+		entries := []*entry{{op: opCreateTable, table: name, schema: t.schema}}
+		for _, row := range t.rows() {
+			entries = append(entries, &entry{op: opInsert, table: name, row: row})
+		}
+
+		for _, e := range entries {
+			payload, err := e.encode()
+			if err != nil {
+				return err
+			}
+
+			if err := writeRecord(f, payload); err != nil {
+				return err
+			}
+		}
+	}
+
+	return f.Sync()
+}
+
+// syncDir syncs a directory so a file renamed in it is durable. Not all
+// platforms support it, so errors are ignored.
+func syncDir(dir string) {
+	f, err := os.Open(dir)
+	if err != nil {
+		return
+	}
+
+	_ = f.Sync()
+	_ = f.Close()
+}
+
+// Close closes the log of the database. The database can't be modified
+// after it's closed.
+func (d *Database) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed {
+		return nil
+	}
+
+	d.closed = true
+	return d.log.Close()
+}
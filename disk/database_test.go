@@ -0,0 +1,324 @@
+package disk
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testSchema = sql.Schema{
+	{Name: "i", Type: sql.Int64},
+	{Name: "s", Type: sql.Text, Nullable: true},
+}
+
+func TestDatabase(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "disk")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	ctx := sql.NewEmptyContext()
+	db, err := Open("mydb", dir, Options{})
+	require.NoError(err)
+	require.Equal("mydb", db.Name())
+
+	table, err := db.CreateTable("mytable", testSchema)
+	require.NoError(err)
+	require.Equal("mytable", table.Name())
+	require.Equal(testSchema, table.Schema())
+
+	_, err = db.CreateTable("mytable", testSchema)
+	require.Error(err)
+
+	require.NoError(table.Insert(ctx, sql.NewRow(int64(1), "a")))
+	require.NoError(table.Insert(ctx, sql.NewRow(2, nil)))
+	require.Error(table.Insert(ctx, sql.NewRow(nil, "c")))
+	require.Error(table.Insert(ctx, sql.NewRow(int64(3))))
+
+	expected := []sql.Row{{int64(1), "a"}, {int64(2), nil}}
+	rows, err := sql.NodeToRows(ctx, table)
+	require.NoError(err)
+	require.Equal(expected, rows)
+	require.NoError(db.Close())
+	require.Error(table.Insert(ctx, sql.NewRow(int64(3), "c")))
+
+	db, err = Open("mydb", dir, Options{})
+	require.NoError(err)
+	defer db.Close()
+
+	tables := db.Tables()
+	require.Len(tables, 1)
+	require.Equal(testSchema, tables["mytable"].Schema())
+
+	rows, err = sql.NodeToRows(ctx, tables["mytable"])
+	require.NoError(err)
+	require.Equal(expected, rows)
+}
+
+func TestDatabase_Types(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "disk")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	schema := sql.Schema{
+		{Name: "a", Type: sql.Decimal(5, 2)},
+		{Name: "b", Type: sql.Datetime(0)},
+		{Name: "c", Type: sql.Time},
+		{Name: "d", Type: sql.Year},
+		{Name: "e", Type: sql.Varchar(3)},
+		{Name: "f", Type: mustType(sql.CreateEnum([]string{"x", "y"}))},
+		{Name: "g", Type: mustType(sql.CreateSet([]string{"x", "y"}))},
+		{Name: "h", Type: sql.Bit(4)},
+	}
+
+	ctx := sql.NewEmptyContext()
+	db, err := Open("mydb", dir, Options{})
+	require.NoError(err)
+
+	table, err := db.CreateTable("mytable", schema)
+	require.NoError(err)
+	require.NoError(table.Insert(ctx, sql.NewRow(
+		"1.5", "2018-01-02 03:04:05", "10:20:30", 2018, "abc", "y", "x,y", 5,
+	)))
+
+	expected, err := sql.NodeToRows(ctx, table)
+	require.NoError(err)
+	require.NoError(db.Close())
+
+	db, err = Open("mydb", dir, Options{})
+	require.NoError(err)
+	defer db.Close()
+
+	require.Equal(schema, db.Tables()["mytable"].Schema())
+	rows, err := sql.NodeToRows(ctx, db.Tables()["mytable"])
+	require.NoError(err)
+	require.Equal(expected, rows)
+}
+
+func TestDatabase_Snapshot(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "disk")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	ctx := sql.NewEmptyContext()
+	db, err := Open("mydb", dir, Options{SnapshotInterval: 4})
+	require.NoError(err)
+
+	table, err := db.CreateTable("mytable", testSchema)
+	require.NoError(err)
+
+	var expected []sql.Row
+	for i := 0; i < 10; i++ {
+		row := sql.NewRow(int64(i), "foo")
+		require.NoError(table.Insert(ctx, row))
+		expected = append(expected, row)
+	}
+
+	// 11 records were written, 8 of them are in the snapshot.
+	require.Equal(3, db.records)
+	require.NoError(db.Close())
+
+	db, err = Open("mydb", dir, Options{SnapshotInterval: 4})
+	require.NoError(err)
+	defer db.Close()
+
+	rows, err := sql.NodeToRows(ctx, db.Tables()["mytable"])
+	require.NoError(err)
+	require.Equal(expected, rows)
+}
+
+func TestDatabase_SnapshotBeforeLogTruncate(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "disk")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	ctx := sql.NewEmptyContext()
+	db, err := Open("mydb", dir, Options{SnapshotInterval: -1})
+	require.NoError(err)
+
+	table, err := db.CreateTable("mytable", testSchema)
+	require.NoError(err)
+	require.NoError(table.Insert(ctx, sql.NewRow(int64(1), "a")))
+
+	log, err := ioutil.ReadFile(filepath.Join(dir, logFileName))
+	require.NoError(err)
+
+	require.NoError(db.Snapshot())
+	require.NoError(table.Insert(ctx, sql.NewRow(int64(2), "b")))
+	require.NoError(db.Close())
+
+	// Simulate a crash after the snapshot was written but before the log
+	// was truncated.
+	newLog, err := ioutil.ReadFile(filepath.Join(dir, logFileName))
+	require.NoError(err)
+	require.NoError(ioutil.WriteFile(
+		filepath.Join(dir, logFileName),
+		append(log, newLog...),
+		0644,
+	))
+
+	db, err = Open("mydb", dir, Options{})
+	require.NoError(err)
+	defer db.Close()
+
+	rows, err := sql.NodeToRows(ctx, db.Tables()["mytable"])
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(1), "a"}, {int64(2), "b"}}, rows)
+}
+
+func TestDatabase_Recovery(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "disk")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	ctx := sql.NewEmptyContext()
+	db, err := Open("mydb", dir, Options{NoSync: true})
+	require.NoError(err)
+
+	table, err := db.CreateTable("mytable", testSchema)
+	require.NoError(err)
+
+	// ends holds the size of the log after each record.
+	ends := []int64{db.size}
+	const n = 10
+	for i := 0; i < n; i++ {
+		require.NoError(table.Insert(ctx, sql.NewRow(int64(i), "foo")))
+		ends = append(ends, db.size)
+	}
+	require.NoError(db.Close())
+
+	log, err := ioutil.ReadFile(filepath.Join(dir, logFileName))
+	require.NoError(err)
+	require.Equal(ends[n], int64(len(log)))
+
+	for size := 0; size <= len(log); size++ {
+		crashDir, err := ioutil.TempDir("", "disk")
+		require.NoError(err)
+
+		path := filepath.Join(crashDir, logFileName)
+		require.NoError(ioutil.WriteFile(path, log[:size], 0644))
+
+		// The number of complete insert records in the truncated log.
+		complete := -1
+		for _, end := range ends {
+			if end <= int64(size) {
+				complete++
+			}
+		}
+
+		db, err := Open("mydb", crashDir, Options{NoSync: true})
+		require.NoError(err, "size %d", size)
+
+		table, ok := db.Tables()["mytable"]
+		if complete < 0 {
+			require.False(ok, "size %d", size)
+			require.NoError(db.Close())
+			require.NoError(os.RemoveAll(crashDir))
+			continue
+		}
+
+		rows, err := sql.NodeToRows(ctx, table)
+		require.NoError(err)
+		require.Len(rows, complete, "size %d", size)
+
+		// The database must be writable after recovery, and the new rows
+		// must survive another restart.
+		require.NoError(table.(*Table).Insert(ctx, sql.NewRow(int64(-1), "bar")))
+		require.NoError(db.Close())
+
+		db, err = Open("mydb", crashDir, Options{NoSync: true})
+		require.NoError(err)
+		rows, err = sql.NodeToRows(ctx, db.Tables()["mytable"])
+		require.NoError(err)
+		require.Len(rows, complete+1, "size %d", size)
+		require.Equal(sql.NewRow(int64(-1), "bar"), rows[complete])
+		require.NoError(db.Close())
+
+		require.NoError(os.RemoveAll(crashDir))
+	}
+}
+
+func TestDatabase_CorruptLog(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "disk")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	ctx := sql.NewEmptyContext()
+	db, err := Open("mydb", dir, Options{})
+	require.NoError(err)
+
+	table, err := db.CreateTable("mytable", testSchema)
+	require.NoError(err)
+	require.NoError(table.Insert(ctx, sql.NewRow(int64(1), "a")))
+	require.NoError(table.Insert(ctx, sql.NewRow(int64(2), "b")))
+	require.NoError(db.Close())
+
+	// Flip a byte of the payload of the last record.
+	path := filepath.Join(dir, logFileName)
+	log, err := ioutil.ReadFile(path)
+	require.NoError(err)
+	log[len(log)-1] ^= 0xff
+	require.NoError(ioutil.WriteFile(path, log, 0644))
+
+	db, err = Open("mydb", dir, Options{})
+	require.NoError(err)
+	defer db.Close()
+
+	rows, err := sql.NodeToRows(ctx, db.Tables()["mytable"])
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(1), "a"}}, rows)
+}
+
+func TestDatabase_UndecodableLog(t *testing.T) {
+	require := require.New(t)
+
+	dir, err := ioutil.TempDir("", "disk")
+	require.NoError(err)
+	defer os.RemoveAll(dir)
+
+	ctx := sql.NewEmptyContext()
+	db, err := Open("mydb", dir, Options{})
+	require.NoError(err)
+
+	table, err := db.CreateTable("mytable", testSchema)
+	require.NoError(err)
+	require.NoError(table.Insert(ctx, sql.NewRow(int64(1), "a")))
+	require.NoError(db.Close())
+
+	// Append a record with a valid checksum and an unknown operation,
+	// followed by a valid record.
+	path := filepath.Join(dir, logFileName)
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0644)
+	require.NoError(err)
+	require.NoError(writeRecord(f, []byte{3, 0xff}))
+	payload, err := (&entry{lsn: 4, op: opInsert, table: "mytable", row: sql.NewRow(int64(2), "b")}).encode()
+	require.NoError(err)
+	require.NoError(writeRecord(f, payload))
+	require.NoError(f.Close())
+
+	before, err := ioutil.ReadFile(path)
+	require.NoError(err)
+
+	_, err = Open("mydb", dir, Options{})
+	require.Error(err)
+
+	after, err := ioutil.ReadFile(path)
+	require.NoError(err)
+	require.Equal(before, after)
+}
@@ -0,0 +1,444 @@
+package disk
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+	"reflect"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/decimal"
+	"github.com/src-d/go-mysql-server/sql/parse"
+
+	"github.com/src-d/go-vitess/vt/proto/query"
+)
+
+// Operations stored in the records of the log and snapshot files.
+const (
+	// opSnapshot is the first record of a snapshot file. Its LSN is the one
+	// of the last log record included in the snapshot.
+	opSnapshot byte = iota + 1
+	// opCreateTable creates a table with a schema.
+	opCreateTable
+	// opInsert inserts a row in a table.
+	opInsert
+)
+
+// entry is a change to a database, stored as the payload of a record.
+type entry struct {
+	// lsn is the log sequence number of the entry. Entries in the log have
+	// increasing LSNs.
+	lsn    uint64
+	op     byte
+	table  string
+	schema sql.Schema
+	row    sql.Row
+}
+
+func (e *entry) encode() ([]byte, error) {
+	var enc encoder
+	enc.uvarint(e.lsn)
+	enc.buf.WriteByte(e.op)
+	enc.string(e.table)
+
+	switch e.op {
+	case opCreateTable:
+		if err := enc.schema(e.schema); err != nil {
+			return nil, err
+		}
+	case opInsert:
+		enc.uvarint(uint64(len(e.row)))
+		for _, v := range e.row {
+			if err := enc.value(v); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	return enc.buf.Bytes(), nil
+}
+
+func decodeEntry(b []byte) (*entry, error) {
+	d := &decoder{r: bytes.NewReader(b)}
+	e := &entry{lsn: d.uvarint()}
+	e.op = d.byte()
+	e.table = d.string()
+
+	switch e.op {
+	case opSnapshot:
+	case opCreateTable:
+		e.schema = d.schema()
+	case opInsert:
+		n := d.uvarint()
+		if d.err == nil && n > uint64(len(b)) {
+			d.err = errCorruptRecord
+		}
+
+		for i := uint64(0); i < n && d.err == nil; i++ {
+			e.row = append(e.row, d.value())
+		}
+	default:
+		if d.err == nil {
+			d.err = fmt.Errorf("disk: unknown operation %d", e.op)
+		}
+	}
+
+	if d.err != nil {
+		return nil, d.err
+	}
+
+	return e, nil
+}
+
+// Tags of the encoded values, which tell the Go type of the value.
+const (
+	tagNil byte = iota
+	tagInt8
+	tagInt16
+	tagInt32
+	tagInt64
+	tagUint8
+	tagUint16
+	tagUint32
+	tagUint64
+	tagFloat32
+	tagFloat64
+	tagBool
+	tagString
+	tagBytes
+	tagTime
+	tagDecimal
+	tagDuration
+)
+
+type encoder struct {
+	buf bytes.Buffer
+}
+
+func (e *encoder) uvarint(v uint64) {
+	var b [binary.MaxVarintLen64]byte
+	e.buf.Write(b[:binary.PutUvarint(b[:], v)])
+}
+
+func (e *encoder) varint(v int64) {
+	var b [binary.MaxVarintLen64]byte
+	e.buf.Write(b[:binary.PutVarint(b[:], v)])
+}
+
+func (e *encoder) bytes(b []byte) {
+	e.uvarint(uint64(len(b)))
+	e.buf.Write(b)
+}
+
+func (e *encoder) string(s string) {
+	e.uvarint(uint64(len(s)))
+	e.buf.WriteString(s)
+}
+
+func (e *encoder) schema(s sql.Schema) error {
+	e.uvarint(uint64(len(s)))
+	for _, c := range s {
+		name, err := typeName(c.Type)
+		if err != nil {
+			return err
+		}
+
+		e.string(c.Name)
+		e.string(name)
+		if c.Nullable {
+			e.buf.WriteByte(1)
+		} else {
+			e.buf.WriteByte(0)
+		}
+	}
+
+	return nil
+}
+
+func (e *encoder) value(v interface{}) error {
+	switch v := v.(type) {
+	case nil:
+		e.buf.WriteByte(tagNil)
+	case int8:
+		e.buf.WriteByte(tagInt8)
+		e.varint(int64(v))
+	case int16:
+		e.buf.WriteByte(tagInt16)
+		e.varint(int64(v))
+	case int32:
+		e.buf.WriteByte(tagInt32)
+		e.varint(int64(v))
+	case int64:
+		e.buf.WriteByte(tagInt64)
+		e.varint(v)
+	case uint8:
+		e.buf.WriteByte(tagUint8)
+		e.uvarint(uint64(v))
+	case uint16:
+		e.buf.WriteByte(tagUint16)
+		e.uvarint(uint64(v))
+	case uint32:
+		e.buf.WriteByte(tagUint32)
+		e.uvarint(uint64(v))
+	case uint64:
+		e.buf.WriteByte(tagUint64)
+		e.uvarint(v)
+	case float32:
+		e.buf.WriteByte(tagFloat32)
+		e.uvarint(uint64(math.Float32bits(v)))
+	case float64:
+		e.buf.WriteByte(tagFloat64)
+		e.uvarint(math.Float64bits(v))
+	case bool:
+		e.buf.WriteByte(tagBool)
+		if v {
+			e.buf.WriteByte(1)
+		} else {
+			e.buf.WriteByte(0)
+		}
+	case string:
+		e.buf.WriteByte(tagString)
+		e.string(v)
+	case []byte:
+		e.buf.WriteByte(tagBytes)
+		e.bytes(v)
+	case time.Time:
+		b, err := v.MarshalBinary()
+		if err != nil {
+			return err
+		}
+
+		e.buf.WriteByte(tagTime)
+		e.bytes(b)
+	case decimal.Decimal:
+		e.buf.WriteByte(tagDecimal)
+		e.string(v.String())
+	case time.Duration:
+		e.buf.WriteByte(tagDuration)
+		e.varint(int64(v))
+	default:
+		return fmt.Errorf("disk: can't encode value of type %T", v)
+	}
+
+	return nil
+}
+
+// decoder reads the values written by an encoder. Once an error is found,
+// the following reads return zero values and the error is kept in err.
+type decoder struct {
+	r   *bytes.Reader
+	err error
+}
+
+func (d *decoder) fail(err error) {
+	if d.err == nil {
+		d.err = err
+	}
+}
+
+func (d *decoder) byte() byte {
+	if d.err != nil {
+		return 0
+	}
+
+	b, err := d.r.ReadByte()
+	if err != nil {
+		d.fail(errCorruptRecord)
+	}
+
+	return b
+}
+
+func (d *decoder) uvarint() uint64 {
+	if d.err != nil {
+		return 0
+	}
+
+	v, err := binary.ReadUvarint(d.r)
+	if err != nil {
+		d.fail(errCorruptRecord)
+	}
+
+	return v
+}
+
+func (d *decoder) varint() int64 {
+	if d.err != nil {
+		return 0
+	}
+
+	v, err := binary.ReadVarint(d.r)
+	if err != nil {
+		d.fail(errCorruptRecord)
+	}
+
+	return v
+}
+
+func (d *decoder) bytes() []byte {
+	n := d.uvarint()
+	if d.err != nil {
+		return nil
+	}
+
+	if n > uint64(d.r.Len()) {
+		d.fail(errCorruptRecord)
+		return nil
+	}
+
+	b := make([]byte, n)
+	_, _ = d.r.Read(b)
+	return b
+}
+
+func (d *decoder) string() string {
+	return string(d.bytes())
+}
+
+func (d *decoder) schema() sql.Schema {
+	n := d.uvarint()
+	var s sql.Schema
+	for i := uint64(0); i < n && d.err == nil; i++ {
+		name := d.string()
+		typ, err := typeFromName(d.string())
+		if err != nil {
+			d.fail(err)
+		}
+
+		s = append(s, &sql.Column{
+			Name:     name,
+			Type:     typ,
+			Nullable: d.byte() == 1,
+		})
+	}
+
+	return s
+}
+
+func (d *decoder) value() interface{} {
+	switch tag := d.byte(); tag {
+	case tagNil:
+		return nil
+	case tagInt8:
+		return int8(d.varint())
+	case tagInt16:
+		return int16(d.varint())
+	case tagInt32:
+		return int32(d.varint())
+	case tagInt64:
+		return d.varint()
+	case tagUint8:
+		return uint8(d.uvarint())
+	case tagUint16:
+		return uint16(d.uvarint())
+	case tagUint32:
+		return uint32(d.uvarint())
+	case tagUint64:
+		return d.uvarint()
+	case tagFloat32:
+		return math.Float32frombits(uint32(d.uvarint()))
+	case tagFloat64:
+		return math.Float64frombits(d.uvarint())
+	case tagBool:
+		return d.byte() == 1
+	case tagString:
+		return d.string()
+	case tagBytes:
+		return d.bytes()
+	case tagTime:
+		var t time.Time
+		if err := t.UnmarshalBinary(d.bytes()); err != nil {
+			d.fail(errCorruptRecord)
+		}
+		return t
+	case tagDecimal:
+		v, err := decimal.Parse(d.string())
+		if err != nil {
+			d.fail(errCorruptRecord)
+		}
+		return v
+	case tagDuration:
+		return time.Duration(d.varint())
+	default:
+		d.fail(errors.New("disk: unknown value tag"))
+		return nil
+	}
+}
+
+// types are the column types stored by the name of their query.Type, as
+// all the types were stored before types had parameters.
+var types = map[query.Type]sql.Type{}
+
+func init() {
+	for _, t := range []sql.Type{
+		sql.Null,
+		sql.Int8,
+		sql.Int16,
+		sql.Int24,
+		sql.Int32,
+		sql.Int64,
+		sql.Uint8,
+		sql.Uint16,
+		sql.Uint24,
+		sql.Uint32,
+		sql.Uint64,
+		sql.Float32,
+		sql.Float64,
+		sql.Timestamp,
+		sql.Text,
+		sql.Blob,
+		sql.JSON,
+	} {
+		types[t.Type()] = t
+	}
+}
+
+// booleanTypeName is the name booleans are stored with. It's the name of
+// the BIT query.Type, which was their type before they became TINYINT(1)
+// values, so they are not confused with INT8 columns.
+const booleanTypeName = "BIT"
+
+// typeName returns the name a type is stored with. Types that are not in
+// types are stored as they are written in column definitions, such as
+// decimal(5,2), in lowercase so they are not confused with the names of
+// query.Types.
+func typeName(t sql.Type) (string, error) {
+	if t == sql.Boolean {
+		return booleanTypeName, nil
+	}
+
+	if types[t.Type()] == t {
+		return t.Type().String(), nil
+	}
+
+	name := sql.MySQLTypeName(t)
+	if st, ok := t.(sql.StringType); ok && sql.IsText(st) {
+		name += fmt.Sprintf(" character set %s collate %s", st.CharacterSet(), st.Collation())
+	}
+
+	if typ, err := parse.ColumnType(name); err != nil || !reflect.DeepEqual(typ, t) {
+		return "", fmt.Errorf("disk: unsupported column type %s", name)
+	}
+
+	return name, nil
+}
+
+func typeFromName(name string) (sql.Type, error) {
+	if name == booleanTypeName {
+		return sql.Boolean, nil
+	}
+
+	if t, ok := types[query.Type(query.Type_value[name])]; ok && t.Type().String() == name {
+		return t, nil
+	}
+
+	t, err := parse.ColumnType(name)
+	if err != nil {
+		return nil, fmt.Errorf("disk: unknown column type %s: %s", name, err)
+	}
+
+	return t, nil
+}
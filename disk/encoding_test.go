@@ -0,0 +1,68 @@
+package disk
+
+import (
+	"testing"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/decimal"
+
+	"github.com/src-d/go-vitess/sqltypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEntryEncoding(t *testing.T) {
+	require := require.New(t)
+
+	now := time.Date(2018, 1, 2, 3, 4, 5, 6, time.UTC)
+	entries := []*entry{
+		{lsn: 1, op: opSnapshot},
+		{lsn: 2, op: opCreateTable, table: "foo", schema: sql.Schema{
+			{Name: "a", Type: sql.Int32, Nullable: true},
+			{Name: "b", Type: sql.Text},
+			{Name: "c", Type: sql.Boolean},
+			{Name: "d", Type: sql.JSON},
+			{Name: "e", Type: sql.Decimal(10, 2)},
+			{Name: "f", Type: sql.Datetime(3)},
+			{Name: "g", Type: sql.Date},
+			{Name: "h", Type: sql.Time},
+			{Name: "i", Type: sql.Year},
+			{Name: "j", Type: sql.Varchar(20)},
+			{Name: "k", Type: mustType(sql.CreateString(sqltypes.Char, 5, "latin1", ""))},
+			{Name: "l", Type: sql.Varbinary(8)},
+			{Name: "m", Type: mustType(sql.CreateEnum([]string{"a", "b"}))},
+			{Name: "n", Type: mustType(sql.CreateSet([]string{"x", "y"}))},
+			{Name: "o", Type: sql.Bit(3)},
+			{Name: "p", Type: sql.LongText},
+		}},
+		{lsn: 3, op: opInsert, table: "foo", row: sql.NewRow(
+			nil, int8(-1), int16(-2), int32(-3), int64(-4),
+			uint8(1), uint16(2), uint32(3), uint64(1<<63),
+			float32(1.5), float64(-2.5), true, false,
+			"foo", []byte("bar"), now, decimal.MustParse("-12.50"),
+			90*time.Minute,
+		)},
+	}
+
+	for _, e := range entries {
+		b, err := e.encode()
+		require.NoError(err)
+
+		d, err := decodeEntry(b)
+		require.NoError(err)
+		require.Equal(e, d)
+
+		_, err = decodeEntry(b[:len(b)-1])
+		require.Error(err)
+	}
+
+	_, err := (&entry{op: opInsert, row: sql.NewRow(struct{}{})}).encode()
+	require.Error(err)
+}
+
+func mustType(t sql.Type, err error) sql.Type {
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
@@ -0,0 +1,76 @@
+package disk
+
+import (
+	"encoding/binary"
+	"errors"
+	"hash/crc32"
+	"io"
+)
+
+// The log and snapshot files are sequences of records. Each record is
+// framed as:
+//
+//   length   uint32, little endian, length of the payload
+//   checksum uint32, little endian, CRC-32 (IEEE) of the payload
+//   payload  [length]byte
+//
+// A record is only valid if it's complete and its checksum matches, so a
+// record torn by a crash in the middle of a write is detected on recovery.
+
+const (
+	recordHeaderSize = 8
+	maxRecordSize    = 1 << 30
+)
+
+// errCorruptRecord is returned when a record is incomplete or its checksum
+// does not match its payload.
+var errCorruptRecord = errors.New("disk: corrupt record")
+
+// writeRecord writes the payload to w framed as a record.
+func writeRecord(w io.Writer, payload []byte) error {
+	buf := make([]byte, recordHeaderSize+len(payload))
+	binary.LittleEndian.PutUint32(buf[0:4], uint32(len(payload)))
+	binary.LittleEndian.PutUint32(buf[4:8], crc32.ChecksumIEEE(payload))
+	copy(buf[recordHeaderSize:], payload)
+
+	_, err := w.Write(buf)
+	return err
+}
+
+// readRecord reads the next record from r and returns its payload. It
+// returns io.EOF if there are no more records, and errCorruptRecord if the
+// next record is not valid.
+func readRecord(r io.Reader) ([]byte, error) {
+	var header [recordHeaderSize]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil {
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+
+		if err == io.ErrUnexpectedEOF {
+			return nil, errCorruptRecord
+		}
+
+		return nil, err
+	}
+
+	length := binary.LittleEndian.Uint32(header[0:4])
+	if length > maxRecordSize {
+		return nil, errCorruptRecord
+	}
+
+	payload := make([]byte, length)
+	if _, err := io.ReadFull(r, payload); err != nil {
+		if err == io.EOF || err == io.ErrUnexpectedEOF {
+			return nil, errCorruptRecord
+		}
+
+		return nil, err
+	}
+
+	if crc32.ChecksumIEEE(payload) != binary.LittleEndian.Uint32(header[4:8]) {
+		return nil, errCorruptRecord
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,115 @@
+package disk
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// Table is a table of a Database. Its rows are kept in memory and every
+// insert is written to the log of the database. Iterators return the rows
+// the table had when they were created.
+type Table struct {
+	db     *Database
+	name   string
+	schema sql.Schema
+
+	// mu guards data. Rows are only appended, never modified, so a prefix
+	// of data can be read without holding it.
+	mu   sync.RWMutex
+	data []sql.Row
+}
+
+func newTable(db *Database, name string, schema sql.Schema) *Table {
+	return &Table{db: db, name: name, schema: schema}
+}
+
+// Resolved implements the Resolvable interface.
+func (*Table) Resolved() bool {
+	return true
+}
+
+// Name implements the Nameable interface.
+func (t *Table) Name() string {
+	return t.name
+}
+
+// Schema implements the Node interface.
+func (t *Table) Schema() sql.Schema {
+	return t.schema
+}
+
+// Children implements the Node interface.
+func (t *Table) Children() []sql.Node {
+	return []sql.Node{}
+}
+
+// RowIter implements the Node interface.
+func (t *Table) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	return sql.RowsToRowIter(t.rows()...), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (t *Table) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(t)
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (t *Table) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return t
+}
+
+// Insert implements the sql.Inserter interface. The values of the row are
+// converted to the types of their columns and the row is written to the log
+// before it's visible.
+func (t *Table) Insert(ctx *sql.Context, row sql.Row) error {
+	if len(row) != len(t.schema) {
+		return fmt.Errorf("insert expected %d values, got %d", len(t.schema), len(row))
+	}
+
+	values := make(sql.Row, len(row))
+	for i, c := range t.schema {
+		if row[i] == nil {
+			if !c.Nullable {
+				return sql.ErrInvalidType
+			}
+			continue
+		}
+
+		// JSON documents are stored as their JSON text, which the JSON type
+		// reads back as the same documents.
+		if c.Type == sql.JSON {
+			doc, err := c.Type.Convert(row[i])
+			if err != nil {
+				return sql.ErrInvalidType
+			}
+
+			values[i] = []byte(c.Type.SQL(doc).Raw())
+			continue
+		}
+
+		v, err := c.Type.Convert(row[i])
+		if err != nil {
+			return sql.ErrInvalidType
+		}
+
+		values[i] = v
+	}
+
+	t.db.mu.Lock()
+	defer t.db.mu.Unlock()
+	return t.db.write(&entry{op: opInsert, table: t.name, row: values})
+}
+
+func (t *Table) rows() []sql.Row {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.data[:len(t.data):len(t.data)]
+}
+
+func (t *Table) append(row sql.Row) {
+	t.mu.Lock()
+	t.data = append(t.data, row)
+	t.mu.Unlock()
+}
@@ -0,0 +1,461 @@
+package sqle
+
+import (
+	"context"
+	gosql "database/sql"
+	"database/sql/driver"
+	"fmt"
+	"math"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/src-d/go-vitess/sqltypes"
+)
+
+// lastConnectionID is the id of the last session created by the driver.
+var lastConnectionID uint32
+
+// lastDriverID is the id of the last engine registered by OpenDB.
+var lastDriverID uint32
+
+// OpenDB returns a database/sql handle of the engine. The first time it's
+// called, the engine is registered as a database/sql driver with a name
+// such as sqle_1. Every connection of the handle gets its own session.
+func (e *Engine) OpenDB() (*gosql.DB, error) {
+	e.register.Do(func() {
+		id := atomic.AddUint32(&lastDriverID, 1)
+		e.driverName = fmt.Sprintf("sqle_%d", id)
+		gosql.Register(e.driverName, e)
+	})
+
+	return gosql.Open(e.driverName, "")
+}
+
+// Open implements the driver.Driver interface. Every connection gets its
+// own session. All connections share the catalog of the engine, so the name
+// is ignored.
+func (e *Engine) Open(name string) (driver.Conn, error) {
+	id := atomic.AddUint32(&lastConnectionID, 1)
+	return &conn{e: e, session: e.NewSession(id)}, nil
+}
+
+// conn is a connection to an Engine. It implements the driver.Conn interface.
+type conn struct {
+	e       *Engine
+	session sql.Session
+}
+
+// Prepare implements the driver.Conn interface.
+func (c *conn) Prepare(query string) (driver.Stmt, error) {
+	return c.PrepareContext(context.Background(), query)
+}
+
+// PrepareContext implements the driver.ConnPrepareContext interface.
+func (c *conn) PrepareContext(ctx context.Context, query string) (driver.Stmt, error) {
+	p, err := c.e.Prepare(sql.NewContext(ctx, c.session), query)
+	if err != nil {
+		return nil, err
+	}
+
+	return &stmt{c: c, p: p}, nil
+}
+
+// Close implements the driver.Conn interface.
+func (c *conn) Close() error {
+	return nil
+}
+
+// Begin implements the driver.Conn interface.
+func (c *conn) Begin() (driver.Tx, error) {
+	if err := c.session.BeginTransaction(); err != nil {
+		return nil, err
+	}
+
+	return &tx{c}, nil
+}
+
+// tx is a transaction of a connection. It implements the driver.Tx
+// interface.
+type tx struct {
+	c *conn
+}
+
+// Commit implements the driver.Tx interface.
+func (t *tx) Commit() error {
+	return t.c.session.CommitTransaction()
+}
+
+// Rollback implements the driver.Tx interface.
+func (t *tx) Rollback() error {
+	return t.c.session.RollbackTransaction()
+}
+
+// QueryContext implements the driver.QueryerContext interface.
+func (c *conn) QueryContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Rows, error) {
+	p, err := c.e.Prepare(sql.NewContext(ctx, c.session), query)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.query(ctx, p, args)
+}
+
+func (c *conn) query(
+	ctx context.Context,
+	p *PreparedQuery,
+	args []driver.NamedValue,
+) (driver.Rows, error) {
+	schema, iter, err := p.Query(sql.NewContext(ctx, c.session), namedValuesToBindings(args))
+	if err != nil {
+		return nil, err
+	}
+
+	// Statements that don't return rows are executed when their result is
+	// read, and they are returned as an empty set of rows.
+	if sql.IsOkResult(schema) {
+		if _, err := sql.RowIterToRows(iter); err != nil {
+			return nil, err
+		}
+
+		return &rows{ctx: ctx, iter: sql.RowsToRowIter()}, nil
+	}
+
+	return &rows{ctx: ctx, schema: schema, iter: iter}, nil
+}
+
+// ExecContext implements the driver.ExecerContext interface.
+func (c *conn) ExecContext(
+	ctx context.Context,
+	query string,
+	args []driver.NamedValue,
+) (driver.Result, error) {
+	p, err := c.e.Prepare(sql.NewContext(ctx, c.session), query)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.exec(ctx, p, args)
+}
+
+func (c *conn) exec(
+	ctx context.Context,
+	p *PreparedQuery,
+	args []driver.NamedValue,
+) (driver.Result, error) {
+	schema, iter, err := p.Query(sql.NewContext(ctx, c.session), namedValuesToBindings(args))
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := sql.RowIterToRows(iter)
+	if err != nil {
+		return nil, err
+	}
+
+	if sql.IsOkResult(schema) && len(rows) == 1 {
+		if r, ok := sql.GetOkResult(rows[0]); ok {
+			return result{r}, nil
+		}
+	}
+
+	return driver.RowsAffected(0), nil
+}
+
+// result is the result of a statement that modifies data. It implements the
+// driver.Result interface.
+type result struct {
+	ok sql.OkResult
+}
+
+// LastInsertId implements the driver.Result interface.
+func (r result) LastInsertId() (int64, error) {
+	return int64(r.ok.InsertID), nil
+}
+
+// RowsAffected implements the driver.Result interface.
+func (r result) RowsAffected() (int64, error) {
+	return int64(r.ok.RowsAffected), nil
+}
+
+// namedValuesToBindings returns the values of the bind variables of a query
+// by name. Positional arguments are bound to the ? or :vN placeholders and
+// named arguments to the :name placeholders.
+func namedValuesToBindings(args []driver.NamedValue) map[string]interface{} {
+	bindings := make(map[string]interface{}, len(args))
+	for _, arg := range args {
+		name := arg.Name
+		if name == "" {
+			name = fmt.Sprintf("v%d", arg.Ordinal)
+		}
+
+		bindings[name] = arg.Value
+	}
+
+	return bindings
+}
+
+// stmt is a prepared statement. It implements the driver.Stmt interface.
+type stmt struct {
+	c *conn
+	p *PreparedQuery
+}
+
+// Close implements the driver.Stmt interface.
+func (s *stmt) Close() error {
+	return nil
+}
+
+// NumInput implements the driver.Stmt interface.
+func (s *stmt) NumInput() int {
+	return len(s.p.params)
+}
+
+// Exec implements the driver.Stmt interface.
+func (s *stmt) Exec(args []driver.Value) (driver.Result, error) {
+	return s.ExecContext(context.Background(), valuesToNamedValues(args))
+}
+
+// Query implements the driver.Stmt interface.
+func (s *stmt) Query(args []driver.Value) (driver.Rows, error) {
+	return s.QueryContext(context.Background(), valuesToNamedValues(args))
+}
+
+// ExecContext implements the driver.StmtExecContext interface.
+func (s *stmt) ExecContext(ctx context.Context, args []driver.NamedValue) (driver.Result, error) {
+	return s.c.exec(ctx, s.p, args)
+}
+
+// QueryContext implements the driver.StmtQueryContext interface.
+func (s *stmt) QueryContext(ctx context.Context, args []driver.NamedValue) (driver.Rows, error) {
+	return s.c.query(ctx, s.p, args)
+}
+
+func valuesToNamedValues(args []driver.Value) []driver.NamedValue {
+	named := make([]driver.NamedValue, len(args))
+	for i, v := range args {
+		named[i] = driver.NamedValue{Ordinal: i + 1, Value: v}
+	}
+
+	return named
+}
+
+// rows is the result of a query. It implements the driver.Rows interface and
+// the interfaces to get the type of its columns.
+type rows struct {
+	ctx    context.Context
+	schema sql.Schema
+	iter   sql.RowIter
+}
+
+// Columns implements the driver.Rows interface.
+func (r *rows) Columns() []string {
+	columns := make([]string, len(r.schema))
+	for i, c := range r.schema {
+		columns[i] = c.Name
+	}
+
+	return columns
+}
+
+// Close implements the driver.Rows interface.
+func (r *rows) Close() error {
+	return r.iter.Close()
+}
+
+// Next implements the driver.Rows interface.
+func (r *rows) Next(dest []driver.Value) error {
+	select {
+	case <-r.ctx.Done():
+		return r.ctx.Err()
+	default:
+	}
+
+	row, err := r.iter.Next()
+	if err != nil {
+		return err
+	}
+
+	for i, v := range row {
+		dest[i], err = driverValue(r.schema[i].Type, v)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// ColumnTypeDatabaseTypeName implements the
+// driver.RowsColumnTypeDatabaseTypeName interface.
+func (r *rows) ColumnTypeDatabaseTypeName(i int) string {
+	return databaseTypeName(r.schema[i].Type)
+}
+
+// ColumnTypeNullable implements the driver.RowsColumnTypeNullable interface.
+func (r *rows) ColumnTypeNullable(i int) (nullable, ok bool) {
+	return r.schema[i].Nullable, true
+}
+
+// ColumnTypeScanType implements the driver.RowsColumnTypeScanType interface.
+func (r *rows) ColumnTypeScanType(i int) reflect.Type {
+	c := r.schema[i]
+	return scanType(c.Type, c.Nullable)
+}
+
+var (
+	scanTypeInt64       = reflect.TypeOf(int64(0))
+	scanTypeFloat64     = reflect.TypeOf(float64(0))
+	scanTypeBool        = reflect.TypeOf(false)
+	scanTypeString      = reflect.TypeOf("")
+	scanTypeBytes       = reflect.TypeOf([]byte(nil))
+	scanTypeTime        = reflect.TypeOf(time.Time{})
+	scanTypeNullInt64   = reflect.TypeOf(gosql.NullInt64{})
+	scanTypeNullFloat64 = reflect.TypeOf(gosql.NullFloat64{})
+	scanTypeNullBool    = reflect.TypeOf(gosql.NullBool{})
+	scanTypeNullString  = reflect.TypeOf(gosql.NullString{})
+	scanTypeUnknown     = reflect.TypeOf(new(interface{})).Elem()
+)
+
+func scanType(typ sql.Type, nullable bool) reflect.Type {
+	t := typ.Type()
+	switch {
+	case typ == sql.Boolean:
+		if nullable {
+			return scanTypeNullBool
+		}
+		return scanTypeBool
+	case sqltypes.IsIntegral(t), t == sqltypes.Bit:
+		if nullable {
+			return scanTypeNullInt64
+		}
+		return scanTypeInt64
+	case sqltypes.IsFloat(t):
+		if nullable {
+			return scanTypeNullFloat64
+		}
+		return scanTypeFloat64
+	case t == sqltypes.Timestamp, t == sqltypes.Date, t == sqltypes.Datetime:
+		return scanTypeTime
+	case sqltypes.IsText(t), t == sqltypes.Enum, t == sqltypes.Set:
+		if nullable {
+			return scanTypeNullString
+		}
+		return scanTypeString
+	case sqltypes.IsBinary(t), t == sqltypes.TypeJSON:
+		return scanTypeBytes
+	default:
+		return scanTypeUnknown
+	}
+}
+
+func databaseTypeName(typ sql.Type) string {
+	switch t := typ.Type(); t {
+	case sqltypes.Int8:
+		return "TINYINT"
+	case sqltypes.Int16:
+		return "SMALLINT"
+	case sqltypes.Int24:
+		return "MEDIUMINT"
+	case sqltypes.Int32:
+		return "INT"
+	case sqltypes.Int64:
+		return "BIGINT"
+	case sqltypes.Uint8:
+		return "UNSIGNED TINYINT"
+	case sqltypes.Uint16:
+		return "UNSIGNED SMALLINT"
+	case sqltypes.Uint24:
+		return "UNSIGNED MEDIUMINT"
+	case sqltypes.Uint32:
+		return "UNSIGNED INT"
+	case sqltypes.Uint64:
+		return "UNSIGNED BIGINT"
+	case sqltypes.Float32:
+		return "FLOAT"
+	case sqltypes.Float64:
+		return "DOUBLE"
+	case sqltypes.TypeJSON:
+		return "JSON"
+	case sqltypes.Null:
+		return "NULL"
+	default:
+		return strings.ToUpper(t.String())
+	}
+}
+
+// driverValue converts a value of a row to one of the types allowed as a
+// driver.Value. Values of other types are converted using the type of their
+// column.
+func driverValue(t sql.Type, v interface{}) (driver.Value, error) {
+	// The values of ENUM and SET types are numbers, but they are read as
+	// the names of their members.
+	switch t.(type) {
+	case sql.EnumType, sql.SetType:
+		if v != nil {
+			return t.SQL(v).ToString(), nil
+		}
+	}
+
+	// JSON documents are read as their JSON text.
+	if t == sql.JSON && v != nil {
+		doc, err := t.Convert(v)
+		if err != nil {
+			return nil, err
+		}
+		return []byte(t.SQL(doc).Raw()), nil
+	}
+
+	switch v := v.(type) {
+	case nil, int64, float64, bool, []byte, string, time.Time:
+		return v, nil
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case uint:
+		return uint64ToValue(uint64(v)), nil
+	case uint8:
+		return int64(v), nil
+	case uint16:
+		return int64(v), nil
+	case uint32:
+		return int64(v), nil
+	case uint64:
+		return uint64ToValue(v), nil
+	case float32:
+		return float64(v), nil
+	}
+
+	cv, err := t.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if driver.IsValue(cv) {
+		return cv, nil
+	}
+
+	return t.SQL(cv).ToString(), nil
+}
+
+// uint64ToValue returns the value as an int64 if it fits in one, or as a
+// string otherwise, as database/sql is able to scan it from a string.
+func uint64ToValue(v uint64) driver.Value {
+	if v > math.MaxInt64 {
+		return fmt.Sprint(v)
+	}
+
+	return int64(v)
+}
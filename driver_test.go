@@ -0,0 +1,158 @@
+package sqle_test
+
+import (
+	"context"
+	gosql "database/sql"
+	"fmt"
+	"reflect"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestDriver(t *testing.T) {
+	require := require.New(t)
+
+	db := openDB(t)
+	defer db.Close()
+
+	res, err := db.Exec("INSERT INTO mytable (i, s) VALUES (?, ?), (?, ?)", 4, "d", 5, "e")
+	require.NoError(err)
+	n, err := res.RowsAffected()
+	require.NoError(err)
+	require.Equal(int64(2), n)
+
+	rows, err := db.Query("SELECT i, s FROM mytable WHERE s = ?", "d")
+	require.NoError(err)
+	require.Equal([]string{"i", "s"}, mustColumns(t, rows))
+
+	types, err := rows.ColumnTypes()
+	require.NoError(err)
+	require.Len(types, 2)
+	require.Equal("BIGINT", types[0].DatabaseTypeName())
+	require.Equal(reflect.TypeOf(int64(0)), types[0].ScanType())
+	require.Equal("TEXT", types[1].DatabaseTypeName())
+	nullable, ok := types[1].Nullable()
+	require.True(ok)
+	require.False(nullable)
+
+	var i int64
+	var s string
+	require.True(rows.Next())
+	require.NoError(rows.Scan(&i, &s))
+	require.Equal(int64(4), i)
+	require.Equal("d", s)
+	require.False(rows.Next())
+	require.NoError(rows.Err())
+	require.NoError(rows.Close())
+
+	stmt, err := db.Prepare("SELECT s FROM mytable WHERE i = :i")
+	require.NoError(err)
+	defer stmt.Close()
+
+	for _, tt := range []struct {
+		i int
+		s string
+	}{{1, "a"}, {5, "e"}} {
+		require.NoError(stmt.QueryRow(gosql.Named("i", tt.i)).Scan(&s))
+		require.Equal(tt.s, s)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	rows, err = db.QueryContext(ctx, "SELECT i FROM mytable")
+	require.NoError(err)
+	require.True(rows.Next())
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	for rows.Next() {
+	}
+	require.Equal(context.Canceled, rows.Err())
+}
+
+func TestDriver_Transaction(t *testing.T) {
+	require := require.New(t)
+
+	db := openDB(t)
+	defer db.Close()
+
+	count := func() int {
+		var n int
+		require.NoError(db.QueryRow("SELECT COUNT(*) FROM mytable").Scan(&n))
+		return n
+	}
+
+	before := count()
+
+	tx, err := db.Begin()
+	require.NoError(err)
+	_, err = tx.Exec("INSERT INTO mytable (i, s) VALUES (?, ?)", 100, "x")
+	require.NoError(err)
+	require.Equal(before, count())
+	require.NoError(tx.Rollback())
+	require.Equal(before, count())
+
+	tx, err = db.Begin()
+	require.NoError(err)
+	_, err = tx.Exec("INSERT INTO mytable (i, s) VALUES (?, ?)", 100, "x")
+	require.NoError(err)
+	require.NoError(tx.Commit())
+	require.Equal(before+1, count())
+}
+
+func TestDriver_Session(t *testing.T) {
+	require := require.New(t)
+
+	db := openDB(t)
+	defer db.Close()
+	db.SetMaxOpenConns(1)
+
+	_, err := db.Exec("SET @foo = 'bar'")
+	require.NoError(err)
+
+	var foo string
+	require.NoError(db.QueryRow("SELECT @foo").Scan(&foo))
+	require.Equal("bar", foo)
+}
+
+func TestOpenDB(t *testing.T) {
+	require := require.New(t)
+
+	e := newEngine(t)
+	db, err := e.OpenDB()
+	require.NoError(err)
+	defer db.Close()
+
+	_, err = db.Exec("INSERT INTO mytable (i, s) VALUES (?, ?)", 4, "d")
+	require.NoError(err)
+
+	// Handles of the same engine share its tables.
+	db2, err := e.OpenDB()
+	require.NoError(err)
+	defer db2.Close()
+
+	var s string
+	require.NoError(db2.QueryRow("SELECT s FROM mytable WHERE i = ?", 4).Scan(&s))
+	require.Equal("d", s)
+}
+
+// drivers is the number of drivers registered by openDB.
+var drivers int32
+
+// openDB opens a database with a new engine registered as a driver, so
+// tests don't depend on each other's drivers nor tables.
+func openDB(t *testing.T) *gosql.DB {
+	name := fmt.Sprintf("%s_%d", driverName, atomic.AddInt32(&drivers, 1))
+	gosql.Register(name, newEngine(t))
+
+	db, err := gosql.Open(name, "")
+	require.NoError(t, err)
+	return db
+}
+
+func mustColumns(t *testing.T, rows *gosql.Rows) []string {
+	columns, err := rows.Columns()
+	require.NoError(t, err)
+	return columns
+}
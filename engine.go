@@ -2,10 +2,13 @@ package sqle
 
 import (
 	"errors"
+	"fmt"
+	"sync"
 
 	"github.com/src-d/go-mysql-server/sql"
 	"github.com/src-d/go-mysql-server/sql/analyzer"
 	"github.com/src-d/go-mysql-server/sql/expression"
+	"github.com/src-d/go-mysql-server/sql/information_schema"
 	"github.com/src-d/go-mysql-server/sql/parse"
 )
 
@@ -15,13 +18,19 @@ var (
 
 // Engine is a SQL engine.
 // It implements the standard database/sql/driver/Driver interface, so it can
-// be registered as a database/sql driver.
+// be registered as a database/sql driver, which OpenDB does.
 type Engine struct {
 	Catalog  *sql.Catalog
 	Analyzer *analyzer.Analyzer
+
+	// register registers the engine as a database/sql driver named
+	// driverName the first time OpenDB is called.
+	register   sync.Once
+	driverName string
 }
 
-// New creates a new Engine.
+// New creates a new Engine. Its catalog contains the INFORMATION_SCHEMA
+// database, which describes the databases added to the engine.
 func New() *Engine {
 	c := sql.NewCatalog()
 	err := expression.RegisterDefaults(c)
@@ -29,28 +38,189 @@ func New() *Engine {
 		panic(err)
 	}
 
+	c.Databases = append(c.Databases, information_schema.NewDatabase(c))
+
 	a := analyzer.New(c)
-	return &Engine{c, a}
+	return &Engine{Catalog: c, Analyzer: a}
 }
 
-// Query executes a query without attaching to any session.
-func (e *Engine) Query(query string) (sql.Schema, sql.RowIter, error) {
-	parsed, err := parse.Parse(query)
+// NewSession creates a new session with the given id. Its system variables
+// are initialized with the current global values of the engine.
+func (e *Engine) NewSession(id uint32) sql.Session {
+	return sql.NewSession(id, e.Catalog.SystemVariables)
+}
+
+// Query executes a query using the session of the given context.
+func (e *Engine) Query(ctx *sql.Context, query string) (sql.Schema, sql.RowIter, error) {
+	p, err := e.Prepare(ctx, query)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	analyzed, err := e.Analyzer.Analyze(parsed)
+	return p.Query(ctx, nil)
+}
+
+// Prepare parses and analyzes a query that may contain bind variables, such
+// as ? or :name, using the session of the given context. The returned
+// prepared query can be executed many times with different values for its
+// bind variables. The values of system and user variables are the ones they
+// have when the query is prepared.
+func (e *Engine) Prepare(ctx *sql.Context, query string) (*PreparedQuery, error) {
+	parsed, err := parse.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	analyzed, err := e.Analyzer.Analyze(ctx, parsed)
+	if err != nil {
+		return nil, err
+	}
+
+	params := make(map[string]sql.Type)
+	analyzed.TransformExpressionsUp(func(e sql.Expression) sql.Expression {
+		if b, ok := e.(*expression.Bindvar); ok {
+			params[b.Variable] = b.Type()
+		}
+
+		return e
+	})
+
+	return &PreparedQuery{query: query, node: analyzed, params: params}, nil
+}
+
+// PreparedQuery is a query that has already been parsed and analyzed.
+type PreparedQuery struct {
+	query  string
+	node   sql.Node
+	params map[string]sql.Type
+}
+
+// String returns the text of the query.
+func (p *PreparedQuery) String() string {
+	return p.query
+}
+
+// Params returns the types of the bind variables of the query by name.
+// Positional bind variables are named v1, v2, ... vN. A bind variable whose
+// type could not be inferred has the sql.Null type and takes the type of the
+// value bound to it.
+func (p *PreparedQuery) Params() map[string]sql.Type {
+	params := make(map[string]sql.Type, len(p.params))
+	for name, typ := range p.params {
+		params[name] = typ
+	}
+
+	return params
+}
+
+// Schema returns the schema of the rows returned by the query.
+func (p *PreparedQuery) Schema() sql.Schema {
+	return p.node.Schema()
+}
+
+// Bind returns the plan of the query with the given values bound to its bind
+// variables. All the bind variables of the query must have a value.
+func (p *PreparedQuery) Bind(bindings map[string]interface{}) (sql.Node, error) {
+	for name := range p.params {
+		if _, ok := bindings[name]; !ok {
+			return nil, fmt.Errorf("missing value for bind variable %s", name)
+		}
+	}
+
+	if len(p.params) == 0 {
+		return p.node, nil
+	}
+
+	var err error
+	bound := p.node.TransformExpressionsUp(func(e sql.Expression) sql.Expression {
+		b, ok := e.(*expression.Bindvar)
+		if !ok || err != nil {
+			return e
+		}
+
+		var nb *expression.Bindvar
+		nb, err = b.Bind(bindings[b.Variable])
+		if err != nil {
+			return e
+		}
+
+		return nb
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return bound, nil
+}
+
+// Query executes the query with the given values for its bind variables
+// using the session of the given context. Statements that modify data run in
+// their own transaction if the session is not inside one, so they are
+// applied atomically, and inside one their changes are discarded if they
+// fail. The warnings of the session are discarded, so after
+// the rows are read it holds the warnings of this query.
+func (p *PreparedQuery) Query(
+	ctx *sql.Context,
+	bindings map[string]interface{},
+) (sql.Schema, sql.RowIter, error) {
+	ctx.ClearWarnings()
+	n, err := p.Bind(bindings)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	iter, err := analyzed.RowIter()
+	if sql.IsOkResult(n.Schema()) {
+		query := queryInTransaction
+		if ctx.InTransaction() {
+			query = queryInStatement
+		}
+
+		rows, err := query(ctx, n)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return n.Schema(), sql.RowsToRowIter(rows...), nil
+	}
+
+	iter, err := n.RowIter(ctx)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	return analyzed.Schema(), iter, nil
+	return n.Schema(), iter, nil
+}
+
+func queryInTransaction(ctx *sql.Context, n sql.Node) ([]sql.Row, error) {
+	if err := ctx.BeginTransaction(); err != nil {
+		return nil, err
+	}
+
+	rows, err := sql.NodeToRows(ctx, n)
+	if err != nil {
+		_ = ctx.RollbackTransaction()
+		return nil, err
+	}
+
+	if err := ctx.CommitTransaction(); err != nil {
+		return nil, err
+	}
+
+	return rows, nil
+}
+
+// queryInStatement executes a statement inside the current transaction,
+// discarding its changes if it fails.
+func queryInStatement(ctx *sql.Context, n sql.Node) ([]sql.Row, error) {
+	ctx.BeginStatement()
+
+	rows, err := sql.NodeToRows(ctx, n)
+	if err != nil {
+		_ = ctx.RollbackStatement()
+		return nil, err
+	}
+
+	return rows, nil
 }
 
 func (e *Engine) AddDatabase(db sql.Database) {
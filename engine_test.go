@@ -1,13 +1,18 @@
 package sqle_test
 
 import (
+	"context"
 	"io"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/src-d/go-mysql-server"
 	"github.com/src-d/go-mysql-server/mem"
 	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/decimal"
 
+	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
@@ -57,13 +62,90 @@ func TestQueries(t *testing.T) {
 		"SELECT COUNT(*) AS c FROM mytable;",
 		[][]interface{}{{int32(3)}},
 	)
+
+	testQuery(t, e,
+		"SELECT i AS __ok_result FROM mytable WHERE i = 1;",
+		[][]interface{}{{int64(1)}},
+	)
+}
+
+func TestInformationSchema(t *testing.T) {
+	e := newEngine(t)
+
+	testQuery(t, e,
+		"SELECT TABLE_NAME FROM information_schema.TABLES WHERE TABLE_SCHEMA = 'mydb'",
+		[][]interface{}{{"mytable"}},
+	)
+
+	testQuery(t, e,
+		"SELECT COLUMN_NAME, DATA_TYPE FROM information_schema.COLUMNS WHERE TABLE_NAME = 'mytable'",
+		[][]interface{}{{"i", "bigint"}, {"s", "text"}},
+	)
+}
+
+func TestShow(t *testing.T) {
+	e := newEngine(t)
+
+	testQuery(t, e,
+		"SHOW DATABASES",
+		[][]interface{}{{"information_schema"}, {"mydb"}},
+	)
+
+	testQuery(t, e,
+		"SHOW FULL TABLES FROM mydb",
+		[][]interface{}{{"mytable", "BASE TABLE"}},
+	)
+
+	testQuery(t, e,
+		"SHOW TABLES WHERE Tables_in_mydb = 'foo'",
+		nil,
+	)
+
+	testQuery(t, e,
+		"SHOW COLUMNS FROM mytable WHERE Field = 's'",
+		[][]interface{}{{"s", "text", "NO", "", nil, ""}},
+	)
+
+	testQuery(t, e,
+		"SHOW TABLE STATUS LIKE 'my%'",
+		[][]interface{}{{
+			"mytable", nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+			nil, nil, nil, "utf8_general_ci", nil, "", "",
+		}},
+	)
+
+	testQuery(t, e, "SHOW INDEX FROM mydb.mytable", nil)
+
+	_, _, err := e.Query(sql.NewEmptyContext(), "SHOW TABLES FROM nope")
+	require.EqualError(t, err, "database not found: nope")
+}
+
+func TestDescribe(t *testing.T) {
+	e := newEngine(t)
+
+	testQuery(t, e,
+		"DESCRIBE mytable",
+		[][]interface{}{
+			{"i", "bigint", "NO", "", nil, ""},
+			{"s", "text", "NO", "", nil, ""},
+		},
+	)
+
+	testQuery(t, e,
+		"EXPLAIN SELECT s FROM mytable WHERE i = 2",
+		[][]interface{}{
+			{"Project(s)"},
+			{" └─ Filter(i = 2)"},
+			{"     └─ Table(mytable)"},
+		},
+	)
 }
 
 func TestInsertInto(t *testing.T) {
 	e := newEngine(t)
 	testQuery(t, e,
 		"INSERT INTO mytable (s, i) VALUES ('x', 999);",
-		[][]interface{}{{int64(1)}},
+		[][]interface{}{{sql.NewOkResult(1)}},
 	)
 
 	testQuery(t, e,
@@ -72,11 +154,834 @@ func TestInsertInto(t *testing.T) {
 	)
 }
 
+func TestDecimal(t *testing.T) {
+	require := require.New(t)
+
+	table := mem.NewTable("prices", sql.Schema{
+		{Name: "item", Type: sql.Text},
+		{Name: "price", Type: sql.Decimal(10, 2)},
+	})
+	db := mem.NewDatabase("shop")
+	db.AddTable("prices", table)
+
+	e := sqle.New()
+	e.AddDatabase(db)
+
+	testQuery(t, e,
+		"INSERT INTO prices (item, price) VALUES ('a', '0.10'), ('b', 0.2), ('c', 3)",
+		[][]interface{}{{sql.NewOkResult(3)}},
+	)
+
+	testQuery(t, e,
+		"SELECT item, price FROM prices",
+		[][]interface{}{
+			{"a", decimal.MustParse("0.10")},
+			{"b", decimal.MustParse("0.20")},
+			{"c", decimal.MustParse("3.00")},
+		},
+	)
+
+	testQuery(t, e,
+		"SELECT SUM(price), AVG(price) FROM prices",
+		[][]interface{}{{decimal.MustParse("3.30"), decimal.MustParse("1.100000")}},
+	)
+
+	_, _, err := e.Query(sql.NewEmptyContext(), "INSERT INTO prices (item, price) VALUES ('d', 123456789)")
+	require.Error(err)
+}
+
+func TestNumberLiterals(t *testing.T) {
+	table := mem.NewTable("nums", sql.Schema{
+		{Name: "d", Type: sql.Decimal(30, 2), Nullable: true},
+		{Name: "u", Type: sql.Uint64, Nullable: true},
+	})
+	db := mem.NewDatabase("mydb")
+	db.AddTable("nums", table)
+
+	e := sqle.New()
+	e.AddDatabase(db)
+
+	// Numbers with a decimal point keep all their digits.
+	testQuery(t, e,
+		"INSERT INTO nums (d, u) VALUES (1234567890123456789.01, 1)",
+		[][]interface{}{{sql.NewOkResult(1)}},
+	)
+
+	testQuery(t, e,
+		"SELECT d FROM nums WHERE d = 1234567890123456789.01",
+		[][]interface{}{{decimal.MustParse("1234567890123456789.01")}},
+	)
+
+	testQuery(t, e,
+		"SELECT d FROM nums WHERE d > 1234567890123456789.009",
+		[][]interface{}{{decimal.MustParse("1234567890123456789.01")}},
+	)
+
+	// Integers that don't fit in a BIGINT are unsigned, and they're
+	// neither saturated nor truncated.
+	testQuery(t, e,
+		"INSERT INTO nums (u) VALUES (18446744073709551615)",
+		[][]interface{}{{sql.NewOkResult(1)}},
+	)
+
+	testQuery(t, e,
+		"SELECT u FROM nums WHERE u = 18446744073709551615",
+		[][]interface{}{{uint64(18446744073709551615)}},
+	)
+
+	testQuery(t, e,
+		"SELECT u FROM nums WHERE u = 9223372036854775807",
+		[][]interface{}{},
+	)
+
+	_, _, err := e.Query(sql.NewEmptyContext(), "INSERT INTO nums (u) VALUES (18446744073709551616)")
+	require.Error(t, err)
+}
+
+func TestTimestamp(t *testing.T) {
+	table := mem.NewTable("events", sql.Schema{
+		{Name: "name", Type: sql.Text},
+		{Name: "ts", Type: sql.Timestamp},
+		{Name: "at", Type: sql.Text, Nullable: true},
+	})
+	db := mem.NewDatabase("mydb")
+	db.AddTable("events", table)
+
+	e := sqle.New()
+	e.AddDatabase(db)
+
+	testQuery(t, e,
+		"INSERT INTO events (name, ts) VALUES ('a', 20200101)",
+		[][]interface{}{{sql.NewOkResult(1)}},
+	)
+
+	testQuery(t, e,
+		"SELECT name FROM events WHERE ts = 20200101000000",
+		[][]interface{}{{"a"}},
+	)
+
+	testQuery(t, e,
+		"SELECT ts FROM events",
+		[][]interface{}{{time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)}},
+	)
+
+	// Strings and numbers are read in the time zone of the session when
+	// they are inserted and compared.
+	ctx := sql.NewContext(context.TODO(), e.NewSession(1))
+	testQueryWithContext(t, e, ctx, "SET time_zone = '+02:00'", nil)
+	testQueryWithContext(t, e, ctx,
+		"INSERT INTO events (name, ts, at) VALUES ('b', '2020-01-01 10:00:00', '2020-01-01 10:00:00')",
+		[][]interface{}{{sql.NewOkResult(1)}},
+	)
+
+	testQueryWithContext(t, e, ctx,
+		"SELECT name FROM events WHERE ts = '2020-01-01 10:00:00'",
+		[][]interface{}{{"b"}},
+	)
+
+	testQueryWithContext(t, e, ctx,
+		"SELECT name FROM events WHERE ts = 20200101100000",
+		[][]interface{}{{"b"}},
+	)
+
+	testQueryWithContext(t, e, ctx,
+		"SELECT name FROM events WHERE ts = at",
+		[][]interface{}{{"b"}},
+	)
+
+	testQuery(t, e,
+		"SELECT name FROM events WHERE ts = '2020-01-01 08:00:00'",
+		[][]interface{}{{"b"}},
+	)
+}
+
+func TestStringLength(t *testing.T) {
+	require := require.New(t)
+
+	table := mem.NewTable("users", sql.Schema{
+		{Name: "name", Type: sql.Varchar(5)},
+	})
+	db := mem.NewDatabase("mydb")
+	db.AddTable("users", table)
+
+	e := sqle.New()
+	e.AddDatabase(db)
+	ctx := sql.NewContext(context.TODO(), e.NewSession(1))
+
+	// The session is in strict mode by default.
+	_, _, err := e.Query(ctx, "INSERT INTO users (name) VALUES ('abcdefg')")
+	require.Error(err)
+
+	testQueryWithContext(t, e, ctx, "SET sql_mode = ''", nil)
+	testQueryWithContext(t, e, ctx,
+		"INSERT INTO users (name) VALUES ('abcdefg'), ('ab')",
+		[][]interface{}{{sql.OkResult{RowsAffected: 2, Warnings: 1}}},
+	)
+
+	testQueryWithContext(t, e, ctx,
+		"SELECT name FROM users",
+		[][]interface{}{{"abcde"}, {"ab"}},
+	)
+}
+
+func TestInsertDefaults(t *testing.T) {
+	require := require.New(t)
+
+	status, err := sql.CreateEnum([]string{"pending", "active"})
+	require.NoError(err)
+
+	table := mem.NewTable("accounts", sql.Schema{
+		{Name: "name", Type: sql.Text},
+		{Name: "status", Type: status, Default: "active"},
+		{Name: "created", Type: sql.Datetime(0), Nullable: true},
+		{Name: "balance", Type: sql.Decimal(10, 2)},
+	})
+	db := mem.NewDatabase("mydb")
+	db.AddTable("accounts", table)
+
+	e := sqle.New()
+	e.AddDatabase(db)
+	ctx := sql.NewContext(context.TODO(), e.NewSession(1))
+
+	// In strict mode, NOT NULL columns without a default value can't be
+	// left out.
+	_, _, err = e.Query(ctx, "INSERT INTO accounts (name) VALUES ('a')")
+	require.Error(err)
+
+	testQueryWithContext(t, e, ctx,
+		"INSERT INTO accounts (name, balance) VALUES ('a', 1.5)",
+		[][]interface{}{{sql.NewOkResult(1)}},
+	)
+
+	testQueryWithContext(t, e, ctx, "SET sql_mode = ''", nil)
+	testQueryWithContext(t, e, ctx,
+		"INSERT INTO accounts (balance) VALUES (2)",
+		[][]interface{}{{sql.NewOkResult(1)}},
+	)
+
+	testQueryWithContext(t, e, ctx,
+		"SELECT name, status, created, balance FROM accounts",
+		[][]interface{}{
+			{"a", uint16(2), nil, decimal.MustParse("1.50")},
+			{"", uint16(2), nil, decimal.MustParse("2.00")},
+		},
+	)
+}
+
+func TestIntegerRanges(t *testing.T) {
+	require := require.New(t)
+
+	table := mem.NewTable("flags", sql.Schema{
+		{Name: "level", Type: sql.Int8},
+		{Name: "count", Type: sql.Uint16},
+		{Name: "active", Type: sql.Boolean},
+	})
+	db := mem.NewDatabase("mydb")
+	db.AddTable("flags", table)
+
+	e := sqle.New()
+	e.AddDatabase(db)
+
+	testQuery(t, e,
+		"INSERT INTO flags (level, count, active) VALUES (-128, 65535, TRUE), (127, 0, 0)",
+		[][]interface{}{{sql.NewOkResult(2)}},
+	)
+
+	testQuery(t, e,
+		"SELECT level, count, active FROM flags",
+		[][]interface{}{
+			{int8(-128), uint16(65535), true},
+			{int8(127), uint16(0), false},
+		},
+	)
+
+	for _, q := range []string{
+		"INSERT INTO flags (level, count, active) VALUES (128, 0, 1)",
+		"INSERT INTO flags (level, count, active) VALUES (0, -1, 1)",
+		"INSERT INTO flags (level, count, active) VALUES (0, 65536, 1)",
+	} {
+		_, _, err := e.Query(sql.NewEmptyContext(), q)
+		require.Error(err, q)
+	}
+}
+
+func TestEnum(t *testing.T) {
+	require := require.New(t)
+
+	status, err := sql.CreateEnum([]string{"pending", "active", "disabled"})
+	require.NoError(err)
+
+	table := mem.NewTable("accounts", sql.Schema{
+		{Name: "name", Type: sql.Text},
+		{Name: "status", Type: status},
+	})
+	db := mem.NewDatabase("mydb")
+	db.AddTable("accounts", table)
+
+	e := sqle.New()
+	e.AddDatabase(db)
+
+	testQuery(t, e,
+		"INSERT INTO accounts (name, status) VALUES ('a', 'disabled'), ('b', 'PENDING'), ('c', 2)",
+		[][]interface{}{{sql.NewOkResult(3)}},
+	)
+
+	// ENUM values are sorted by the order of their members.
+	testQuery(t, e,
+		"SELECT name FROM accounts ORDER BY status",
+		[][]interface{}{{"b"}, {"c"}, {"a"}},
+	)
+
+	testQuery(t, e,
+		"SELECT name FROM accounts WHERE status = 'active'",
+		[][]interface{}{{"c"}},
+	)
+
+	testQuery(t, e,
+		"DESCRIBE accounts",
+		[][]interface{}{
+			{"name", "text", "NO", "", nil, ""},
+			{"status", "enum('pending','active','disabled')", "NO", "", nil, ""},
+		},
+	)
+
+	_, _, err = e.Query(sql.NewEmptyContext(), "INSERT INTO accounts (name, status) VALUES ('d', 'deleted')")
+	require.Error(err)
+}
+
+func TestComparisonTypes_Members(t *testing.T) {
+	require := require.New(t)
+
+	status, err := sql.CreateEnum([]string{"pending", "active", "disabled"})
+	require.NoError(err)
+	flags, err := sql.CreateSet([]string{"a", "b", "c"})
+	require.NoError(err)
+
+	table := mem.NewTable("accounts", sql.Schema{
+		{Name: "name", Type: sql.Text},
+		{Name: "status", Type: status},
+		{Name: "flags", Type: flags},
+	})
+	db := mem.NewDatabase("mydb")
+	db.AddTable("accounts", table)
+
+	e := sqle.New()
+	e.AddDatabase(db)
+
+	testQuery(t, e,
+		"INSERT INTO accounts (name, status, flags) VALUES ('active', 'active', 'a'), ('a,b', 'pending', 'a,b')",
+		[][]interface{}{{sql.NewOkResult(2)}},
+	)
+
+	// Strings are converted to the ENUM or SET type on either side.
+	for _, q := range []string{
+		"SELECT name FROM accounts WHERE name = status",
+		"SELECT name FROM accounts WHERE status = name",
+		"SELECT name FROM accounts WHERE 2 = status",
+	} {
+		testQuery(t, e, q, [][]interface{}{{"active"}})
+	}
+
+	for _, q := range []string{
+		"SELECT name FROM accounts WHERE name = flags",
+		"SELECT name FROM accounts WHERE flags = name",
+	} {
+		testQuery(t, e, q, [][]interface{}{{"a,b"}})
+	}
+}
+
+func TestComparisonTypes(t *testing.T) {
+	e := newEngine(t)
+
+	testQuery(t, e,
+		"SELECT s FROM mytable WHERE i = '2'",
+		[][]interface{}{{"b"}},
+	)
+
+	testQuery(t, e,
+		"SELECT s FROM mytable WHERE i < 2.5",
+		[][]interface{}{{"a"}, {"b"}},
+	)
+
+	testQuery(t, e,
+		"SELECT s FROM mytable WHERE '3' <= i",
+		[][]interface{}{{"c"}},
+	)
+
+	// Strings that aren't numbers are compared as 0.
+	testQuery(t, e,
+		"SELECT i FROM mytable WHERE s > 0",
+		[][]interface{}{},
+	)
+}
+
+func TestComparisonTypes_Decimal(t *testing.T) {
+	table := mem.NewTable("prices", sql.Schema{
+		{Name: "a", Type: sql.Decimal(5, 2)},
+	})
+	for _, d := range []string{"1.50", "2.25"} {
+		require.NoError(t, table.Insert(sql.NewEmptyContext(), sql.NewRow(decimal.MustParse(d))))
+	}
+
+	db := mem.NewDatabase("mydb")
+	db.AddTable("prices", table)
+
+	e := sqle.New()
+	e.AddDatabase(db)
+
+	for _, q := range []string{
+		"SELECT a FROM prices WHERE a = 1.5",
+		"SELECT a FROM prices WHERE a < 1.51",
+		"SELECT a FROM prices WHERE a = '1.5'",
+		"SELECT a FROM prices WHERE '1.50' >= a",
+	} {
+		testQuery(t, e, q, [][]interface{}{{decimal.MustParse("1.50")}})
+	}
+
+	testQuery(t, e,
+		"SELECT a FROM prices WHERE a > 1.49",
+		[][]interface{}{{decimal.MustParse("1.50")}, {decimal.MustParse("2.25")}},
+	)
+}
+
+func TestCast(t *testing.T) {
+	require := require.New(t)
+	e := newEngine(t)
+	ctx := sql.NewEmptyContext()
+
+	testQueryWithContext(t, e, ctx,
+		"SELECT CAST(i AS CHAR), CONVERT(s, SIGNED), CAST('2018-01-02 10:00' AS DATE) FROM mytable WHERE i = 1",
+		[][]interface{}{{"1", int64(0), time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)}},
+	)
+	require.Equal([]sql.Warning{
+		{Code: sql.ErrTruncatedWrongValue, Message: "Truncated incorrect INTEGER value: 'a'"},
+	}, ctx.Warnings())
+
+	// Each query discards the warnings of the previous one.
+	testQueryWithContext(t, e, ctx,
+		"SELECT s FROM mytable WHERE CAST(i AS DECIMAL(3,1)) = CAST('2.0' AS DECIMAL(3,1))",
+		[][]interface{}{{"b"}},
+	)
+	require.Empty(ctx.Warnings())
+}
+
+func TestCollations(t *testing.T) {
+	table := mem.NewTable("people", sql.Schema{
+		{Name: "name", Type: sql.Text},
+	})
+	for _, name := range []string{"john", "Jane", "JOHN", "josé"} {
+		require.NoError(t, table.Insert(sql.NewEmptyContext(), sql.NewRow(name)))
+	}
+
+	db := mem.NewDatabase("mydb")
+	db.AddTable("people", table)
+
+	e := sqle.New()
+	e.AddDatabase(db)
+
+	testQuery(t, e,
+		"SELECT name FROM people WHERE name = 'John'",
+		[][]interface{}{{"john"}, {"JOHN"}},
+	)
+
+	testQuery(t, e,
+		"SELECT name FROM people WHERE name = 'JOSE'",
+		[][]interface{}{{"josé"}},
+	)
+
+	testQuery(t, e,
+		"SELECT name FROM people WHERE name = 'John' COLLATE utf8_bin",
+		[][]interface{}{},
+	)
+
+	testQuery(t, e,
+		"SELECT name FROM people WHERE name COLLATE utf8_bin = 'JOHN'",
+		[][]interface{}{{"JOHN"}},
+	)
+
+	testQuery(t, e,
+		"SELECT name FROM people ORDER BY name",
+		[][]interface{}{{"Jane"}, {"john"}, {"JOHN"}, {"josé"}},
+	)
+
+	testQuery(t, e,
+		"SELECT DISTINCT name FROM people",
+		[][]interface{}{{"john"}, {"Jane"}, {"josé"}},
+	)
+
+	testQuery(t, e,
+		"SELECT COUNT(*) FROM people WHERE name = 'JOHN' GROUP BY name",
+		[][]interface{}{{int32(2)}},
+	)
+
+	_, _, err := e.Query(sql.NewEmptyContext(), "SELECT name FROM people WHERE name = 'a' COLLATE unknown_ci")
+	require.Error(t, err)
+}
+
+func TestJSON(t *testing.T) {
+	table := mem.NewTable("people", sql.Schema{
+		{Name: "name", Type: sql.Text},
+		{Name: "phone_numbers", Type: sql.JSON},
+		{Name: "doc", Type: sql.JSON, Nullable: true},
+	})
+	// Values of Go tables are documents as encoding/json encodes them.
+	require.NoError(t, table.Insert(sql.NewEmptyContext(), sql.NewRow(
+		"John", []string{"555-1234", "555-5678"}, map[string]interface{}{"age": 30, "city": "Paris"},
+	)))
+
+	db := mem.NewDatabase("mydb")
+	db.AddTable("people", table)
+
+	e := sqle.New()
+	e.AddDatabase(db)
+
+	_, iter, err := e.Query(sql.NewEmptyContext(),
+		`INSERT INTO people (name, phone_numbers, doc) VALUES ('Jane', '["555-0000"]', '{"age": 25, "city": "Madrid"}')`,
+	)
+	require.NoError(t, err)
+	_, err = sql.RowIterToRows(iter)
+	require.NoError(t, err)
+
+	_, _, err = e.Query(sql.NewEmptyContext(),
+		`INSERT INTO people (name, phone_numbers) VALUES ('Jim', '["555-1111"')`,
+	)
+	require.Error(t, err)
+
+	testQuery(t, e,
+		"SELECT name, phone_numbers->'$[0]', doc->>'$.city' FROM people",
+		[][]interface{}{
+			{"John", sql.JSONDocument{Val: "555-1234"}, "Paris"},
+			{"Jane", sql.JSONDocument{Val: "555-0000"}, "Madrid"},
+		},
+	)
+
+	testQuery(t, e,
+		"SELECT name FROM people WHERE doc->'$.city' = 'Madrid'",
+		[][]interface{}{{"Jane"}},
+	)
+
+	testQuery(t, e,
+		"SELECT name FROM people WHERE doc->'$.age' > 26",
+		[][]interface{}{{"John"}},
+	)
+
+	testQuery(t, e,
+		`SELECT name FROM people WHERE JSON_CONTAINS(phone_numbers, '"555-5678"')`,
+		[][]interface{}{{"John"}},
+	)
+
+	testQuery(t, e,
+		"SELECT JSON_LENGTH(phone_numbers), JSON_KEYS(doc) FROM people WHERE name = 'John'",
+		[][]interface{}{{int64(2), sql.JSONDocument{Val: []interface{}{"age", "city"}}}},
+	)
+
+	testQuery(t, e,
+		"SELECT JSON_OBJECT('name', name, 'phones', JSON_ARRAY(phone_numbers->'$[0]', 'x')) FROM people WHERE name = 'Jane'",
+		[][]interface{}{{sql.JSONDocument{Val: map[string]interface{}{
+			"name":   "Jane",
+			"phones": []interface{}{"555-0000", "x"},
+		}}}},
+	)
+
+	testQuery(t, e,
+		"SELECT JSON_EXTRACT('[1, 2, 3]', '$[last]')",
+		[][]interface{}{{sql.JSONDocument{Val: 3.0}}},
+	)
+
+	testQuery(t, e,
+		`SELECT n, name, age, tags FROM JSON_TABLE(
+			'{"people": [{"name": "John", "age": 30, "tags": [1]}, {"name": "Jane"}]}',
+			'$.people[*]' COLUMNS (
+				n FOR ORDINALITY,
+				name VARCHAR(10) PATH '$.name',
+				age INT PATH '$.age',
+				tags JSON PATH '$.tags'
+			)
+		) AS people WHERE n > 0`,
+		[][]interface{}{
+			{int64(1), "John", int32(30), sql.JSONDocument{Val: []interface{}{1.0}}},
+			{int64(2), "Jane", nil, nil},
+		},
+	)
+
+	for _, q := range []string{
+		"SELECT JSON_EXTRACT(doc, 'bad path') FROM people",
+		"SELECT JSON_EXTRACT('{\"a\": ', '$.a')",
+		"SELECT JSON_OBJECT('a', 1, 'b')",
+		"SELECT JSON_OBJECT(NULL, 1)",
+	} {
+		_, _, err = e.Query(sql.NewEmptyContext(), q)
+		require.Error(t, err, q)
+	}
+
+	// Converted documents, such as JSON strings, are not read again as JSON
+	// text when they are inserted.
+	_, iter, err = e.Query(sql.NewEmptyContext(),
+		`INSERT INTO people (name, phone_numbers, doc) VALUES ('Jo', '"555-2222"', '{"city": null}')`,
+	)
+	require.NoError(t, err)
+	_, err = sql.RowIterToRows(iter)
+	require.NoError(t, err)
+
+	_, iter, err = e.Query(sql.NewEmptyContext(),
+		`INSERT INTO people (name, phone_numbers) SELECT 'Jim', doc->'$.city' FROM people WHERE name = 'Jane'`,
+	)
+	require.NoError(t, err)
+	_, err = sql.RowIterToRows(iter)
+	require.NoError(t, err)
+
+	testQuery(t, e,
+		"SELECT phone_numbers FROM people WHERE name = 'Jo'",
+		[][]interface{}{{sql.JSONDocument{Val: "555-2222"}}},
+	)
+
+	testQuery(t, e,
+		"SELECT phone_numbers FROM people WHERE name = 'Jim'",
+		[][]interface{}{{sql.JSONDocument{Val: "Madrid"}}},
+	)
+
+	// The JSON null is not SQL NULL.
+	testQuery(t, e,
+		"SELECT doc->'$.city', doc->>'$.city', doc->'$.age' FROM people WHERE name = 'Jo'",
+		[][]interface{}{{sql.JSONDocument{}, "null", nil}},
+	)
+}
+
+func TestTransactions(t *testing.T) {
+	require := require.New(t)
+
+	e := newEngine(t)
+	ctx1 := sql.NewContext(context.TODO(), e.NewSession(1))
+	ctx2 := sql.NewContext(context.TODO(), e.NewSession(2))
+
+	_, _, err := e.Query(ctx1, "INSERT INTO mytable (i, s) VALUES (4, 'd'), ('foo', 'e')")
+	require.Error(err)
+	testQuery(t, e, "SELECT COUNT(*) FROM mytable", [][]interface{}{{int32(3)}})
+
+	testQueryWithContext(t, e, ctx1, "BEGIN", nil)
+	testQueryWithContext(t, e, ctx1,
+		"INSERT INTO mytable (i, s) VALUES (4, 'd')",
+		[][]interface{}{{sql.NewOkResult(1)}},
+	)
+	testQueryWithContext(t, e, ctx2, "START TRANSACTION", nil)
+	testQueryWithContext(t, e, ctx1,
+		"SELECT COUNT(*) FROM mytable",
+		[][]interface{}{{int32(4)}},
+	)
+	testQueryWithContext(t, e, ctx2,
+		"SELECT COUNT(*) FROM mytable",
+		[][]interface{}{{int32(3)}},
+	)
+	testQueryWithContext(t, e, ctx1, "COMMIT", nil)
+
+	// The snapshot of the second session was taken before the commit.
+	testQueryWithContext(t, e, ctx2,
+		"SELECT COUNT(*) FROM mytable",
+		[][]interface{}{{int32(3)}},
+	)
+	testQueryWithContext(t, e, ctx2, "ROLLBACK", nil)
+	testQueryWithContext(t, e, ctx2,
+		"SELECT COUNT(*) FROM mytable",
+		[][]interface{}{{int32(4)}},
+	)
+
+	testQueryWithContext(t, e, ctx1, "SET autocommit = 0", nil)
+	testQueryWithContext(t, e, ctx1,
+		"INSERT INTO mytable (i, s) VALUES (5, 'e')",
+		[][]interface{}{{sql.NewOkResult(1)}},
+	)
+	testQueryWithContext(t, e, ctx1, "ROLLBACK", nil)
+	testQueryWithContext(t, e, ctx1,
+		"SELECT COUNT(*) FROM mytable",
+		[][]interface{}{{int32(4)}},
+	)
+
+	// A failed statement discards its own rows, but not the ones inserted
+	// before it in the transaction.
+	testQueryWithContext(t, e, ctx1, "BEGIN", nil)
+	testQueryWithContext(t, e, ctx1,
+		"INSERT INTO mytable (i, s) VALUES (5, 'e')",
+		[][]interface{}{{sql.NewOkResult(1)}},
+	)
+	_, _, err = e.Query(ctx1, "INSERT INTO mytable (i, s) VALUES (6, 'f'), ('foo', 'g')")
+	require.Error(err)
+	testQueryWithContext(t, e, ctx1, "COMMIT", nil)
+	testQuery(t, e,
+		"SELECT i FROM mytable WHERE i > 3",
+		[][]interface{}{{int64(4)}, {int64(5)}},
+	)
+
+	// Turning autocommit back on commits the open transaction.
+	testQueryWithContext(t, e, ctx1,
+		"INSERT INTO mytable (i, s) VALUES (6, 'f')",
+		[][]interface{}{{sql.NewOkResult(1)}},
+	)
+	testQueryWithContext(t, e, ctx1, "SET autocommit = 1", nil)
+	testQueryWithContext(t, e, ctx2,
+		"SELECT COUNT(*) FROM mytable",
+		[][]interface{}{{int32(6)}},
+	)
+	testQueryWithContext(t, e, ctx1,
+		"SELECT COUNT(*) FROM mytable",
+		[][]interface{}{{int32(6)}},
+	)
+	testQueryWithContext(t, e, ctx1, "BEGIN", nil)
+	testQueryWithContext(t, e, ctx1, "ROLLBACK", nil)
+	testQuery(t, e,
+		"SELECT COUNT(*) FROM mytable",
+		[][]interface{}{{int32(6)}},
+	)
+}
+
+func TestConcurrentQueries(t *testing.T) {
+	assert := assert.New(t)
+	e := newEngine(t)
+
+	const (
+		clients = 8
+		queries = 50
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < clients; i++ {
+		wg.Add(1)
+		go func(id uint32) {
+			defer wg.Done()
+			ctx := sql.NewContext(context.TODO(), e.NewSession(id))
+			for j := 0; j < queries; j++ {
+				_, iter, err := e.Query(ctx, "INSERT INTO mytable (i, s) VALUES (10, 'x')")
+				if !assert.NoError(err) {
+					return
+				}
+				_, err = sql.RowIterToRows(iter)
+				assert.NoError(err)
+
+				_, iter, err = e.Query(ctx, "SELECT i, s FROM mytable WHERE s = 'x'")
+				if !assert.NoError(err) {
+					return
+				}
+				_, err = sql.RowIterToRows(iter)
+				assert.NoError(err)
+			}
+		}(uint32(i + 1))
+	}
+
+	wg.Wait()
+
+	testQuery(t, e,
+		"SELECT COUNT(*) FROM mytable",
+		[][]interface{}{{int32(3 + clients*queries)}},
+	)
+}
+
+func TestVariables(t *testing.T) {
+	e := newEngine(t)
+	ctx := sql.NewContext(context.TODO(), e.NewSession(1))
+
+	testQueryWithContext(t, e, ctx,
+		"SELECT @@autocommit, @@max_allowed_packet, @@version_comment",
+		[][]interface{}{{int64(1), int64(4194304), "go-mysql-server"}},
+	)
+
+	testQueryWithContext(t, e, ctx,
+		"SET autocommit = OFF, NAMES latin1, @foo = 'bar'",
+		nil,
+	)
+
+	testQueryWithContext(t, e, ctx,
+		"SELECT @@session.autocommit, @@character_set_client, @foo, @bar",
+		[][]interface{}{{int64(0), "latin1", "bar", nil}},
+	)
+
+	testQueryWithContext(t, e, ctx,
+		"SELECT i FROM mytable WHERE s = @foo",
+		nil,
+	)
+
+	testQueryWithContext(t, e, ctx,
+		"SET GLOBAL net_write_timeout = 10",
+		nil,
+	)
+
+	testQueryWithContext(t, e, ctx,
+		"SHOW VARIABLES LIKE 'net_write_timeout'",
+		[][]interface{}{{"net_write_timeout", "60"}},
+	)
+
+	testQueryWithContext(t, e, ctx,
+		"SHOW GLOBAL VARIABLES LIKE 'net_write_timeout'",
+		[][]interface{}{{"net_write_timeout", "10"}},
+	)
+
+	ctx2 := sql.NewContext(context.TODO(), e.NewSession(2))
+	testQueryWithContext(t, e, ctx2,
+		"SELECT @@autocommit, @@net_write_timeout, @foo",
+		[][]interface{}{{int64(1), int64(10), nil}},
+	)
+
+	_, _, err := e.Query(ctx, "SET version = 'foo'")
+	require.Error(t, err)
+
+	_, _, err = e.Query(ctx, "SELECT @@nope")
+	require.EqualError(t, err, "unknown system variable: nope")
+}
+
+func TestPrepare(t *testing.T) {
+	require := require.New(t)
+
+	e := newEngine(t)
+	ctx := sql.NewEmptyContext()
+
+	p, err := e.Prepare(ctx, "SELECT s FROM mytable WHERE i = ?")
+	require.NoError(err)
+	require.Equal(map[string]sql.Type{"v1": sql.Int64}, p.Params())
+	require.Equal(sql.Schema{{Name: "s", Type: sql.Text}}, p.Schema())
+
+	for _, tt := range []struct {
+		i interface{}
+		s string
+	}{{int64(1), "a"}, {"2", "b"}, {3, "c"}} {
+		_, iter, err := p.Query(ctx, map[string]interface{}{"v1": tt.i})
+		require.NoError(err)
+		rows, err := sql.RowIterToRows(iter)
+		require.NoError(err)
+		require.Equal([]sql.Row{{tt.s}}, rows)
+	}
+
+	_, _, err = p.Query(ctx, nil)
+	require.Error(err)
+
+	_, _, err = p.Query(ctx, map[string]interface{}{"v1": "foo"})
+	require.Error(err)
+
+	p, err = e.Prepare(ctx, "INSERT INTO mytable (s, i) VALUES (:s, :i)")
+	require.NoError(err)
+	require.Equal(map[string]sql.Type{"s": sql.Text, "i": sql.Int64}, p.Params())
+
+	_, iter, err := p.Query(ctx, map[string]interface{}{"s": "d", "i": 4})
+	require.NoError(err)
+	rows, err := sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Equal([]sql.Row{{sql.NewOkResult(1)}}, rows)
+
+	testQuery(t, e, "SELECT i FROM mytable WHERE s = 'd'", [][]interface{}{{int64(4)}})
+}
+
 func testQuery(t *testing.T, e *sqle.Engine, q string, r [][]interface{}) {
+	testQueryWithContext(t, e, sql.NewEmptyContext(), q, r)
+}
+
+func testQueryWithContext(
+	t *testing.T,
+	e *sqle.Engine,
+	ctx *sql.Context,
+	q string,
+	r [][]interface{},
+) {
 	t.Run(q, func(t *testing.T) {
 		assert := require.New(t)
 
-		_, rows, err := e.Query(q)
+		_, rows, err := e.Query(ctx, q)
 		assert.NoError(err)
 
 		i := 0
@@ -105,9 +1010,9 @@ func newEngine(t *testing.T) *sqle.Engine {
 		{Name: "i", Type: sql.Int64},
 		{Name: "s", Type: sql.Text},
 	})
-	assert.Nil(table.Insert(sql.NewRow(int64(1), "a")))
-	assert.Nil(table.Insert(sql.NewRow(int64(2), "b")))
-	assert.Nil(table.Insert(sql.NewRow(int64(3), "c")))
+	assert.Nil(table.Insert(sql.NewEmptyContext(), sql.NewRow(int64(1), "a")))
+	assert.Nil(table.Insert(sql.NewEmptyContext(), sql.NewRow(int64(2), "b")))
+	assert.Nil(table.Insert(sql.NewEmptyContext(), sql.NewRow(int64(3), "c")))
 
 	db := mem.NewDatabase("mydb")
 	db.AddTable("mytable", table)
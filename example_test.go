@@ -14,7 +14,7 @@ func Example() {
 	// Create a test memory database and register it to the default engine.
 	e.AddDatabase(createTestDatabase())
 
-	_, r, err := e.Query(`SELECT name, count(*) FROM mytable
+	_, r, err := e.Query(gitqlsql.NewEmptyContext(), `SELECT name, count(*) FROM mytable
 	WHERE name = 'John Doe'
 	GROUP BY name`)
 	checkIfError(err)
@@ -49,9 +49,9 @@ func createTestDatabase() *mem.Database {
 		{Name: "email", Type: gitqlsql.Text},
 	})
 	db.AddTable("mytable", table)
-	table.Insert(gitqlsql.NewRow("John Doe", "john@doe.com"))
-	table.Insert(gitqlsql.NewRow("John Doe", "johnalt@doe.com"))
-	table.Insert(gitqlsql.NewRow("Jane Doe", "jane@doe.com"))
-	table.Insert(gitqlsql.NewRow("Evil Bob", "evilbob@gmail.com"))
+	table.Insert(gitqlsql.NewEmptyContext(), gitqlsql.NewRow("John Doe", "john@doe.com"))
+	table.Insert(gitqlsql.NewEmptyContext(), gitqlsql.NewRow("John Doe", "johnalt@doe.com"))
+	table.Insert(gitqlsql.NewEmptyContext(), gitqlsql.NewRow("Jane Doe", "jane@doe.com"))
+	table.Insert(gitqlsql.NewEmptyContext(), gitqlsql.NewRow("Evil Bob", "evilbob@gmail.com"))
 	return db
 }
@@ -0,0 +1,320 @@
+// Package federated implements a database whose tables live in another
+// MySQL server.
+//
+// The tables of the remote database are listed when the database is opened
+// and are read by sending SELECT queries to the remote server, streaming
+// their results. Filters on the columns of a table are sent in the WHERE
+// clause of the query, and only the columns used by a query are selected.
+package federated
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/src-d/go-vitess/mysql"
+	"github.com/src-d/go-vitess/vt/proto/query"
+)
+
+// maxIdleConns is the maximum number of idle connections to the remote
+// server kept by a database.
+const maxIdleConns = 4
+
+// Database is a database whose tables are the ones of a database in a
+// remote MySQL server. It is safe for concurrent use.
+type Database struct {
+	name   string
+	params *mysql.ConnParams
+
+	mu     sync.Mutex
+	tables map[string]sql.Table
+	idle   []*mysql.Conn
+	closed bool
+}
+
+// Open connects to the MySQL server and database of the given connection
+// parameters and returns it as a database with the given name.
+func Open(ctx context.Context, name string, params *mysql.ConnParams) (*Database, error) {
+	d := &Database{name: name, params: params}
+	if err := d.Refresh(ctx); err != nil {
+		_ = d.Close()
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Name implements the sql.Database interface.
+func (d *Database) Name() string {
+	return d.name
+}
+
+// Tables implements the sql.Database interface.
+func (d *Database) Tables() map[string]sql.Table {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.tables
+}
+
+// Refresh reads again the tables of the remote database and their schemas.
+func (d *Database) Refresh(ctx context.Context) error {
+	c, err := d.conn(ctx)
+	if err != nil {
+		return err
+	}
+
+	loc, err := readTimeZone(c)
+	if err != nil {
+		c.Close()
+		return err
+	}
+
+	tables, err := d.readTables(c, loc)
+	if err != nil {
+		c.Close()
+		return err
+	}
+
+	d.release(c)
+
+	d.mu.Lock()
+	d.tables = tables
+	d.mu.Unlock()
+	return nil
+}
+
+// readTimeZone returns the time zone of the sessions of the remote server,
+// in which it reads and sends TIMESTAMP values.
+func readTimeZone(c *mysql.Conn) (*time.Location, error) {
+	r, err := c.ExecuteFetch("SELECT @@session.time_zone, @@global.system_time_zone", 1, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(r.Rows) != 1 || len(r.Rows[0]) != 2 {
+		return nil, fmt.Errorf("federated: can't read the time zone of the remote server")
+	}
+
+	name := r.Rows[0][0].ToString()
+	if strings.EqualFold(name, "SYSTEM") {
+		name = r.Rows[0][1].ToString()
+	}
+
+	loc, err := sql.ParseTimeZone(name)
+	if err != nil {
+		return nil, fmt.Errorf("federated: time zone of the remote server: %s", err)
+	}
+
+	return loc, nil
+}
+
+func (d *Database) readTables(c *mysql.Conn, loc *time.Location) (map[string]sql.Table, error) {
+	result, err := c.ExecuteFetch("SHOW TABLES", -1, false)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make(map[string]sql.Table, len(result.Rows))
+	for _, row := range result.Rows {
+		if len(row) == 0 {
+			continue
+		}
+
+		name := row[0].ToString()
+		r, err := c.ExecuteFetch("SELECT * FROM "+quoteIdentifier(name)+" LIMIT 0", 0, true)
+		if err != nil {
+			return nil, fmt.Errorf("federated: can't read columns of table %s: %s", name, err)
+		}
+
+		schema := make(sql.Schema, len(r.Fields))
+		for i, f := range r.Fields {
+			schema[i] = &sql.Column{
+				Name:     f.Name,
+				Type:     fieldType(f),
+				Nullable: f.Flags&uint32(query.MySqlFlag_NOT_NULL_FLAG) == 0,
+			}
+		}
+
+		tables[name] = newTable(d, name, schema, loc)
+	}
+
+	return tables, nil
+}
+
+// conn returns an idle connection to the remote server or a new one.
+func (d *Database) conn(ctx context.Context) (*mysql.Conn, error) {
+	d.mu.Lock()
+	if d.closed {
+		d.mu.Unlock()
+		return nil, fmt.Errorf("federated: database %s is closed", d.name)
+	}
+
+	if n := len(d.idle); n > 0 {
+		c := d.idle[n-1]
+		d.idle = d.idle[:n-1]
+		d.mu.Unlock()
+		return c, nil
+	}
+	d.mu.Unlock()
+
+	return mysql.Connect(ctx, d.params)
+}
+
+// release returns a connection that is not in use to the idle connections,
+// or closes it if there are enough of them.
+func (d *Database) release(c *mysql.Conn) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.closed || len(d.idle) >= maxIdleConns {
+		c.Close()
+		return
+	}
+
+	d.idle = append(d.idle, c)
+}
+
+// Close closes the idle connections to the remote server. Connections in
+// use are closed when they are released.
+func (d *Database) Close() error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.closed = true
+	for _, c := range d.idle {
+		c.Close()
+	}
+	d.idle = nil
+	return nil
+}
+
+// fieldType returns the type of the values of a remote column.
+func fieldType(f *query.Field) sql.Type {
+	switch f.Type {
+	case query.Type_INT8:
+		// Booleans are TINYINT(1) columns.
+		if f.ColumnLength == 1 {
+			return sql.Boolean
+		}
+		return sql.Int8
+	case query.Type_INT16:
+		return sql.Int16
+	case query.Type_INT24:
+		return sql.Int24
+	case query.Type_INT32:
+		return sql.Int32
+	case query.Type_INT64:
+		return sql.Int64
+	case query.Type_UINT8:
+		return sql.Uint8
+	case query.Type_UINT16:
+		return sql.Uint16
+	case query.Type_UINT24:
+		return sql.Uint24
+	case query.Type_UINT32:
+		return sql.Uint32
+	case query.Type_UINT64:
+		return sql.Uint64
+	case query.Type_FLOAT32:
+		return sql.Float32
+	case query.Type_FLOAT64:
+		return sql.Float64
+	case query.Type_DECIMAL:
+		return decimalType(f)
+	case query.Type_TIMESTAMP:
+		return sql.Timestamp
+	case query.Type_DATETIME:
+		precision := int(f.Decimals)
+		if precision > sql.MaxDatetimePrecision {
+			precision = sql.MaxDatetimePrecision
+		}
+		return sql.Datetime(precision)
+	case query.Type_DATE:
+		return sql.Date
+	case query.Type_TIME:
+		return sql.Time
+	case query.Type_YEAR:
+		return sql.Year
+	case query.Type_BIT:
+		length := int(f.ColumnLength)
+		if length < 1 || length > sql.MaxBitLength {
+			length = sql.MaxBitLength
+		}
+		return sql.Bit(length)
+	case query.Type_CHAR, query.Type_VARCHAR, query.Type_TEXT,
+		query.Type_BINARY, query.Type_VARBINARY, query.Type_BLOB:
+		return stringType(f)
+	case query.Type_JSON:
+		return sql.JSON
+	default:
+		return sql.Text
+	}
+}
+
+// stringType returns the string type of a remote column, whose length is
+// in bytes. Its character set is the one of the column, with the default
+// collation.
+func stringType(f *query.Field) sql.Type {
+	binary := f.Type == query.Type_BINARY || f.Type == query.Type_VARBINARY ||
+		f.Type == query.Type_BLOB
+	if binary {
+		t, err := sql.CreateBinary(f.Type, int64(f.ColumnLength))
+		if err != nil {
+			return sql.LongBlob
+		}
+		return t
+	}
+
+	charset := characterSet(f.Charset)
+	length := int64(f.ColumnLength) / sql.MaxCharacterBytes(charset)
+	t, err := sql.CreateString(f.Type, length, charset, "")
+	if err != nil {
+		return sql.LongText
+	}
+
+	return t
+}
+
+// characterSet returns the name of the character set of a collation id
+// sent by a remote server.
+func characterSet(id uint32) string {
+	switch {
+	case id == mysql.CharacterSetBinary:
+		return sql.BinaryCharacterSet
+	case id == 45 || id == 46 || id >= 224 && id <= 247 || id == 255:
+		return "utf8mb4"
+	case id == 8 || id == 47 || id == 48:
+		return "latin1"
+	default:
+		return sql.DefaultCharacterSet
+	}
+}
+
+// decimalType returns the DECIMAL type of a remote column, whose length
+// includes the decimal point and, if the column is signed, the sign.
+func decimalType(f *query.Field) sql.Type {
+	scale := int(f.Decimals)
+	precision := int(f.ColumnLength)
+	if scale > 0 {
+		precision--
+	}
+	if f.Flags&uint32(query.MySqlFlag_UNSIGNED_FLAG) == 0 {
+		precision--
+	}
+
+	if precision < 1 || precision > sql.MaxDecimalPrecision ||
+		scale > sql.MaxDecimalScale || scale > precision {
+		return sql.Decimal(sql.MaxDecimalPrecision, sql.MaxDecimalScale)
+	}
+
+	return sql.Decimal(precision, scale)
+}
+
+// quoteIdentifier quotes the name of a table or column.
+func quoteIdentifier(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
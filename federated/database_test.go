@@ -0,0 +1,229 @@
+package federated
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	sqle "github.com/src-d/go-mysql-server"
+	"github.com/src-d/go-mysql-server/mem"
+	"github.com/src-d/go-mysql-server/server"
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/decimal"
+	"github.com/src-d/go-mysql-server/sql/expression"
+
+	"github.com/src-d/go-vitess/mysql"
+	"github.com/src-d/go-vitess/sqltypes"
+	"github.com/stretchr/testify/require"
+)
+
+var created = time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+
+// startRemote starts a server with a database to be used as the remote
+// server of the tests.
+func startRemote(t *testing.T) (*server.Server, *mysql.ConnParams) {
+	db := mem.NewDatabase("remote")
+	people := mem.NewTable("people", sql.Schema{
+		{Name: "id", Type: sql.Int64},
+		{Name: "name", Type: sql.Text, Nullable: true},
+		{Name: "admin", Type: sql.Boolean},
+		{Name: "created", Type: sql.Timestamp, Nullable: true},
+		{Name: "balance", Type: sql.Decimal(10, 2)},
+	})
+	db.AddTable("people", people)
+
+	ctx := sql.NewEmptyContext()
+	require.NoError(t, people.Insert(ctx, sql.NewRow(int64(1), "john", true, created, decimal.MustParse("1234.50"))))
+	require.NoError(t, people.Insert(ctx, sql.NewRow(int64(2), "jane", false, nil, decimal.MustParse("-0.05"))))
+	require.NoError(t, people.Insert(ctx, sql.NewRow(int64(3), nil, false, created, decimal.MustParse("0.00"))))
+
+	e := sqle.New()
+	e.AddDatabase(db)
+
+	auth := mysql.NewAuthServerStatic()
+	auth.Entries["user"] = []*mysql.AuthServerStaticEntry{{Password: "pass"}}
+
+	s, err := server.NewServer("tcp", "127.0.0.1:0", auth, e)
+	require.NoError(t, err)
+	go s.Start()
+
+	addr := s.Listener.Addr().(*net.TCPAddr)
+	return s, &mysql.ConnParams{
+		Host:   addr.IP.String(),
+		Port:   addr.Port,
+		Uname:  "user",
+		Pass:   "pass",
+		DbName: "remote",
+	}
+}
+
+func TestDatabase(t *testing.T) {
+	require := require.New(t)
+
+	s, params := startRemote(t)
+	defer s.Listener.Close()
+
+	db, err := Open(context.Background(), "fed", params)
+	require.NoError(err)
+	defer db.Close()
+	require.Equal("fed", db.Name())
+
+	tables := db.Tables()
+	require.Len(tables, 1)
+
+	people := tables["people"]
+	require.Equal(sql.Schema{
+		{Name: "id", Type: sql.Int64},
+		{Name: "name", Type: sql.Text, Nullable: true},
+		{Name: "admin", Type: sql.Boolean},
+		{Name: "created", Type: sql.Timestamp, Nullable: true},
+		{Name: "balance", Type: sql.Decimal(10, 2)},
+	}, people.Schema())
+
+	expected := []sql.Row{
+		{int64(1), "john", true, created, decimal.MustParse("1234.50")},
+		{int64(2), "jane", false, nil, decimal.MustParse("-0.05")},
+		{int64(3), nil, false, created, decimal.MustParse("0.00")},
+	}
+
+	// Reading twice reuses the connection.
+	for i := 0; i < 2; i++ {
+		rows, err := sql.NodeToRows(sql.NewEmptyContext(), people)
+		require.NoError(err)
+		require.Equal(expected, rows)
+	}
+
+	require.Len(db.idle, 1)
+}
+
+func TestTable_Pushdown(t *testing.T) {
+	require := require.New(t)
+
+	s, params := startRemote(t)
+	defer s.Listener.Close()
+
+	db, err := Open(context.Background(), "fed", params)
+	require.NoError(err)
+	defer db.Close()
+
+	table := db.Tables()["people"].(*Table)
+
+	id := expression.NewGreaterThan(
+		expression.NewGetField(0, sql.Int64, "id", false),
+		expression.NewLiteral(int64(1), sql.Int64),
+	)
+	name := expression.NewLessThan(
+		expression.NewGetField(1, sql.Text, "name", true),
+		expression.NewLiteral("k", sql.Text),
+	)
+	notNull := expression.NewNot(expression.NewIsNull(
+		expression.NewGetField(3, sql.Timestamp, "created", true),
+	))
+
+	handled := table.HandledFilters([]sql.Expression{id, name, notNull})
+	require.Equal([]sql.Expression{id, notNull}, handled)
+
+	filtered := table.WithFilters(handled).(*Table).WithProjection([]int{0, 3}).(*Table)
+	q, _, err := filtered.query()
+	require.NoError(err)
+	require.Equal(
+		"SELECT `id`, `created` FROM `people` WHERE `id` > 1 AND NOT `created` IS NULL",
+		q,
+	)
+
+	filtered = table.WithFilters(handled[:1]).(*Table).WithProjection([]int{0, 3}).(*Table)
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), filtered)
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(2), nil, nil, nil, nil}, {int64(3), nil, nil, created, nil}}, rows)
+}
+
+func TestDatabase_Join(t *testing.T) {
+	require := require.New(t)
+
+	s, params := startRemote(t)
+	defer s.Listener.Close()
+
+	fed, err := Open(context.Background(), "fed", params)
+	require.NoError(err)
+	defer fed.Close()
+
+	local := mem.NewDatabase("local")
+	roles := mem.NewTable("roles", sql.Schema{
+		{Name: "person", Type: sql.Int64},
+		{Name: "role", Type: sql.Text},
+	})
+	local.AddTable("roles", roles)
+
+	ctx := sql.NewEmptyContext()
+	require.NoError(roles.Insert(ctx, sql.NewRow(int64(1), "owner")))
+	require.NoError(roles.Insert(ctx, sql.NewRow(int64(3), "guest")))
+
+	e := sqle.New()
+	e.AddDatabase(fed)
+	e.AddDatabase(local)
+
+	_, iter, err := e.Query(ctx, "SELECT role, name FROM roles, fed.people WHERE person = id")
+	require.NoError(err)
+
+	rows, err := sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Equal([]sql.Row{{"owner", "john"}, {"guest", nil}}, rows)
+
+	_, iter, err = e.Query(ctx, "SELECT name FROM fed.people WHERE admin = false")
+	require.NoError(err)
+
+	rows, err = sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Equal([]sql.Row{{"jane"}, {nil}}, rows)
+}
+
+func TestDatabase_TimeZone(t *testing.T) {
+	require := require.New(t)
+
+	s, params := startRemote(t)
+	defer s.Listener.Close()
+
+	c, err := mysql.Connect(context.Background(), params)
+	require.NoError(err)
+	_, err = c.ExecuteFetch("SET GLOBAL time_zone = '+02:00'", 0, false)
+	c.Close()
+	require.NoError(err)
+
+	db, err := Open(context.Background(), "fed", params)
+	require.NoError(err)
+	defer db.Close()
+
+	// The remote server sends and reads timestamps in its time zone.
+	table := db.Tables()["people"].(*Table)
+	filtered := table.WithFilters([]sql.Expression{
+		expression.NewEquals(
+			expression.NewGetField(3, sql.Timestamp, "created", true),
+			expression.NewLiteral(created, sql.Timestamp),
+		),
+	}).(*Table).WithProjection([]int{0, 3}).(*Table)
+
+	q, _, err := filtered.query()
+	require.NoError(err)
+	require.Equal(
+		"SELECT `id`, `created` FROM `people` WHERE `created` = '2018-01-02 05:04:05'",
+		q,
+	)
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), filtered)
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(1), nil, nil, created, nil}, {int64(3), nil, nil, created, nil}}, rows)
+}
+
+func TestParseValue(t *testing.T) {
+	require := require.New(t)
+
+	v, err := parseValue(sql.JSON, sqltypes.MakeTrusted(sqltypes.TypeJSON, []byte(`{"a": [1]}`)), time.UTC)
+	require.NoError(err)
+	require.Equal(sql.JSONDocument{Val: map[string]interface{}{"a": []interface{}{1.0}}}, v)
+
+	loc := time.FixedZone("", -5*60*60)
+	v, err = parseValue(sql.Timestamp, sqltypes.MakeTrusted(sqltypes.Timestamp, []byte("2018-01-01 22:04:05")), loc)
+	require.NoError(err)
+	require.Equal(created, v)
+}
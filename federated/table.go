@@ -0,0 +1,410 @@
+package federated
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/expression"
+
+	"github.com/src-d/go-vitess/mysql"
+	"github.com/src-d/go-vitess/sqltypes"
+)
+
+// Table is a table of a remote database.
+type Table struct {
+	db     *Database
+	name   string
+	schema sql.Schema
+	// loc is the time zone of the sessions of the remote server, in which
+	// TIMESTAMP values are read and sent.
+	loc *time.Location
+
+	// filters are sent to the remote server in the WHERE clause. As the
+	// remote server may compare values in a different way, for example
+	// ignoring the case of strings, they are also applied to the rows it
+	// returns.
+	filters []sql.Expression
+	// columns are the indexes of the columns read, or nil if all of them
+	// are read.
+	columns []int
+}
+
+func newTable(db *Database, name string, schema sql.Schema, loc *time.Location) *Table {
+	return &Table{db: db, name: name, schema: schema, loc: loc}
+}
+
+// Resolved implements the Resolvable interface.
+func (*Table) Resolved() bool {
+	return true
+}
+
+// Name implements the Nameable interface.
+func (t *Table) Name() string {
+	return t.name
+}
+
+// Schema implements the Node interface.
+func (t *Table) Schema() sql.Schema {
+	return t.schema
+}
+
+// Children implements the Node interface.
+func (t *Table) Children() []sql.Node {
+	return []sql.Node{}
+}
+
+// TransformUp implements the Transformable interface.
+func (t *Table) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(t)
+}
+
+// TransformExpressionsUp implements the Transformable interface. The
+// expressions of the table are its filters.
+func (t *Table) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	if len(t.filters) == 0 {
+		return t
+	}
+
+	filters := make([]sql.Expression, len(t.filters))
+	for i, e := range t.filters {
+		filters[i] = e.TransformUp(f)
+	}
+
+	return t.WithFilters(filters)
+}
+
+// HandledFilters implements the sql.FilteredTable interface. Filters are
+// handled if they can be written as SQL that the remote server evaluates
+// to true for, at least, the same rows.
+func (t *Table) HandledFilters(filters []sql.Expression) []sql.Expression {
+	var handled []sql.Expression
+	for _, f := range filters {
+		if canRender(f) {
+			handled = append(handled, f)
+		}
+	}
+
+	return handled
+}
+
+// WithFilters implements the sql.FilteredTable interface.
+func (t *Table) WithFilters(filters []sql.Expression) sql.Table {
+	n := *t
+	n.filters = filters
+	return &n
+}
+
+// WithProjection implements the sql.ProjectedTable interface.
+func (t *Table) WithProjection(columns []int) sql.Table {
+	n := *t
+	n.columns = columns
+	return &n
+}
+
+// query returns the SELECT query that reads the table and the indexes of
+// the columns it returns.
+func (t *Table) query() (string, []int, error) {
+	columns := t.columns
+	if columns == nil {
+		columns = make([]int, len(t.schema))
+		for i := range columns {
+			columns[i] = i
+		}
+	}
+
+	// The query needs at least one column.
+	if len(columns) == 0 && len(t.schema) > 0 {
+		columns = []int{0}
+	}
+
+	names := make([]string, len(columns))
+	for i, c := range columns {
+		names[i] = quoteIdentifier(t.schema[c].Name)
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString("SELECT ")
+	buf.WriteString(strings.Join(names, ", "))
+	buf.WriteString(" FROM ")
+	buf.WriteString(quoteIdentifier(t.name))
+
+	for i, f := range t.filters {
+		if i == 0 {
+			buf.WriteString(" WHERE ")
+		} else {
+			buf.WriteString(" AND ")
+		}
+
+		if err := render(&buf, t.schema, t.loc, f); err != nil {
+			return "", nil, err
+		}
+	}
+
+	return buf.String(), columns, nil
+}
+
+// RowIter implements the Node interface.
+func (t *Table) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	q, columns, err := t.query()
+	if err != nil {
+		return nil, err
+	}
+
+	c, err := t.db.conn(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.ExecuteStreamFetch(q); err != nil {
+		c.Close()
+		if _, ok := err.(*mysql.SQLError); ok && !isConnectionError(err) {
+			return nil, err
+		}
+
+		// The connection may have been closed by the server while it was
+		// idle, so the query is sent again through a new one.
+		if c, err = mysql.Connect(ctx, t.db.params); err != nil {
+			return nil, err
+		}
+
+		if err := c.ExecuteStreamFetch(q); err != nil {
+			c.Close()
+			return nil, err
+		}
+	}
+
+	return &rowIter{t: t, conn: c, columns: columns}, nil
+}
+
+func isConnectionError(err error) bool {
+	code := err.(*mysql.SQLError).Number()
+	return code == mysql.CRServerGone || code == mysql.CRServerLost
+}
+
+type rowIter struct {
+	t       *Table
+	conn    *mysql.Conn
+	columns []int
+}
+
+func (i *rowIter) Next() (sql.Row, error) {
+	for {
+		if i.conn == nil {
+			return nil, io.EOF
+		}
+
+		values, err := i.conn.FetchNext()
+		if err != nil {
+			i.conn.Close()
+			i.conn = nil
+			return nil, err
+		}
+
+		if values == nil {
+			i.t.db.release(i.conn)
+			i.conn = nil
+			return nil, io.EOF
+		}
+
+		row := make(sql.Row, len(i.t.schema))
+		for j, v := range values {
+			if j >= len(i.columns) {
+				break
+			}
+
+			c := i.t.schema[i.columns[j]]
+			if row[i.columns[j]], err = parseValue(c.Type, v, i.t.loc); err != nil {
+				return nil, fmt.Errorf("federated: invalid value for column %s: %s", c.Name, err)
+			}
+		}
+
+		if i.matches(row) {
+			return row, nil
+		}
+	}
+}
+
+func (i *rowIter) matches(row sql.Row) bool {
+	for _, f := range i.t.filters {
+		if f.Eval(row) != true {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Close closes the connection if not all the rows were read, as reading
+// them could take long.
+func (i *rowIter) Close() error {
+	if i.conn != nil {
+		i.conn.Close()
+		i.conn = nil
+	}
+
+	return nil
+}
+
+// parseValue returns the value of a remote value of a column of the given
+// type. TIMESTAMP values are read as times in the given time zone, which is
+// the one of the remote session.
+func parseValue(typ sql.Type, v sqltypes.Value, loc *time.Location) (interface{}, error) {
+	if v.IsNull() {
+		return nil, nil
+	}
+
+	s := v.ToString()
+	switch typ {
+	case sql.Int32, sql.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return typ.Convert(n)
+	case sql.Uint32, sql.Uint64:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		return typ.Convert(n)
+	case sql.Float32, sql.Float64:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+		return typ.Convert(n)
+	case sql.Timestamp:
+		t, err := sql.ParseTime(s, loc)
+		if err != nil {
+			return nil, err
+		}
+
+		if !t.IsZero() {
+			t = t.UTC()
+		}
+		return t, nil
+	case sql.Blob:
+		return v.ToBytes(), nil
+	case sql.JSON:
+		return sql.JSON.Convert(v.ToBytes())
+	default:
+		return typ.Convert(s)
+	}
+}
+
+// canRender returns whether a filter can be sent to the remote server.
+// Filters are comparisons of values, which are only compared for equality
+// unless they are not strings, as remote servers may use a collation that
+// orders strings differently. As their operands are always values, filters
+// are written without parentheses.
+func canRender(e sql.Expression) bool {
+	switch e := e.(type) {
+	case *expression.IsNull:
+		return isValue(e.Child)
+	case *expression.Not:
+		_, ok := e.Child.(*expression.IsNull)
+		return ok && canRender(e.Child)
+	case *expression.Equals:
+		return isValue(e.Left) && isValue(e.Right)
+	case *expression.GreaterThan:
+		return canCompare(e.Left, e.Right)
+	case *expression.LessThan:
+		return canCompare(e.Left, e.Right)
+	case *expression.GreaterThanOrEqual:
+		return canCompare(e.Left, e.Right)
+	case *expression.LessThanOrEqual:
+		return canCompare(e.Left, e.Right)
+	default:
+		return false
+	}
+}
+
+func canCompare(left, right sql.Expression) bool {
+	return !sql.IsText(left.Type()) && !sql.IsText(right.Type()) &&
+		isValue(left) && isValue(right)
+}
+
+// isValue returns whether an expression is a column or a value that can be
+// sent to the remote server.
+func isValue(e sql.Expression) bool {
+	switch e.(type) {
+	case *expression.GetField, *expression.Literal, *expression.Bindvar:
+		return e.Type() != sql.JSON
+	default:
+		return false
+	}
+}
+
+// render writes the SQL of a filter. TIMESTAMP values are written as times
+// in the given time zone, which is the one of the remote session.
+func render(buf *bytes.Buffer, schema sql.Schema, loc *time.Location, e sql.Expression) error {
+	switch e := e.(type) {
+	case *expression.GetField:
+		buf.WriteString(quoteIdentifier(schema[e.Index()].Name))
+	case *expression.Literal:
+		renderValue(buf, e.Type(), e.Eval(nil), loc)
+	case *expression.Bindvar:
+		if !e.Bound() {
+			return fmt.Errorf("federated: unbound variable %s", e.Name())
+		}
+		renderValue(buf, e.Type(), e.Eval(nil), loc)
+	case *expression.IsNull:
+		if err := render(buf, schema, loc, e.Child); err != nil {
+			return err
+		}
+		buf.WriteString(" IS NULL")
+	case *expression.Not:
+		buf.WriteString("NOT ")
+		return render(buf, schema, loc, e.Child)
+	case *expression.Equals:
+		return renderBinary(buf, schema, loc, e.Left, "=", e.Right)
+	case *expression.GreaterThan:
+		return renderBinary(buf, schema, loc, e.Left, ">", e.Right)
+	case *expression.LessThan:
+		return renderBinary(buf, schema, loc, e.Left, "<", e.Right)
+	case *expression.GreaterThanOrEqual:
+		return renderBinary(buf, schema, loc, e.Left, ">=", e.Right)
+	case *expression.LessThanOrEqual:
+		return renderBinary(buf, schema, loc, e.Left, "<=", e.Right)
+	default:
+		return fmt.Errorf("federated: can't render expression %s", e.Name())
+	}
+
+	return nil
+}
+
+func renderBinary(buf *bytes.Buffer, schema sql.Schema, loc *time.Location, left sql.Expression, op string, right sql.Expression) error {
+	if err := render(buf, schema, loc, left); err != nil {
+		return err
+	}
+
+	buf.WriteString(" " + op + " ")
+	return render(buf, schema, loc, right)
+}
+
+func renderValue(buf *bytes.Buffer, typ sql.Type, v interface{}, loc *time.Location) {
+	if t, ok := v.(time.Time); ok && typ == sql.Timestamp && !t.IsZero() {
+		v = t.In(loc)
+	}
+
+	switch {
+	case v == nil:
+		buf.WriteString("NULL")
+	case typ == sql.Boolean:
+		if b, _ := typ.Convert(v); b == true {
+			buf.WriteString("TRUE")
+		} else {
+			buf.WriteString("FALSE")
+		}
+	case typ == sql.Float32 || typ == sql.Float64:
+		f, _ := sql.Float64.Convert(v)
+		buf.WriteString(strconv.FormatFloat(f.(float64), 'g', -1, 64))
+	default:
+		typ.SQL(v).EncodeSQL(buf)
+	}
+}
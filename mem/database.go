@@ -1,9 +1,21 @@
 package mem
 
-import "github.com/src-d/go-mysql-server/sql"
+import (
+	"sync"
 
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// Database is an in-memory database. It supports transactions, which see the
+// rows committed when they started and their own changes.
+// It is safe for concurrent use.
 type Database struct {
-	name   string
+	name string
+
+	// mu guards the tables of the database. It's also held while
+	// transactions are committed and snapshotted, so they see the changes
+	// of other transactions either completely or not at all.
+	mu     sync.RWMutex
 	tables map[string]sql.Table
 }
 
@@ -18,10 +30,146 @@ func (d *Database) Name() string {
 	return d.name
 }
 
+// Tables returns a copy of the tables of the database by name.
 func (d *Database) Tables() map[string]sql.Table {
-	return d.tables
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	tables := make(map[string]sql.Table, len(d.tables))
+	for name, t := range d.tables {
+		tables[name] = t
+	}
+
+	return tables
 }
 
 func (d *Database) AddTable(name string, t *Table) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	t.mu.Lock()
+	t.db = d
+	t.mu.Unlock()
+
 	d.tables[name] = t
 }
+
+// BeginTransaction implements the sql.TransactionalDatabase interface.
+func (d *Database) BeginTransaction() (sql.Transaction, error) {
+	d.mu.RLock()
+	defer d.mu.RUnlock()
+
+	snapshot := make(map[*Table][]sql.Row, len(d.tables))
+	for _, t := range d.tables {
+		if t, ok := t.(*Table); ok {
+			snapshot[t] = t.committed()
+		}
+	}
+
+	return &transaction{
+		db:       d,
+		snapshot: snapshot,
+		inserted: make(map[*Table][]sql.Row),
+	}, nil
+}
+
+// transaction is a transaction of a Database. Rows inserted in it are kept
+// apart until it's committed.
+type transaction struct {
+	db       *Database
+	snapshot map[*Table][]sql.Row
+
+	mu       sync.Mutex
+	inserted map[*Table][]sql.Row
+	// statement holds the number of rows inserted in each table when the
+	// current statement started. Tables without an entry had none.
+	statement map[*Table]int
+	closed    bool
+}
+
+// rows returns the rows of the table seen by the transaction.
+func (tx *transaction) rows(t *Table) []sql.Row {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	snapshot, ok := tx.snapshot[t]
+	if !ok {
+		// The table was added after the transaction started.
+		snapshot = t.committed()
+	}
+
+	return append(snapshot[:len(snapshot):len(snapshot)], tx.inserted[t]...)
+}
+
+func (tx *transaction) insert(t *Table, row sql.Row) error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	if tx.closed {
+		return sql.ErrTransactionClosed
+	}
+
+	tx.inserted[t] = append(tx.inserted[t], row)
+	return nil
+}
+
+// BeginStatement implements the sql.StatementTransaction interface.
+func (tx *transaction) BeginStatement() {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	tx.statement = make(map[*Table]int, len(tx.inserted))
+	for t, rows := range tx.inserted {
+		tx.statement[t] = len(rows)
+	}
+}
+
+// RollbackStatement implements the sql.StatementTransaction interface.
+func (tx *transaction) RollbackStatement() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	if tx.closed {
+		return sql.ErrTransactionClosed
+	}
+
+	for t, rows := range tx.inserted {
+		tx.inserted[t] = rows[:tx.statement[t]]
+	}
+
+	return nil
+}
+
+// Commit implements the sql.Transaction interface.
+func (tx *transaction) Commit() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	if tx.closed {
+		return sql.ErrTransactionClosed
+	}
+
+	tx.db.mu.Lock()
+	for t, rows := range tx.inserted {
+		t.append(rows...)
+	}
+	tx.db.mu.Unlock()
+
+	tx.closed = true
+	tx.inserted = nil
+	return nil
+}
+
+// Rollback implements the sql.Transaction interface.
+func (tx *transaction) Rollback() error {
+	tx.mu.Lock()
+	defer tx.mu.Unlock()
+
+	if tx.closed {
+		return sql.ErrTransactionClosed
+	}
+
+	tx.closed = true
+	tx.inserted = nil
+	return nil
+}
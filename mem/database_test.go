@@ -19,7 +19,7 @@ func TestDatabase_AddTable(t *testing.T) {
 	db := NewDatabase("test")
 	tables := db.Tables()
 	assert.Equal(0, len(tables))
-	table := &Table{"test_table", sql.Schema{}, nil}
+	table := NewTable("test_table", sql.Schema{})
 	db.AddTable("test_table", table)
 	tables = db.Tables()
 	assert.Equal(1, len(tables))
@@ -27,3 +27,81 @@ func TestDatabase_AddTable(t *testing.T) {
 	assert.True(ok)
 	assert.NotNil(tt)
 }
+
+func TestDatabase_Transaction(t *testing.T) {
+	assert := assert.New(t)
+
+	db := NewDatabase("test")
+	table := NewTable("test_table", sql.Schema{
+		{Name: "col1", Type: sql.Text},
+	})
+	db.AddTable("test_table", table)
+
+	ctx1 := sql.NewEmptyContext()
+	ctx2 := sql.NewEmptyContext()
+	assert.Nil(table.Insert(ctx1, sql.NewRow("foo")))
+
+	assert.Nil(ctx1.BeginTransaction())
+	assert.Nil(ctx2.BeginTransaction())
+	assert.Nil(table.Insert(ctx1, sql.NewRow("bar")))
+
+	rows, err := sql.NodeToRows(ctx1, table)
+	assert.Nil(err)
+	assert.Equal([]sql.Row{{"foo"}, {"bar"}}, rows)
+
+	rows, err = sql.NodeToRows(ctx2, table)
+	assert.Nil(err)
+	assert.Equal([]sql.Row{{"foo"}}, rows)
+
+	assert.Nil(ctx1.CommitTransaction())
+
+	rows, err = sql.NodeToRows(ctx2, table)
+	assert.Nil(err)
+	assert.Equal([]sql.Row{{"foo"}}, rows)
+
+	assert.Nil(ctx2.RollbackTransaction())
+
+	rows, err = sql.NodeToRows(ctx2, table)
+	assert.Nil(err)
+	assert.Equal([]sql.Row{{"foo"}, {"bar"}}, rows)
+
+	tx, err := db.BeginTransaction()
+	assert.Nil(err)
+	assert.Nil(tx.Rollback())
+	assert.Equal(sql.ErrTransactionClosed, tx.Commit())
+}
+
+func TestDatabase_RollbackStatement(t *testing.T) {
+	assert := assert.New(t)
+
+	db := NewDatabase("test")
+	table := NewTable("test_table", sql.Schema{
+		{Name: "col1", Type: sql.Text},
+	})
+	db.AddTable("test_table", table)
+
+	ctx := sql.NewEmptyContext()
+	assert.Nil(ctx.BeginTransaction())
+
+	// The transaction starts during the statement, so all its rows are
+	// discarded.
+	ctx.BeginStatement()
+	assert.Nil(table.Insert(ctx, sql.NewRow("foo")))
+	assert.Nil(ctx.RollbackStatement())
+
+	ctx.BeginStatement()
+	assert.Nil(table.Insert(ctx, sql.NewRow("bar")))
+
+	ctx.BeginStatement()
+	assert.Nil(table.Insert(ctx, sql.NewRow("baz")))
+	assert.Nil(ctx.RollbackStatement())
+
+	rows, err := sql.NodeToRows(ctx, table)
+	assert.Nil(err)
+	assert.Equal([]sql.Row{{"bar"}}, rows)
+
+	assert.Nil(ctx.CommitTransaction())
+	rows, err = sql.NodeToRows(ctx, table)
+	assert.Nil(err)
+	assert.Equal([]sql.Row{{"bar"}}, rows)
+}
@@ -2,14 +2,24 @@ package mem
 
 import (
 	"fmt"
+	"sync"
 
 	"github.com/src-d/go-mysql-server/sql"
 )
 
+// Table is an in-memory table. It is safe for concurrent use, and iterators
+// return the rows the table had when they were created.
 type Table struct {
 	name   string
 	schema sql.Schema
-	data   []sql.Row
+
+	// mu guards the rows and the database of the table. Rows are only
+	// appended, never modified, so a prefix of data can be read without
+	// holding it.
+	mu   sync.RWMutex
+	data []sql.Row
+	// db is the database the table belongs to, if any.
+	db *Database
 }
 
 func NewTable(name string, schema sql.Schema) *Table {
@@ -19,7 +29,7 @@ func NewTable(name string, schema sql.Schema) *Table {
 	}
 }
 
-func (Table) Resolved() bool {
+func (*Table) Resolved() bool {
 	return true
 }
 
@@ -35,8 +45,54 @@ func (t *Table) Children() []sql.Node {
 	return []sql.Node{}
 }
 
-func (t *Table) RowIter() (sql.RowIter, error) {
-	return sql.RowsToRowIter(t.data...), nil
+// RowIter implements the Node interface. Inside a transaction it returns
+// the rows seen by the transaction.
+func (t *Table) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	tx, err := t.transaction(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	if tx != nil {
+		return sql.RowsToRowIter(tx.rows(t)...), nil
+	}
+
+	return sql.RowsToRowIter(t.committed()...), nil
+}
+
+// committed returns the committed rows of the table.
+func (t *Table) committed() []sql.Row {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.data[:len(t.data):len(t.data)]
+}
+
+func (t *Table) append(rows ...sql.Row) {
+	t.mu.Lock()
+	t.data = append(t.data, rows...)
+	t.mu.Unlock()
+}
+
+func (t *Table) database() *Database {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return t.db
+}
+
+// transaction returns the transaction of the session of the context in the
+// database of the table, or nil if there is none.
+func (t *Table) transaction(ctx *sql.Context) (*transaction, error) {
+	db := t.database()
+	if db == nil || ctx == nil || ctx.Session == nil {
+		return nil, nil
+	}
+
+	tx, err := ctx.Transaction(db)
+	if err != nil || tx == nil {
+		return nil, err
+	}
+
+	return tx.(*transaction), nil
 }
 
 func (t *Table) TransformUp(f func(sql.Node) sql.Node) sql.Node {
@@ -47,7 +103,8 @@ func (t *Table) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sq
 	return t
 }
 
-func (t *Table) Insert(row sql.Row) error {
+// Insert implements the sql.Inserter interface.
+func (t *Table) Insert(ctx *sql.Context, row sql.Row) error {
 	if len(row) != len(t.schema) {
 		return fmt.Errorf("insert expected %d values, got %d", len(t.schema), len(row))
 	}
@@ -59,6 +116,15 @@ func (t *Table) Insert(row sql.Row) error {
 		}
 	}
 
-	t.data = append(t.data, row.Copy())
+	tx, err := t.transaction(ctx)
+	if err != nil {
+		return err
+	}
+
+	if tx != nil {
+		return tx.insert(t, row.Copy())
+	}
+
+	t.append(row.Copy())
 	return nil
 }
@@ -1,6 +1,7 @@
 package mem
 
 import (
+	"sync"
 	"testing"
 
 	"github.com/src-d/go-mysql-server/sql"
@@ -19,26 +20,83 @@ func TestTable_Name(t *testing.T) {
 
 func TestTable_Insert_RowIter(t *testing.T) {
 	assert := assert.New(t)
+	ctx := sql.NewEmptyContext()
 	s := sql.Schema{
 		{"col1", sql.Text, nil, true},
 	}
 
 	table := NewTable("test", s)
 
-	rows, err := sql.NodeToRows(table)
+	rows, err := sql.NodeToRows(ctx, table)
 	assert.Nil(err)
 	assert.Len(rows, 0)
 
-	err = table.Insert(sql.NewRow("foo"))
-	rows, err = sql.NodeToRows(table)
+	err = table.Insert(ctx, sql.NewRow("foo"))
+	rows, err = sql.NodeToRows(ctx, table)
 	assert.Nil(err)
 	assert.Len(rows, 1)
 	assert.Nil(s.CheckRow(rows[0]))
 
-	err = table.Insert(sql.NewRow("bar"))
-	rows, err = sql.NodeToRows(table)
+	err = table.Insert(ctx, sql.NewRow("bar"))
+	rows, err = sql.NodeToRows(ctx, table)
 	assert.Nil(err)
 	assert.Len(rows, 2)
 	assert.Nil(s.CheckRow(rows[0]))
 	assert.Nil(s.CheckRow(rows[1]))
 }
+
+func TestTable_Concurrent(t *testing.T) {
+	assert := assert.New(t)
+
+	db := NewDatabase("test")
+	table := NewTable("test", sql.Schema{
+		{Name: "col1", Type: sql.Int64},
+	})
+	db.AddTable("test", table)
+
+	const (
+		writers = 4
+		rows    = 100
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx := sql.NewEmptyContext()
+			for j := 0; j < rows; j++ {
+				if i%2 == 0 {
+					assert.NoError(table.Insert(ctx, sql.NewRow(int64(j))))
+					continue
+				}
+
+				assert.NoError(ctx.BeginTransaction())
+				assert.NoError(table.Insert(ctx, sql.NewRow(int64(j))))
+				assert.NoError(ctx.CommitTransaction())
+			}
+		}(i)
+	}
+
+	for i := 0; i < writers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := sql.NewEmptyContext()
+			var last int
+			for j := 0; j < rows; j++ {
+				rows, err := sql.NodeToRows(ctx, table)
+				assert.NoError(err)
+				assert.True(len(rows) >= last)
+				last = len(rows)
+				_ = db.Tables()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	result, err := sql.NodeToRows(sql.NewEmptyContext(), table)
+	assert.NoError(err)
+	assert.Len(result, writers*rows)
+}
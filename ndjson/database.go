@@ -0,0 +1,88 @@
+// Package ndjson exposes a directory of newline delimited JSON files as a
+// database.
+//
+// Every file with a .jsonl or .ndjson extension is a table named after the
+// file without its extension. Each line of a file is a JSON object, and the
+// columns of the table are the top-level fields found in the first records
+// of the file. Nested objects and arrays are JSON columns. Files are read as
+// they are iterated, and filters on the top-level fields are applied before
+// the other fields of a line are decoded.
+package ndjson
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// DefaultInferRecords is the default number of records read to infer the
+// columns of a file.
+const DefaultInferRecords = 100
+
+// Options of a Database.
+type Options struct {
+	// InferRecords is the number of records read to infer the columns of
+	// the files. If it's 0, DefaultInferRecords is used.
+	InferRecords int
+}
+
+// Database is a database whose tables are the newline delimited JSON files
+// of a directory.
+type Database struct {
+	name   string
+	tables map[string]sql.Table
+}
+
+// Open opens the files of the given directory as a database with the given
+// name.
+func Open(name, dir string, opts Options) (*Database, error) {
+	if opts.InferRecords <= 0 {
+		opts.InferRecords = DefaultInferRecords
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	tables := make(map[string]sql.Table)
+	for _, f := range files {
+		if f.IsDir() {
+			continue
+		}
+
+		ext := filepath.Ext(f.Name())
+		switch strings.ToLower(ext) {
+		case ".jsonl", ".ndjson":
+		default:
+			continue
+		}
+
+		name := strings.TrimSuffix(f.Name(), ext)
+		if _, ok := tables[name]; ok {
+			return nil, fmt.Errorf("ndjson: more than one file for table %s", name)
+		}
+
+		t, err := newTable(name, filepath.Join(dir, f.Name()), opts)
+		if err != nil {
+			return nil, err
+		}
+
+		tables[name] = t
+	}
+
+	return &Database{name: name, tables: tables}, nil
+}
+
+// Name implements the sql.Database interface.
+func (d *Database) Name() string {
+	return d.name
+}
+
+// Tables implements the sql.Database interface.
+func (d *Database) Tables() map[string]sql.Table {
+	return d.tables
+}
@@ -0,0 +1,150 @@
+package ndjson
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	sqle "github.com/src-d/go-mysql-server"
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/expression"
+
+	"github.com/stretchr/testify/require"
+)
+
+const testLogs = `{"level": "info", "code": 1, "took": 1, "ok": true, "ctx": {"user": "a"}}
+{"level": "error", "code": 2, "took": 2.5, "ok": false, "ctx": {"user": "b"}, "tags": ["x"]}
+
+{"code": 3, "took": null, "ok": true, "ctx": {"user": "c"}, "tags": "y"}
+`
+
+func writeFiles(t *testing.T, files map[string]string) string {
+	dir, err := ioutil.TempDir("", "ndjson")
+	require.NoError(t, err)
+
+	for name, content := range files {
+		path := filepath.Join(dir, name)
+		require.NoError(t, ioutil.WriteFile(path, []byte(content), 0644))
+	}
+
+	return dir
+}
+
+func TestDatabase(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeFiles(t, map[string]string{
+		"logs.jsonl":   testLogs,
+		"other.ndjson": `{"a": "b"}`,
+		"notes.json":   `{"a": "b"}`,
+	})
+	defer os.RemoveAll(dir)
+
+	db, err := Open("mydb", dir, Options{})
+	require.NoError(err)
+	require.Equal("mydb", db.Name())
+
+	tables := db.Tables()
+	require.Len(tables, 2)
+
+	logs := tables["logs"]
+	require.Equal(sql.Schema{
+		{Name: "level", Type: sql.Text, Nullable: true},
+		{Name: "code", Type: sql.Int64},
+		{Name: "took", Type: sql.Float64, Nullable: true},
+		{Name: "ok", Type: sql.Boolean},
+		{Name: "ctx", Type: sql.JSON},
+		{Name: "tags", Type: sql.JSON, Nullable: true},
+	}, logs.Schema())
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), logs)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{"info", int64(1), float64(1), true, sql.JSONDocument{Val: map[string]interface{}{"user": "a"}}, nil},
+		{"error", int64(2), 2.5, false, sql.JSONDocument{Val: map[string]interface{}{"user": "b"}}, sql.JSONDocument{Val: []interface{}{"x"}}},
+		{nil, int64(3), nil, true, sql.JSONDocument{Val: map[string]interface{}{"user": "c"}}, sql.JSONDocument{Val: "y"}},
+	}, rows)
+
+	rows, err = sql.NodeToRows(sql.NewEmptyContext(), tables["other"])
+	require.NoError(err)
+	require.Equal([]sql.Row{{"b"}}, rows)
+}
+
+func TestDatabase_Invalid(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeFiles(t, map[string]string{"t.jsonl": "[1, 2]\n"})
+	defer os.RemoveAll(dir)
+
+	_, err := Open("mydb", dir, Options{})
+	require.Error(err)
+
+	dir2 := writeFiles(t, map[string]string{"t.jsonl": "{\"a\": 1}\n{\"a\": 2}\n{\"a\": \"b\"}\n"})
+	defer os.RemoveAll(dir2)
+
+	db, err := Open("mydb", dir2, Options{InferRecords: 2})
+	require.NoError(err)
+
+	_, err = sql.NodeToRows(sql.NewEmptyContext(), db.Tables()["t"])
+	require.Error(err)
+}
+
+func TestTable_Filters(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeFiles(t, map[string]string{
+		"t.jsonl": testLogs + `{"level": "debug", "code": "invalid"}` + "\n",
+	})
+	defer os.RemoveAll(dir)
+
+	db, err := Open("mydb", dir, Options{InferRecords: 3})
+	require.NoError(err)
+
+	table := db.Tables()["t"].(*Table)
+	level := expression.NewEquals(
+		expression.NewGetField(0, sql.Text, "level", true),
+		expression.NewLiteral("error", sql.Text),
+	)
+	ctx := expression.NewEquals(
+		expression.NewGetField(4, sql.JSON, "ctx", false),
+		expression.NewLiteral("error", sql.Text),
+	)
+
+	handled := table.HandledFilters([]sql.Expression{level, ctx})
+	require.Equal([]sql.Expression{level}, handled)
+
+	_, err = sql.NodeToRows(sql.NewEmptyContext(), table)
+	require.Error(err)
+
+	// The code of the last record is not decoded, because its level
+	// doesn't match.
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), table.WithFilters(handled))
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{"error", int64(2), 2.5, false, sql.JSONDocument{Val: map[string]interface{}{"user": "b"}}, sql.JSONDocument{Val: []interface{}{"x"}}},
+	}, rows)
+}
+
+func TestTable_Pushdown(t *testing.T) {
+	require := require.New(t)
+
+	dir := writeFiles(t, map[string]string{
+		"logs.jsonl": testLogs + `{"level": "debug", "code": "invalid"}` + "\n",
+	})
+	defer os.RemoveAll(dir)
+
+	db, err := Open("mydb", dir, Options{InferRecords: 3})
+	require.NoError(err)
+
+	e := sqle.New()
+	e.AddDatabase(db)
+
+	ctx := sql.NewEmptyContext()
+	_, iter, err := e.Query(ctx, "SELECT code FROM logs WHERE level = 'error'")
+	require.NoError(err)
+
+	rows, err := sql.RowIterToRows(iter)
+	require.NoError(err)
+	require.Equal([]sql.Row{{int64(2)}}, rows)
+}
@@ -0,0 +1,427 @@
+package ndjson
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/expression"
+)
+
+// Table is a newline delimited JSON file. Fields that are null or missing
+// in a record are NULL.
+type Table struct {
+	name   string
+	path   string
+	schema sql.Schema
+	// columns are the indexes of the columns by name.
+	columns map[string]int
+
+	filters []sql.Expression
+	// filtered is true for the columns used by the filters.
+	filtered []bool
+}
+
+func newTable(name, path string, opts Options) (*Table, error) {
+	t := &Table{name: name, path: path}
+	if err := t.inferSchema(opts.InferRecords); err != nil {
+		return nil, err
+	}
+
+	return t, nil
+}
+
+// Resolved implements the Resolvable interface.
+func (*Table) Resolved() bool {
+	return true
+}
+
+// Name implements the Nameable interface.
+func (t *Table) Name() string {
+	return t.name
+}
+
+// Schema implements the Node interface.
+func (t *Table) Schema() sql.Schema {
+	return t.schema
+}
+
+// Children implements the Node interface.
+func (t *Table) Children() []sql.Node {
+	return []sql.Node{}
+}
+
+// RowIter implements the Node interface. Records are read from the file as
+// the iterator is advanced.
+func (t *Table) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return nil, err
+	}
+
+	return &rowIter{t: t, f: f, r: bufio.NewReader(f)}, nil
+}
+
+// TransformUp implements the Transformable interface.
+func (t *Table) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(t)
+}
+
+// TransformExpressionsUp implements the Transformable interface. The
+// expressions of the table are its filters.
+func (t *Table) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	if len(t.filters) == 0 {
+		return t
+	}
+
+	filters := make([]sql.Expression, len(t.filters))
+	for i, e := range t.filters {
+		filters[i] = e.TransformUp(f)
+	}
+
+	return t.WithFilters(filters)
+}
+
+// HandledFilters implements the sql.FilteredTable interface. Filters are
+// handled if they only use top-level fields that are not JSON columns.
+func (t *Table) HandledFilters(filters []sql.Expression) []sql.Expression {
+	var handled []sql.Expression
+	for _, f := range filters {
+		ok := true
+		f.TransformUp(func(e sql.Expression) sql.Expression {
+			gf, isField := e.(*expression.GetField)
+			if isField && (gf.Index() >= len(t.schema) || t.schema[gf.Index()].Type == sql.JSON) {
+				ok = false
+			}
+
+			return e
+		})
+
+		if ok {
+			handled = append(handled, f)
+		}
+	}
+
+	return handled
+}
+
+// WithFilters implements the sql.FilteredTable interface.
+func (t *Table) WithFilters(filters []sql.Expression) sql.Table {
+	filtered := make([]bool, len(t.schema))
+	for _, f := range filters {
+		f.TransformUp(func(e sql.Expression) sql.Expression {
+			if gf, ok := e.(*expression.GetField); ok {
+				filtered[gf.Index()] = true
+			}
+
+			return e
+		})
+	}
+
+	n := *t
+	n.filters = filters
+	n.filtered = filtered
+	return &n
+}
+
+// inferSchema reads the first records of the file to find its columns. The
+// columns are sorted by their first appearance. A column takes the type of
+// its values: BIGINT, DOUBLE, BOOLEAN, TEXT, or JSON for objects, arrays and
+// values of different types. A column is nullable if any of the records has
+// no value or null for it.
+func (t *Table) inferSchema(records int) error {
+	f, err := os.Open(t.path)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var names []string
+	var kinds []kind
+	// seen is the number of records with a value for each column.
+	var seen []int
+	t.columns = make(map[string]int)
+
+	iter := &rowIter{t: t, f: f, r: bufio.NewReader(f)}
+	var n int
+	for ; n < records; n++ {
+		line, err := iter.readLine()
+		if err == io.EOF {
+			break
+		}
+
+		if err != nil {
+			return err
+		}
+
+		fields, err := decodeObject(line)
+		if err != nil {
+			return fmt.Errorf("ndjson: %s:%d: %s", t.path, iter.line, err)
+		}
+
+		for _, f := range fields {
+			idx, ok := t.columns[f.name]
+			if !ok {
+				idx = len(names)
+				t.columns[f.name] = idx
+				names = append(names, f.name)
+				kinds = append(kinds, kindUnknown)
+				seen = append(seen, 0)
+			}
+
+			if isNull(f.value) {
+				continue
+			}
+
+			kinds[idx] = kinds[idx].widen(f.value)
+			seen[idx]++
+		}
+	}
+
+	t.schema = make(sql.Schema, len(names))
+	for i, name := range names {
+		t.schema[i] = &sql.Column{
+			Name:     name,
+			Type:     kinds[i].typ(),
+			Nullable: seen[i] < n,
+		}
+	}
+
+	t.filtered = make([]bool, len(names))
+	return nil
+}
+
+// kind is the type inferred for a column from the values seen so far.
+type kind int
+
+const (
+	kindUnknown kind = iota
+	kindInt
+	kindFloat
+	kindBool
+	kindText
+	kindJSON
+)
+
+// widen returns the narrowest kind that can hold both the values of k and
+// the given value. Integers can be widened to floats, and any kind to JSON.
+func (k kind) widen(value json.RawMessage) kind {
+	var v kind
+	switch value[0] {
+	case '"':
+		v = kindText
+	case 't', 'f':
+		v = kindBool
+	case '{', '[':
+		v = kindJSON
+	default:
+		if _, err := strconv.ParseInt(string(value), 10, 64); err == nil {
+			v = kindInt
+		} else {
+			v = kindFloat
+		}
+	}
+
+	switch {
+	case k == kindUnknown || k == v:
+		return v
+	case (k == kindInt || k == kindFloat) && (v == kindInt || v == kindFloat):
+		return kindFloat
+	default:
+		return kindJSON
+	}
+}
+
+func (k kind) typ() sql.Type {
+	switch k {
+	case kindInt:
+		return sql.Int64
+	case kindFloat:
+		return sql.Float64
+	case kindBool:
+		return sql.Boolean
+	case kindText:
+		return sql.Text
+	default:
+		return sql.JSON
+	}
+}
+
+// field is a top-level field of a record.
+type field struct {
+	name  string
+	value json.RawMessage
+}
+
+// decodeObject returns the top-level fields of the JSON object in line, in
+// the order they appear. Their values are not decoded.
+func decodeObject(line []byte) ([]field, error) {
+	dec := json.NewDecoder(bytes.NewReader(line))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		return nil, fmt.Errorf("record is not a JSON object")
+	}
+
+	var fields []field
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+
+		fields = append(fields, field{
+			name:  tok.(string),
+			value: bytes.TrimSpace(value),
+		})
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return nil, err
+	}
+
+	return fields, nil
+}
+
+func isNull(value json.RawMessage) bool {
+	return value == nil || string(value) == "null"
+}
+
+// decodeValue decodes a value of the given column.
+func decodeValue(c *sql.Column, value json.RawMessage) (interface{}, error) {
+	if isNull(value) {
+		return nil, nil
+	}
+
+	var v interface{}
+	var err error
+	switch c.Type {
+	case sql.Int64:
+		v, err = strconv.ParseInt(string(value), 10, 64)
+	case sql.Float64:
+		v, err = strconv.ParseFloat(string(value), 64)
+	case sql.Boolean:
+		var b bool
+		err = json.Unmarshal(value, &b)
+		v = b
+	case sql.Text:
+		var s string
+		err = json.Unmarshal(value, &s)
+		v = s
+	default:
+		v, err = c.Type.Convert([]byte(value))
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for column %s: %s", c.Name, value)
+	}
+
+	return v, nil
+}
+
+type rowIter struct {
+	t    *Table
+	f    *os.File
+	r    *bufio.Reader
+	line int
+}
+
+// readLine returns the next line that is not empty.
+func (i *rowIter) readLine() ([]byte, error) {
+	for {
+		line, err := i.r.ReadBytes('\n')
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		if len(line) > 0 {
+			i.line++
+		}
+
+		line = bytes.TrimSpace(line)
+		if len(line) > 0 {
+			return line, nil
+		}
+
+		if err == io.EOF {
+			return nil, io.EOF
+		}
+	}
+}
+
+func (i *rowIter) Next() (sql.Row, error) {
+	for {
+		line, err := i.readLine()
+		if err != nil {
+			return nil, err
+		}
+
+		row, err := i.decodeRow(line)
+		if err != nil {
+			return nil, fmt.Errorf("ndjson: %s:%d: %s", i.t.path, i.line, err)
+		}
+
+		if row != nil {
+			return row, nil
+		}
+	}
+}
+
+// decodeRow decodes the record in line. If the table has filters, the
+// columns used by them are decoded first, and nil is returned if they don't
+// match.
+func (i *rowIter) decodeRow(line []byte) (sql.Row, error) {
+	fields, err := decodeObject(line)
+	if err != nil {
+		return nil, err
+	}
+
+	schema := i.t.schema
+	values := make([]json.RawMessage, len(schema))
+	for _, f := range fields {
+		if idx, ok := i.t.columns[f.name]; ok {
+			values[idx] = f.value
+		}
+	}
+
+	row := make(sql.Row, len(schema))
+	for idx, c := range schema {
+		if !i.t.filtered[idx] {
+			continue
+		}
+
+		if row[idx], err = decodeValue(c, values[idx]); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, f := range i.t.filters {
+		if f.Eval(row) != true {
+			return nil, nil
+		}
+	}
+
+	for idx, c := range schema {
+		if i.t.filtered[idx] {
+			continue
+		}
+
+		if row[idx], err = decodeValue(c, values[idx]); err != nil {
+			return nil, err
+		}
+	}
+
+	return row, nil
+}
+
+func (i *rowIter) Close() error {
+	return i.f.Close()
+}
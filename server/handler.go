@@ -1,8 +1,12 @@
 package server
 
 import (
+	"context"
 	"io"
+	"math"
 	"sync"
+	"time"
+	"unicode/utf8"
 
 	"github.com/src-d/go-mysql-server"
 	"github.com/src-d/go-mysql-server/sql"
@@ -13,49 +17,200 @@ import (
 	"github.com/src-d/go-vitess/vt/proto/query"
 )
 
+// Handler is the handler of the connections to a MySQL server that runs
+// the queries in an engine. Queries are sent as text: the listener doesn't
+// dispatch the COM_STMT_* packets of the binary protocol, so clients must
+// prepare statements on their side.
 type Handler struct {
-	mu sync.Mutex
-	e  *sqle.Engine
+	mu       sync.Mutex
+	e        *sqle.Engine
+	sessions map[uint32]sql.Session
+	// warnings holds the number of warnings of the last statement of each
+	// connection.
+	warnings map[uint32]uint16
 }
 
 func NewHandler(e *sqle.Engine) *Handler {
-	return &Handler{e: e}
+	return &Handler{
+		e:        e,
+		sessions: make(map[uint32]sql.Session),
+		warnings: make(map[uint32]uint16),
+	}
 }
 
 func (h *Handler) NewConnection(c *mysql.Conn) {
+	h.mu.Lock()
+	h.sessions[c.ConnectionID] = h.e.NewSession(c.ConnectionID)
+	h.mu.Unlock()
+
 	logrus.Infof("NewConnection: client %v", c.ConnectionID)
 }
 
 func (h *Handler) ConnectionClosed(c *mysql.Conn) {
+	h.mu.Lock()
+	delete(h.sessions, c.ConnectionID)
+	delete(h.warnings, c.ConnectionID)
+	h.mu.Unlock()
+
 	logrus.Infof("ConnectionClosed: client %v", c.ConnectionID)
 }
 
+// session returns the session of the given connection, creating it if it
+// does not exist.
+func (h *Handler) session(c *mysql.Conn) sql.Session {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	s, ok := h.sessions[c.ConnectionID]
+	if !ok {
+		s = h.e.NewSession(c.ConnectionID)
+		h.sessions[c.ConnectionID] = s
+	}
+
+	return s
+}
+
 func (h *Handler) ComQuery(c *mysql.Conn, query string, callback func(*sqltypes.Result) error) error {
-	schema, rows, err := h.e.Query(query)
+	h.setWarnings(c, 0)
+	ctx := sql.NewContext(context.TODO(), h.session(c))
+	schema, rows, err := h.e.Query(ctx, query)
+	if err != nil {
+		return err
+	}
+
+	return h.send(ctx, c, schema, rows, callback)
+}
+
+// WarningCount returns the number of warnings of the last statement executed
+// by the given connection.
+func (h *Handler) WarningCount(c *mysql.Conn) uint16 {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return h.warnings[c.ConnectionID]
+}
+
+func (h *Handler) setWarnings(c *mysql.Conn, n uint16) {
+	h.mu.Lock()
+	h.warnings[c.ConnectionID] = n
+	h.mu.Unlock()
+}
+
+// warningCount returns the given number of warnings plus the warnings
+// recorded in the session of the context.
+func warningCount(n uint16, ctx *sql.Context) uint16 {
+	total := int(n) + len(ctx.Warnings())
+	if total > math.MaxUint16 {
+		return math.MaxUint16
+	}
+
+	return uint16(total)
+}
+
+// send sends the result of a statement to the client. Statements that return
+// an sql.OkResult are sent as an OK packet, and the rest as a result set.
+func (h *Handler) send(
+	ctx *sql.Context,
+	c *mysql.Conn,
+	schema sql.Schema,
+	rows sql.RowIter,
+	callback func(*sqltypes.Result) error,
+) error {
+	if !sql.IsOkResult(schema) {
+		loc, err := sql.TimeZone(ctx)
+		if err != nil {
+			return err
+		}
+
+		err = sendRows(loc, schema, rows, callback)
+		h.setWarnings(c, warningCount(0, ctx))
+		return err
+	}
+
+	result, err := sql.RowIterToRows(rows)
 	if err != nil {
 		return err
 	}
 
-	r := &sqltypes.Result{Fields: schemaToFields(schema)}
+	var ok sql.OkResult
+	if len(result) == 1 {
+		ok, _ = sql.GetOkResult(result[0])
+	}
+
+	h.setWarnings(c, warningCount(ok.Warnings, ctx))
+
+	// A result without fields is sent as an OK packet.
+	return callback(&sqltypes.Result{
+		RowsAffected: ok.RowsAffected,
+		InsertID:     ok.InsertID,
+	})
+}
+
+// rowsBatch is the maximum number of rows sent to the client at once.
+const rowsBatch = 128
+
+// sendRows streams the rows to the client through the callback in batches
+// of at most rowsBatch rows. The fields are sent along with the first batch,
+// which is not sent until it's full or there are no more rows, so errors
+// found while reading it are reported to the client as an error packet.
+// Once the fields are sent the protocol does not allow to send an error, so
+// later errors abort the connection. TIMESTAMP values are sent as times in
+// the given location, which is the time zone of the session.
+func sendRows(
+	loc *time.Location,
+	schema sql.Schema,
+	rows sql.RowIter,
+	callback func(*sqltypes.Result) error,
+) error {
+	defer rows.Close()
+
+	fields := schemaToFields(schema)
+	r := &sqltypes.Result{Fields: fields}
+	sent := false
 	for {
 		row, err := rows.Next()
+		if err == io.EOF {
+			break
+		}
+
 		if err != nil {
-			if err == io.EOF {
-				break
+			if sent {
+				logrus.Errorf("error reading rows after sending a result: %s", err)
 			}
 			return err
 		}
 
-		r.Rows = append(r.Rows, rowToSQL(schema, row))
+		r.Rows = append(r.Rows, rowToSQL(loc, schema, row))
 		r.RowsAffected++
+
+		if len(r.Rows) == rowsBatch {
+			if err := callback(r); err != nil {
+				return err
+			}
+
+			sent = true
+			r = &sqltypes.Result{Fields: fields}
+		}
+	}
+
+	if sent && len(r.Rows) == 0 {
+		return nil
 	}
 
 	return callback(r)
 }
 
-func rowToSQL(s sql.Schema, row sql.Row) []sqltypes.Value {
+func rowToSQL(loc *time.Location, s sql.Schema, row sql.Row) []sqltypes.Value {
 	o := make([]sqltypes.Value, len(row))
 	for i, v := range row {
+		if v == nil {
+			o[i] = sqltypes.NULL
+			continue
+		}
+
+		if t, ok := v.(time.Time); ok && s[i].Type == sql.Timestamp && !t.IsZero() {
+			v = t.In(loc)
+		}
+
 		o[i] = s[i].Type.SQL(v)
 	}
 
@@ -65,11 +220,110 @@ func rowToSQL(s sql.Schema, row sql.Row) []sqltypes.Value {
 func schemaToFields(s sql.Schema) []*query.Field {
 	fields := make([]*query.Field, len(s))
 	for i, c := range s {
+		typ := c.Type.Type()
+		_, flags := sqltypes.TypeToMySQL(typ)
+		if !c.Nullable {
+			flags |= int64(query.MySqlFlag_NOT_NULL_FLAG)
+		}
+
+		// Clients ignore the flags of fields without a character set.
+		charset := uint8(mysql.CharacterSetBinary)
+		if st, ok := c.Type.(sql.StringType); ok && sql.IsText(st) {
+			charset = mysql.CharacterSetUtf8
+			if id, ok := mysql.CharacterSetMap[st.CharacterSet()]; ok {
+				charset = id
+			}
+		} else if sqltypes.IsText(typ) || typ == sqltypes.Enum || typ == sqltypes.Set {
+			charset = mysql.CharacterSetUtf8
+		}
+
 		fields[i] = &query.Field{
-			Name: c.Name,
-			Type: c.Type.Type(),
+			Name:    c.Name,
+			Type:    typ,
+			Charset: uint32(charset),
+			Flags:   uint32(flags),
+		}
+
+		// Clients read TINYINT(1) fields as booleans.
+		if c.Type == sql.Boolean {
+			fields[i].ColumnLength = 1
+		} else if length, ok := integerLengths[typ]; ok {
+			fields[i].ColumnLength = length
+		}
+
+		switch t := c.Type.(type) {
+		case sql.BitType:
+			fields[i].ColumnLength = uint32(t.Length())
+		case sql.EnumType:
+			fields[i].ColumnLength = membersLength(t.Values(), false)
+		case sql.SetType:
+			fields[i].ColumnLength = membersLength(t.Values(), true)
+		case sql.StringType:
+			// The length of a character type is in bytes, so it's enough
+			// for the widest characters of its character set.
+			length := uint64(t.MaxLength())
+			if sql.IsText(t) {
+				length *= uint64(sql.MaxCharacterBytes(t.CharacterSet()))
+			}
+
+			if length > math.MaxUint32 {
+				length = math.MaxUint32
+			}
+
+			fields[i].ColumnLength = uint32(length)
+		case sql.DecimalType:
+			// The length of a decimal includes its sign and decimal point.
+			length := t.Precision() + 1
+			if t.Scale() > 0 {
+				length++
+			}
+
+			fields[i].ColumnLength = uint32(length)
+			fields[i].Decimals = uint32(t.Scale())
+		case sql.DatetimeType:
+			length := len(sql.TimestampLayout)
+			if t.Precision() > 0 {
+				length += t.Precision() + 1
+			}
+
+			fields[i].ColumnLength = uint32(length)
+			fields[i].Decimals = uint32(t.Precision())
 		}
 	}
 
 	return fields
 }
+
+// membersLength returns the length in bytes of the longest value of an ENUM
+// or SET type with the given members, which are sent in utf8.
+func membersLength(values []string, set bool) uint32 {
+	var length int
+	for i, v := range values {
+		n := utf8.RuneCountInString(v)
+		switch {
+		case set && i > 0:
+			length += n + 1
+		case set:
+			length += n
+		case n > length:
+			length = n
+		}
+	}
+
+	return uint32(length * 3)
+}
+
+// integerLengths are the display widths of the integer types, which are the
+// lengths of their fields.
+var integerLengths = map[query.Type]uint32{
+	sqltypes.Int8:   4,
+	sqltypes.Uint8:  3,
+	sqltypes.Int16:  6,
+	sqltypes.Uint16: 5,
+	sqltypes.Int24:  9,
+	sqltypes.Uint24: 8,
+	sqltypes.Int32:  11,
+	sqltypes.Uint32: 10,
+	sqltypes.Int64:  20,
+	sqltypes.Uint64: 20,
+}
@@ -0,0 +1,215 @@
+package server
+
+import (
+	"errors"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/src-d/go-mysql-server"
+	"github.com/src-d/go-mysql-server/mem"
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/src-d/go-vitess/mysql"
+	"github.com/src-d/go-vitess/sqltypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSendRows(t *testing.T) {
+	schema := sql.Schema{{Name: "i", Type: sql.Int64}}
+
+	for _, n := range []int{0, 1, rowsBatch, rowsBatch + 1, 3*rowsBatch - 1} {
+		require := require.New(t)
+
+		rows := make([]sql.Row, n)
+		for i := range rows {
+			rows[i] = sql.NewRow(int64(i))
+		}
+
+		var results []*sqltypes.Result
+		err := sendRows(time.UTC, schema, sql.RowsToRowIter(rows...), func(r *sqltypes.Result) error {
+			results = append(results, r)
+			return nil
+		})
+		require.NoError(err)
+
+		expected := (n + rowsBatch - 1) / rowsBatch
+		if expected == 0 {
+			expected = 1
+		}
+		require.Len(results, expected)
+
+		var total int
+		for _, r := range results {
+			require.Len(r.Fields, 1)
+			require.True(len(r.Rows) <= rowsBatch)
+			require.Equal(uint64(len(r.Rows)), r.RowsAffected)
+			total += len(r.Rows)
+		}
+		require.Equal(n, total)
+	}
+}
+
+func TestSendRowsError(t *testing.T) {
+	require := require.New(t)
+	schema := sql.Schema{{Name: "i", Type: sql.Int64}}
+
+	var calls int
+	callback := func(r *sqltypes.Result) error {
+		calls++
+		return nil
+	}
+
+	err := sendRows(time.UTC, schema, &failingIter{n: rowsBatch - 1}, callback)
+	require.Error(err)
+	require.Equal(0, calls)
+
+	err = sendRows(time.UTC, schema, &failingIter{n: rowsBatch + 1}, callback)
+	require.Error(err)
+	require.Equal(1, calls)
+}
+
+type failingIter struct {
+	n int
+}
+
+func (i *failingIter) Next() (sql.Row, error) {
+	if i.n == 0 {
+		return nil, errors.New("iter failed")
+	}
+
+	i.n--
+	return sql.NewRow(int64(i.n)), nil
+}
+
+func (i *failingIter) Close() error {
+	return nil
+}
+
+func TestHandlerOkResult(t *testing.T) {
+	require := require.New(t)
+
+	table := mem.NewTable("mytable", sql.Schema{
+		{Name: "i", Type: sql.Int64},
+	})
+	db := mem.NewDatabase("mydb")
+	db.AddTable("mytable", table)
+	e := sqle.New()
+	e.AddDatabase(db)
+
+	h := NewHandler(e)
+	c := &mysql.Conn{ConnectionID: 1}
+	h.NewConnection(c)
+	defer h.ConnectionClosed(c)
+
+	var results []*sqltypes.Result
+	callback := func(r *sqltypes.Result) error {
+		results = append(results, r)
+		return nil
+	}
+
+	err := h.ComQuery(c, "INSERT INTO mytable (i) VALUES (1), (2)", callback)
+	require.NoError(err)
+	require.Equal([]*sqltypes.Result{{RowsAffected: 2}}, results)
+	require.Equal(uint16(0), h.WarningCount(c))
+
+	results = nil
+	err = h.ComQuery(c, "SET @foo = 1", callback)
+	require.NoError(err)
+	require.Len(results, 1)
+	require.Len(results[0].Fields, 0)
+
+	results = nil
+	err = h.ComQuery(c, "SELECT i FROM mytable", callback)
+	require.NoError(err)
+	require.Len(results, 1)
+	require.Len(results[0].Fields, 1)
+	require.Equal(uint64(2), results[0].RowsAffected)
+
+	results = nil
+	err = h.ComQuery(c, "SELECT 1 AS __ok_result", callback)
+	require.NoError(err)
+	require.Len(results, 1)
+	require.Len(results[0].Fields, 1)
+	require.Len(results[0].Rows, 1)
+}
+
+func TestHandlerTimeZone(t *testing.T) {
+	require := require.New(t)
+
+	table := mem.NewTable("events", sql.Schema{
+		{Name: "at", Type: sql.Timestamp},
+		{Name: "local", Type: sql.Datetime(0)},
+	})
+	db := mem.NewDatabase("mydb")
+	db.AddTable("events", table)
+	e := sqle.New()
+	e.AddDatabase(db)
+
+	h := NewHandler(e)
+	c := &mysql.Conn{ConnectionID: 1}
+	h.NewConnection(c)
+	defer h.ConnectionClosed(c)
+
+	var results []*sqltypes.Result
+	callback := func(r *sqltypes.Result) error {
+		results = append(results, r)
+		return nil
+	}
+
+	require.NoError(h.ComQuery(c, "SET time_zone = '+02:00'", callback))
+	require.NoError(h.ComQuery(c,
+		"INSERT INTO events (at, local) VALUES ('2018-01-02 10:00:00', '2018-01-02 10:00:00')",
+		callback,
+	))
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), table)
+	require.NoError(err)
+	require.Equal(
+		time.Date(2018, 1, 2, 8, 0, 0, 0, time.UTC),
+		rows[0][0].(time.Time).UTC(),
+	)
+
+	for zone, expected := range map[string]string{
+		"+02:00": "2018-01-02 10:00:00",
+		"-01:30": "2018-01-02 06:30:00",
+		"SYSTEM": "2018-01-02 08:00:00",
+	} {
+		require.NoError(h.ComQuery(c, "SET time_zone = '"+zone+"'", callback))
+
+		results = nil
+		require.NoError(h.ComQuery(c, "SELECT at, local FROM events", callback))
+		require.Len(results, 1)
+		require.Equal(expected, results[0].Rows[0][0].ToString(), zone)
+		require.Equal("2018-01-02 10:00:00", results[0].Rows[0][1].ToString(), zone)
+	}
+
+	require.Error(h.ComQuery(c, "SET time_zone = 'Nowhere/Never'", callback))
+}
+
+func TestSchemaToFields(t *testing.T) {
+	require := require.New(t)
+
+	name, err := sql.CreateString(sqltypes.VarChar, 255, "utf8mb4", "")
+	require.NoError(err)
+
+	fields := schemaToFields(sql.Schema{
+		{Name: "name", Type: name},
+		{Name: "code", Type: sql.Char(2), Nullable: true},
+		{Name: "hash", Type: sql.Binary(16)},
+		{Name: "body", Type: sql.LongText},
+		{Name: "admin", Type: sql.Boolean},
+		{Name: "age", Type: sql.Uint8},
+	})
+
+	require.Equal(uint32(1020), fields[0].ColumnLength)
+	require.Equal(uint32(mysql.CharacterSetMap["utf8mb4"]), fields[0].Charset)
+	require.Equal(uint32(6), fields[1].ColumnLength)
+	require.Equal(uint32(mysql.CharacterSetUtf8), fields[1].Charset)
+	require.Equal(uint32(16), fields[2].ColumnLength)
+	require.Equal(uint32(mysql.CharacterSetBinary), fields[2].Charset)
+	require.Equal(uint32(math.MaxUint32), fields[3].ColumnLength)
+	require.Equal(sqltypes.Int8, fields[4].Type)
+	require.Equal(uint32(1), fields[4].ColumnLength)
+	require.Equal(uint32(3), fields[5].ColumnLength)
+}
@@ -0,0 +1,189 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/src-d/go-mysql-server"
+	"github.com/src-d/go-mysql-server/mem"
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/decimal"
+
+	"github.com/src-d/go-vitess/mysql"
+	"github.com/src-d/go-vitess/sqltypes"
+	"github.com/stretchr/testify/require"
+)
+
+// startServer starts a server with the given database, and returns the
+// parameters to connect to it.
+func startServer(t *testing.T, db sql.Database) (*Server, *mysql.ConnParams) {
+	e := sqle.New()
+	e.AddDatabase(db)
+
+	auth := mysql.NewAuthServerStatic()
+	auth.Entries["user"] = []*mysql.AuthServerStaticEntry{{Password: "pass"}}
+
+	s, err := NewServer("tcp", "127.0.0.1:0", auth, e)
+	require.NoError(t, err)
+	go s.Start()
+
+	addr := s.Listener.Addr().(*net.TCPAddr)
+	return s, &mysql.ConnParams{
+		Host:   addr.IP.String(),
+		Port:   addr.Port,
+		Uname:  "user",
+		Pass:   "pass",
+		DbName: db.Name(),
+	}
+}
+
+func mustType(t sql.Type, err error) sql.Type {
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// TestRoundTrip sends a value of every type through the server and reads it
+// back with a MySQL client, which must see the type of the column and read
+// the same value.
+func TestRoundTrip(t *testing.T) {
+	require := require.New(t)
+
+	status := mustType(sql.CreateEnum([]string{"active", "disabled"}))
+	perms := mustType(sql.CreateSet([]string{"read", "write", "admin"}))
+
+	testCases := []struct {
+		typ   sql.Type
+		value interface{}
+		text  string
+	}{
+		{sql.Int8, int8(math.MinInt8), "-128"},
+		{sql.Int16, int16(math.MaxInt16), "32767"},
+		{sql.Int24, int32(-8388608), "-8388608"},
+		{sql.Int32, int32(math.MinInt32), "-2147483648"},
+		{sql.Int64, int64(math.MinInt64), "-9223372036854775808"},
+		{sql.Uint8, uint8(math.MaxUint8), "255"},
+		{sql.Uint16, uint16(math.MaxUint16), "65535"},
+		{sql.Uint24, uint32(16777215), "16777215"},
+		{sql.Uint32, uint32(math.MaxUint32), "4294967295"},
+		{sql.Uint64, uint64(math.MaxUint64), "18446744073709551615"},
+		{sql.Float32, float32(0.1), "0.1"},
+		{sql.Float64, 0.30000000000000004, "0.30000000000000004"},
+		{sql.Float64, -1.5e300, "-1.5e+300"},
+		{sql.Decimal(10, 2), decimal.MustParse("-1234.50"), "-1234.50"},
+		{sql.Boolean, true, "1"},
+		{sql.Bit(12), uint64(0xabc), "\x0a\xbc"},
+		{sql.Timestamp, time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC), "2018-01-02 03:04:05"},
+		{sql.Date, time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC), "2018-01-02"},
+		{sql.Datetime(3), time.Date(2018, 1, 2, 3, 4, 5, 120000000, time.UTC), "2018-01-02 03:04:05.120"},
+		{sql.Time, -(26*time.Hour + 3*time.Minute), "-26:03:00"},
+		{sql.Year, int16(2018), "2018"},
+		{sql.Char(5), "abc", "abc"},
+		{sql.Varchar(10), "héllo", "héllo"},
+		{sql.TinyText, "tiny", "tiny"},
+		{sql.Text, "text", "text"},
+		{sql.MediumText, "medium", "medium"},
+		{sql.LongText, "long", "long"},
+		{sql.Binary(3), []byte{1, 0, 0}, "\x01\x00\x00"},
+		{sql.Varbinary(3), []byte{0xff}, "\xff"},
+		{sql.TinyBlob, []byte("tiny"), "tiny"},
+		{sql.Blob, []byte("blob"), "blob"},
+		{sql.MediumBlob, []byte("medium"), "medium"},
+		{sql.LongBlob, []byte{0, 1}, "\x00\x01"},
+		{status, uint16(2), "disabled"},
+		{perms, uint64(5), "read,admin"},
+		{sql.JSON, map[string]interface{}{"a": []interface{}{1.0, "b"}}, `{"a":[1,"b"]}`},
+		{sql.Null, nil, ""},
+	}
+
+	schema := make(sql.Schema, len(testCases))
+	row := make(sql.Row, len(testCases))
+	for i, tt := range testCases {
+		schema[i] = &sql.Column{Name: fmt.Sprintf("c%d", i), Type: tt.typ, Nullable: true}
+		row[i] = tt.value
+	}
+
+	table := mem.NewTable("types", schema)
+	require.NoError(table.Insert(sql.NewEmptyContext(), row))
+	require.NoError(table.Insert(sql.NewEmptyContext(), make(sql.Row, len(testCases))))
+
+	db := mem.NewDatabase("mydb")
+	db.AddTable("types", table)
+
+	s, params := startServer(t, db)
+	defer s.Listener.Close()
+
+	conn, err := mysql.Connect(context.Background(), params)
+	require.NoError(err)
+	defer conn.Close()
+
+	result, err := conn.ExecuteFetch("SELECT * FROM types", 10, true)
+	require.NoError(err)
+	require.Len(result.Fields, len(testCases))
+	require.Len(result.Rows, 2)
+
+	for i, tt := range testCases {
+		name := sql.MySQLTypeName(tt.typ)
+		require.Equal(tt.typ.Type(), result.Fields[i].Type, name)
+
+		v := result.Rows[0][i]
+		require.Equal(tt.text, v.ToString(), name)
+
+		// The value read by the client is converted back to the value that
+		// was sent.
+		var got interface{}
+		switch {
+		case tt.typ == sql.Null:
+			require.True(v.IsNull(), name)
+		case tt.typ == sql.JSON:
+			require.NoError(json.Unmarshal(v.ToBytes(), &got), name)
+		case tt.typ == sql.Timestamp:
+			got, err = sql.ParseTime(v.ToString(), time.UTC)
+			require.NoError(err, name)
+		case sqltypes.IsBinary(tt.typ.Type()) || tt.typ.Type() == sqltypes.Bit:
+			got, err = tt.typ.Convert(v.ToBytes())
+			require.NoError(err, name)
+		default:
+			got, err = tt.typ.Convert(v.ToString())
+			require.NoError(err, name)
+		}
+		require.Equal(tt.value, got, name)
+
+		require.True(result.Rows[1][i].IsNull(), name)
+	}
+}
+
+func TestWarnings(t *testing.T) {
+	require := require.New(t)
+
+	table := mem.NewTable("mytable", sql.Schema{{Name: "s", Type: sql.Text}})
+	for _, s := range []string{"1", "2x", "y"} {
+		require.NoError(table.Insert(sql.NewEmptyContext(), sql.NewRow(s)))
+	}
+
+	db := mem.NewDatabase("mydb")
+	db.AddTable("mytable", table)
+
+	s, params := startServer(t, db)
+	defer s.Listener.Close()
+
+	conn, err := mysql.Connect(context.Background(), params)
+	require.NoError(err)
+	defer conn.Close()
+
+	result, warnings, err := conn.ExecuteFetchWithWarningCount("SELECT CAST(s AS SIGNED) FROM mytable", 10, false)
+	require.NoError(err)
+	require.Len(result.Rows, 3)
+	require.Equal(uint16(2), warnings)
+
+	_, warnings, err = conn.ExecuteFetchWithWarningCount("SELECT s FROM mytable", 10, false)
+	require.NoError(err)
+	require.Equal(uint16(0), warnings)
+}
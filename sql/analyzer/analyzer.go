@@ -18,7 +18,7 @@ type Analyzer struct {
 
 type Rule struct {
 	Name  string
-	Apply func(*Analyzer, sql.Node) sql.Node
+	Apply func(*sql.Context, *Analyzer, sql.Node) (sql.Node, error)
 }
 
 type ValidationRule struct {
@@ -34,13 +34,21 @@ func New(catalog *sql.Catalog) *Analyzer {
 	}
 }
 
-func (a *Analyzer) Analyze(n sql.Node) (sql.Node, error) {
+func (a *Analyzer) Analyze(ctx *sql.Context, n sql.Node) (sql.Node, error) {
 	prev := n
-	cur := a.analyzeOnce(n)
+	cur, err := a.analyzeOnce(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+
 	i := 0
 	for !reflect.DeepEqual(prev, cur) {
 		prev = cur
-		cur = a.analyzeOnce(n)
+		cur, err = a.analyzeOnce(ctx, cur)
+		if err != nil {
+			return nil, err
+		}
+
 		i++
 		if i >= maxAnalysisIterations {
 			return cur, fmt.Errorf("exceeded max analysis iterations (%d)", maxAnalysisIterations)
@@ -55,12 +63,16 @@ func (a *Analyzer) Analyze(n sql.Node) (sql.Node, error) {
 	return cur, nil
 }
 
-func (a *Analyzer) analyzeOnce(n sql.Node) sql.Node {
+func (a *Analyzer) analyzeOnce(ctx *sql.Context, n sql.Node) (sql.Node, error) {
 	result := n
 	for _, rule := range a.Rules {
-		result = rule.Apply(a, result)
+		var err error
+		result, err = rule.Apply(ctx, a, result)
+		if err != nil {
+			return nil, err
+		}
 	}
-	return result
+	return result, nil
 }
 
 func (a *Analyzer) validate(n sql.Node) (validationErrors []error) {
@@ -15,6 +15,7 @@ import (
 
 func TestAnalyzer_Analyze(t *testing.T) {
 	assert := require.New(t)
+	ctx := sql.NewEmptyContext()
 
 	table := mem.NewTable("mytable", sql.Schema{{Name: "i", Type: sql.Int32}})
 	table2 := mem.NewTable("mytable2", sql.Schema{{Name: "i2", Type: sql.Int32}})
@@ -27,16 +28,16 @@ func TestAnalyzer_Analyze(t *testing.T) {
 	a.CurrentDatabase = "mydb"
 
 	var notAnalyzed sql.Node = plan.NewUnresolvedTable("mytable")
-	analyzed, err := a.Analyze(notAnalyzed)
+	analyzed, err := a.Analyze(ctx, notAnalyzed)
 	assert.NoError(err)
 	assert.Equal(table, analyzed)
 
 	notAnalyzed = plan.NewUnresolvedTable("nonexistant")
-	analyzed, err = a.Analyze(notAnalyzed)
+	analyzed, err = a.Analyze(ctx, notAnalyzed)
 	assert.Error(err)
 	assert.Equal(notAnalyzed, analyzed)
 
-	analyzed, err = a.Analyze(table)
+	analyzed, err = a.Analyze(ctx, table)
 	assert.NoError(err)
 	assert.Equal(table, analyzed)
 
@@ -44,14 +45,14 @@ func TestAnalyzer_Analyze(t *testing.T) {
 		[]sql.Expression{expression.NewUnresolvedColumn("o")},
 		plan.NewUnresolvedTable("mytable"),
 	)
-	_, err = a.Analyze(notAnalyzed)
+	_, err = a.Analyze(ctx, notAnalyzed)
 	assert.Error(err)
 
 	notAnalyzed = plan.NewProject(
 		[]sql.Expression{expression.NewUnresolvedColumn("i")},
 		plan.NewUnresolvedTable("mytable"),
 	)
-	analyzed, err = a.Analyze(notAnalyzed)
+	analyzed, err = a.Analyze(ctx, notAnalyzed)
 	var expected sql.Node = plan.NewProject(
 		[]sql.Expression{expression.NewGetField(0, sql.Int32, "i", false)},
 		table,
@@ -62,7 +63,7 @@ func TestAnalyzer_Analyze(t *testing.T) {
 	notAnalyzed = plan.NewDescribe(
 		plan.NewUnresolvedTable("mytable"),
 	)
-	analyzed, err = a.Analyze(notAnalyzed)
+	analyzed, err = a.Analyze(ctx, notAnalyzed)
 	expected = plan.NewDescribe(table)
 	assert.NoError(err)
 	assert.Equal(expected, analyzed)
@@ -71,7 +72,7 @@ func TestAnalyzer_Analyze(t *testing.T) {
 		[]sql.Expression{expression.NewStar()},
 		plan.NewUnresolvedTable("mytable"),
 	)
-	analyzed, err = a.Analyze(notAnalyzed)
+	analyzed, err = a.Analyze(ctx, notAnalyzed)
 	expected = plan.NewProject(
 		[]sql.Expression{expression.NewGetField(0, sql.Int32, "i", false)},
 		table,
@@ -86,7 +87,7 @@ func TestAnalyzer_Analyze(t *testing.T) {
 			plan.NewUnresolvedTable("mytable"),
 		),
 	)
-	analyzed, err = a.Analyze(notAnalyzed)
+	analyzed, err = a.Analyze(ctx, notAnalyzed)
 	expected = plan.NewProject(
 		[]sql.Expression{expression.NewGetField(0, sql.Int32, "i", false)},
 		plan.NewProject(
@@ -106,7 +107,7 @@ func TestAnalyzer_Analyze(t *testing.T) {
 		},
 		plan.NewUnresolvedTable("mytable"),
 	)
-	analyzed, err = a.Analyze(notAnalyzed)
+	analyzed, err = a.Analyze(ctx, notAnalyzed)
 	expected = plan.NewProject(
 		[]sql.Expression{
 			expression.NewAlias(
@@ -129,7 +130,7 @@ func TestAnalyzer_Analyze(t *testing.T) {
 			plan.NewUnresolvedTable("mytable"),
 		),
 	)
-	analyzed, err = a.Analyze(notAnalyzed)
+	analyzed, err = a.Analyze(ctx, notAnalyzed)
 	expected = plan.NewProject(
 		[]sql.Expression{expression.NewGetField(0, sql.Int32, "i", false)},
 		plan.NewFilter(
@@ -153,7 +154,7 @@ func TestAnalyzer_Analyze(t *testing.T) {
 			plan.NewUnresolvedTable("mytable2"),
 		),
 	)
-	analyzed, err = a.Analyze(notAnalyzed)
+	analyzed, err = a.Analyze(ctx, notAnalyzed)
 	expected = plan.NewProject(
 		[]sql.Expression{
 			expression.NewGetField(0, sql.Int32, "i", false),
@@ -172,7 +173,7 @@ func TestAnalyzer_Analyze(t *testing.T) {
 			plan.NewUnresolvedTable("mytable"),
 		),
 	)
-	analyzed, err = a.Analyze(notAnalyzed)
+	analyzed, err = a.Analyze(ctx, notAnalyzed)
 	expected = plan.NewLimit(int64(1),
 		plan.NewProject(
 			[]sql.Expression{
@@ -187,6 +188,7 @@ func TestAnalyzer_Analyze(t *testing.T) {
 
 func TestAnalyzer_Analyze_MaxIterations(t *testing.T) {
 	assert := require.New(t)
+	ctx := sql.NewEmptyContext()
 
 	catalog := &sql.Catalog{}
 	a := analyzer.New(catalog)
@@ -195,14 +197,14 @@ func TestAnalyzer_Analyze_MaxIterations(t *testing.T) {
 	i := 0
 	a.Rules = []analyzer.Rule{{
 		"infinite",
-		func(a *analyzer.Analyzer, n sql.Node) sql.Node {
+		func(ctx *sql.Context, a *analyzer.Analyzer, n sql.Node) (sql.Node, error) {
 			i += 1
-			return plan.NewUnresolvedTable(fmt.Sprintf("table%d", i))
+			return plan.NewUnresolvedTable(fmt.Sprintf("table%d", i)), nil
 		},
 	}}
 
 	notAnalyzed := plan.NewUnresolvedTable("mytable")
-	analyzed, err := a.Analyze(notAnalyzed)
+	analyzed, err := a.Analyze(ctx, notAnalyzed)
 	assert.NotNil(err)
 	assert.Equal(plan.NewUnresolvedTable("table1001"), analyzed)
 }
@@ -1,50 +1,114 @@
 package analyzer
 
 import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
 	"github.com/src-d/go-mysql-server/sql"
 	"github.com/src-d/go-mysql-server/sql/expression"
 	"github.com/src-d/go-mysql-server/sql/plan"
+
+	"github.com/src-d/go-vitess/sqltypes"
 )
 
 var DefaultRules = []Rule{
 	{"resolve_tables", resolveTables},
+	{"resolve_variables", resolveVariables},
 	{"resolve_columns", resolveColumns},
 	{"resolve_database", resolveDatabase},
 	{"resolve_star", resolveStar},
 	{"resolve_functions", resolveFunctions},
+	{"resolve_bindvars", resolveBindvars},
+	{"resolve_casts", resolveCasts},
+	{"coerce_types", coerceTypes},
+	{"pushdown_filters", pushdownFilters},
+	{"pushdown_projections", pushdownProjections},
 }
 
-func resolveDatabase(a *Analyzer, n sql.Node) sql.Node {
-	_, ok := n.(*plan.ShowTables)
-	if !ok {
-		return n
-	}
+// resolveDatabase resolves the databases of the SHOW statements. It returns
+// an error if a database is not found.
+func resolveDatabase(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
+	var err error
+	resolved := n.TransformUp(func(n sql.Node) sql.Node {
+		if err != nil {
+			return n
+		}
+
+		switch n := n.(type) {
+		case *plan.ShowTables:
+			var db sql.Database
+			db, err = a.database(n.Database)
+			if err != nil {
+				return n
+			}
+
+			return plan.NewShowTables(db, n.Full, n.Pattern)
+		case *plan.ShowTableStatus:
+			var db sql.Database
+			db, err = a.database(n.Database)
+			if err != nil {
+				return n
+			}
+
+			return plan.NewShowTableStatus(db, n.Pattern)
+		case *plan.ShowDatabases:
+			return &plan.ShowDatabases{Catalog: a.Catalog, Pattern: n.Pattern}
+		default:
+			return n
+		}
+	})
 
-	db, err := a.Catalog.Database(a.CurrentDatabase)
 	if err != nil {
-		return n
+		return nil, err
+	}
+
+	return resolved, nil
+}
+
+// database returns the database of the catalog an unresolved database
+// refers to, which is the current one if it has no name. Databases that are
+// already resolved are returned as they are.
+func (a *Analyzer) database(db sql.Database) (sql.Database, error) {
+	if _, ok := db.(*sql.UnresolvedDatabase); !ok {
+		return db, nil
 	}
 
-	return plan.NewShowTables(db)
+	name := db.Name()
+	if name == "" {
+		name = a.CurrentDatabase
+	}
+
+	return a.Catalog.Database(name)
 }
 
-func resolveTables(a *Analyzer, n sql.Node) sql.Node {
+func resolveTables(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
 	return n.TransformUp(func(n sql.Node) sql.Node {
 		t, ok := n.(*plan.UnresolvedTable)
 		if !ok {
 			return n
 		}
 
-		rt, err := a.Catalog.Table(a.CurrentDatabase, t.Name)
+		if t.Name == "dual" {
+			return plan.NewDual()
+		}
+
+		db := t.Database
+		if db == "" {
+			db = a.CurrentDatabase
+		}
+
+		rt, err := a.Catalog.Table(db, t.Name)
 		if err != nil {
 			return n
 		}
 
 		return rt
-	})
+	}), nil
 }
 
-func resolveStar(a *Analyzer, n sql.Node) sql.Node {
+func resolveStar(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
 	return n.TransformUp(func(n sql.Node) sql.Node {
 		if n.Resolved() {
 			return n
@@ -70,10 +134,10 @@ func resolveStar(a *Analyzer, n sql.Node) sql.Node {
 		}
 
 		return plan.NewProject(exprs, p.Child)
-	})
+	}), nil
 }
 
-func resolveColumns(a *Analyzer, n sql.Node) sql.Node {
+func resolveColumns(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
 	return n.TransformUp(func(n sql.Node) sql.Node {
 		if n.Resolved() {
 			return n
@@ -88,14 +152,17 @@ func resolveColumns(a *Analyzer, n sql.Node) sql.Node {
 			return n
 		}
 
+		// Column names are compared ignoring case, as the parser lowers the
+		// names of unresolved columns.
 		colMap := map[string]*expression.GetField{}
 		for idx, child := range child.Schema() {
-			if _, ok := colMap[child.Name]; ok {
+			name := strings.ToLower(child.Name)
+			if _, ok := colMap[name]; ok {
 				// There is no unambiguous resolution
 				return n
 			}
 
-			colMap[child.Name] = expression.NewGetField(idx, child.Type, child.Name, child.Nullable)
+			colMap[name] = expression.NewGetField(idx, child.Type, child.Name, child.Nullable)
 		}
 
 		return n.TransformExpressionsUp(func(e sql.Expression) sql.Expression {
@@ -111,10 +178,10 @@ func resolveColumns(a *Analyzer, n sql.Node) sql.Node {
 
 			return gf
 		})
-	})
+	}), nil
 }
 
-func resolveFunctions(a *Analyzer, n sql.Node) sql.Node {
+func resolveFunctions(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
 	return n.TransformUp(func(n sql.Node) sql.Node {
 		if n.Resolved() {
 			return n
@@ -139,5 +206,648 @@ func resolveFunctions(a *Analyzer, n sql.Node) sql.Node {
 
 			return rf
 		})
+	}), nil
+}
+
+// resolveVariables gives the variables of the plan their current values. It
+// returns an error if a system variable doesn't exist.
+func resolveVariables(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
+	var err error
+	resolved := n.TransformUp(func(n sql.Node) sql.Node {
+		if n.Resolved() || err != nil {
+			return n
+		}
+
+		return n.TransformExpressionsUp(func(e sql.Expression) sql.Expression {
+			switch v := e.(type) {
+			case *expression.SystemVar:
+				if v.Resolved() {
+					return e
+				}
+
+				typ, value, verr := ctx.SystemVariable(v.Scope, v.Variable)
+				if verr != nil {
+					err = verr
+					return e
+				}
+
+				return v.WithValue(typ, value)
+			case *expression.UserVar:
+				if v.Resolved() {
+					return e
+				}
+
+				typ, value := ctx.UserVariable(v.Variable)
+				return v.WithValue(typ, value)
+			default:
+				return e
+			}
+		})
 	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return resolved, nil
+}
+
+// resolveBindvars infers the types of the bind variables in the plan. A bind
+// variable takes the type of the expression it is compared with or the type
+// of the column its value is inserted into.
+func resolveBindvars(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
+	return n.TransformUp(func(n sql.Node) sql.Node {
+		if insert, ok := n.(*plan.InsertInto); ok {
+			return resolveInsertBindvars(insert)
+		}
+
+		return n.TransformExpressionsUp(func(e sql.Expression) sql.Expression {
+			switch e := e.(type) {
+			case *expression.Equals:
+				if l, r, ok := typeBindvars(e.Left, e.Right); ok {
+					return expression.NewEquals(l, r)
+				}
+			case *expression.Regexp:
+				if l, r, ok := typeBindvars(e.Left, e.Right); ok {
+					return expression.NewRegexp(l, r)
+				}
+			case *expression.GreaterThan:
+				if l, r, ok := typeBindvars(e.Left, e.Right); ok {
+					return expression.NewGreaterThan(l, r)
+				}
+			case *expression.LessThan:
+				if l, r, ok := typeBindvars(e.Left, e.Right); ok {
+					return expression.NewLessThan(l, r)
+				}
+			case *expression.GreaterThanOrEqual:
+				if l, r, ok := typeBindvars(e.Left, e.Right); ok {
+					return expression.NewGreaterThanOrEqual(l, r)
+				}
+			case *expression.LessThanOrEqual:
+				if l, r, ok := typeBindvars(e.Left, e.Right); ok {
+					return expression.NewLessThanOrEqual(l, r)
+				}
+			}
+
+			return e
+		})
+	}), nil
+}
+
+// typeBindvars gives an untyped bind variable on one side of a binary
+// expression the type of the other side. It returns false if no bind
+// variable was typed.
+func typeBindvars(left, right sql.Expression) (sql.Expression, sql.Expression, bool) {
+	if b, ok := left.(*expression.Bindvar); ok && !b.Typed() && isTyped(right) {
+		return b.WithType(right.Type()), right, true
+	}
+
+	if b, ok := right.(*expression.Bindvar); ok && !b.Typed() && isTyped(left) {
+		return left, b.WithType(left.Type()), true
+	}
+
+	return left, right, false
+}
+
+func isTyped(e sql.Expression) bool {
+	if !e.Resolved() {
+		return false
+	}
+
+	if b, ok := e.(*expression.Bindvar); ok {
+		return b.Typed()
+	}
+
+	return true
+}
+
+func resolveInsertBindvars(n *plan.InsertInto) sql.Node {
+	values, ok := n.Right.(*plan.Values)
+	if !ok || !n.Left.Resolved() {
+		return n
+	}
+
+	schema := n.Left.Schema()
+	types := make([]sql.Type, len(n.Columns))
+	for i, name := range n.Columns {
+		for _, c := range schema {
+			if c.Name == name {
+				types[i] = c.Type
+				break
+			}
+		}
+	}
+
+	var changed bool
+	tuples := make([][]sql.Expression, len(values.ExpressionTuples))
+	for i, tuple := range values.ExpressionTuples {
+		tuples[i] = make([]sql.Expression, len(tuple))
+		for j, e := range tuple {
+			tuples[i][j] = e
+			if j >= len(types) || types[j] == nil {
+				continue
+			}
+
+			if b, ok := e.(*expression.Bindvar); ok && !b.Typed() {
+				tuples[i][j] = b.WithType(types[j])
+				changed = true
+			}
+		}
+	}
+
+	if !changed {
+		return n
+	}
+
+	return plan.NewInsertInto(n.Left, plan.NewValues(tuples), n.Columns)
+}
+
+// resolveCasts gives the CAST expressions of the plan the session they
+// record their warnings in, and the time zone of the session.
+func resolveCasts(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
+	loc, err := sql.TimeZone(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.TransformUp(func(n sql.Node) sql.Node {
+		return n.TransformExpressionsUp(func(e sql.Expression) sql.Expression {
+			if c, ok := e.(*expression.Convert); ok && c.Explicit() && c.Session() == nil {
+				return c.WithSession(ctx.Session).WithTimeZone(loc)
+			}
+
+			return e
+		})
+	}), nil
+}
+
+// coerceTypes converts the operands of comparisons to a common type, so
+// they are compared by a type that knows the values of both. Literals take
+// the type of the other operand when their value doesn't change, so the
+// other operand doesn't need to be converted. Strings and numbers are
+// converted to TIMESTAMP values in the time zone of the session.
+func coerceTypes(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
+	loc, err := sql.TimeZone(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return n.TransformUp(func(n sql.Node) sql.Node {
+		return n.TransformExpressionsUp(func(e sql.Expression) sql.Expression {
+			switch e := e.(type) {
+			case *expression.Equals:
+				if l, r, ok := coerceOperands(e.Left, e.Right, loc); ok {
+					return expression.NewEquals(l, r)
+				}
+			case *expression.Regexp:
+				if l, r, ok := coerceRegexp(e.Left, e.Right); ok {
+					return expression.NewRegexp(l, r)
+				}
+			case *expression.GreaterThan:
+				if l, r, ok := coerceOperands(e.Left, e.Right, loc); ok {
+					return expression.NewGreaterThan(l, r)
+				}
+			case *expression.LessThan:
+				if l, r, ok := coerceOperands(e.Left, e.Right, loc); ok {
+					return expression.NewLessThan(l, r)
+				}
+			case *expression.GreaterThanOrEqual:
+				if l, r, ok := coerceOperands(e.Left, e.Right, loc); ok {
+					return expression.NewGreaterThanOrEqual(l, r)
+				}
+			case *expression.LessThanOrEqual:
+				if l, r, ok := coerceOperands(e.Left, e.Right, loc); ok {
+					return expression.NewLessThanOrEqual(l, r)
+				}
+			}
+
+			return e
+		})
+	}), nil
+}
+
+// coerceOperands returns the operands of a comparison converted to their
+// common type. It returns false if they don't need to be converted.
+func coerceOperands(
+	left, right sql.Expression,
+	loc *time.Location,
+) (sql.Expression, sql.Expression, bool) {
+	if !isTyped(left) || !isTyped(right) {
+		return left, right, false
+	}
+
+	if sql.IsText(left.Type()) && sql.IsText(right.Type()) {
+		return coerceCollations(left, right)
+	}
+
+	typ, ok := commonType(left.Type(), right.Type())
+	if !ok || typ == nil {
+		return left, right, false
+	}
+
+	// Strings are not compared like the values they are converted to, so
+	// literals are never converted to text, and they are JSON strings when
+	// they are compared with JSON values.
+	if typ == sql.JSON {
+		return convertTo(left, typ), convertTo(right, typ), true
+	}
+
+	if _, ok := left.(*expression.Literal); !ok && !sql.IsText(left.Type()) {
+		if lit, ok := right.(*expression.Literal); ok {
+			if r, ok := literalAs(lit, left.Type(), loc); ok {
+				return left, r, true
+			}
+		}
+	}
+
+	if _, ok := right.(*expression.Literal); !ok && !sql.IsText(right.Type()) {
+		if lit, ok := left.(*expression.Literal); ok {
+			if l, ok := literalAs(lit, right.Type(), loc); ok {
+				return l, right, true
+			}
+		}
+	}
+
+	return convertToIn(left, typ, loc), convertToIn(right, typ, loc), true
+}
+
+// coerceCollations returns the operands of a comparison of strings with the
+// type of the one whose collation is used to compare them. It returns false
+// if they already have the same collation.
+func coerceCollations(left, right sql.Expression) (sql.Expression, sql.Expression, bool) {
+	lt, rt := left.Type().(sql.StringType), right.Type().(sql.StringType)
+	collation, err := comparisonCollation(left, right)
+	if err != nil || lt.Collation() == rt.Collation() {
+		return left, right, false
+	}
+
+	if collation == lt.Collation() {
+		return left, convertTo(right, lt), true
+	}
+
+	return convertTo(left, rt), right, true
+}
+
+// Coercibility of the collations of expressions, as in MySQL. When strings
+// with different collations are compared, the collation with the lowest
+// coercibility is used.
+const (
+	explicitCoercibility  = 0
+	implicitCoercibility  = 2
+	coercibleCoercibility = 4
+)
+
+func coercibility(e sql.Expression) int {
+	switch e.(type) {
+	case *expression.Collate:
+		return explicitCoercibility
+	case *expression.Literal, *expression.Bindvar:
+		return coercibleCoercibility
+	default:
+		return implicitCoercibility
+	}
+}
+
+// comparisonCollation returns the collation two character strings are
+// compared with. It returns an error if no collation takes precedence over
+// the other, such as for columns with different collations, unless one of
+// them is binary or utf8mb4 and the other utf8.
+func comparisonCollation(left, right sql.Expression) (string, error) {
+	lc := left.Type().(sql.StringType).Collation()
+	rc := right.Type().(sql.StringType).Collation()
+	if lc == rc {
+		return lc, nil
+	}
+
+	lk, rk := coercibility(left), coercibility(right)
+	switch {
+	case lk < rk:
+		return lc, nil
+	case rk < lk:
+		return rc, nil
+	case lk == explicitCoercibility:
+	case strings.HasSuffix(lc, "_bin"):
+		return lc, nil
+	case strings.HasSuffix(rc, "_bin"):
+		return rc, nil
+	case strings.HasPrefix(lc, "utf8mb4_") && strings.HasPrefix(rc, "utf8_"):
+		// utf8mb4 is a superset of utf8.
+		return lc, nil
+	case strings.HasPrefix(rc, "utf8mb4_") && strings.HasPrefix(lc, "utf8_"):
+		return rc, nil
+	}
+
+	return "", fmt.Errorf("illegal mix of collations %s and %s", lc, rc)
+}
+
+// coerceRegexp returns the operands of a REGEXP converted to text. It
+// returns false if they are already text.
+func coerceRegexp(left, right sql.Expression) (sql.Expression, sql.Expression, bool) {
+	if !isTyped(left) || !isTyped(right) {
+		return left, right, false
+	}
+
+	l, r := left, right
+	if !sql.IsText(l.Type()) && l.Type() != sql.Null {
+		l = convertTo(l, sql.LongText)
+	}
+
+	if !sql.IsText(r.Type()) && r.Type() != sql.Null {
+		r = convertTo(r, sql.LongText)
+	}
+
+	return l, r, l != left || r != right
+}
+
+// literalAs returns a literal with the value of lit converted to typ. It
+// returns false if the value can't be converted or if it changes, such as
+// 1.5 converted to an integer.
+func literalAs(lit *expression.Literal, typ sql.Type, loc *time.Location) (sql.Expression, bool) {
+	v := lit.Eval(nil)
+	converted, err := sql.ConvertIn(typ, v, loc)
+	if err != nil {
+		return nil, false
+	}
+
+	back, err := lit.Type().Convert(converted)
+	if err != nil || lit.Type().Compare(back, v) != 0 {
+		return nil, false
+	}
+
+	return expression.NewLiteral(converted, typ), true
+}
+
+// convertTo returns the expression converted to typ. Literals are converted
+// when the plan is analyzed, and other expressions when they are evaluated.
+// Values are converted to JSON as the scalars they are compared as.
+func convertTo(e sql.Expression, typ sql.Type) sql.Expression {
+	return convertToIn(e, typ, time.UTC)
+}
+
+// convertToIn returns the expression converted to typ like convertTo does,
+// reading strings and numbers as TIMESTAMP values in the given time zone.
+func convertToIn(e sql.Expression, typ sql.Type, loc *time.Location) sql.Expression {
+	if e.Type() == typ {
+		return e
+	}
+
+	if typ == sql.JSON {
+		scalar := expression.NewJSONScalar(e)
+		if _, ok := e.(*expression.Literal); ok {
+			if v := scalar.Eval(nil); v != nil {
+				return expression.NewLiteral(v, typ)
+			}
+		}
+		return scalar
+	}
+
+	if lit, ok := e.(*expression.Literal); ok {
+		if v, err := sql.ConvertIn(typ, lit.Eval(nil), loc); err == nil {
+			return expression.NewLiteral(v, typ)
+		}
+	}
+
+	return expression.NewConvert(e, typ).WithTimeZone(loc)
+}
+
+// commonType returns the type values of the given types are converted to
+// before they are compared, or nil if they can be compared as they are. It
+// returns false if values of the types can't be compared. Like in MySQL,
+// numbers are compared with strings as floats, and temporal values are
+// compared with strings and numbers as temporal values.
+func commonType(a, b sql.Type) (sql.Type, bool) {
+	if a == b || a == sql.Null || b == sql.Null {
+		return nil, true
+	}
+
+	switch {
+	case a == sql.JSON || b == sql.JSON:
+		// Like in MySQL, other values are compared as JSON scalars. Binary
+		// strings are opaque JSON values in MySQL, which are not supported.
+		return sql.JSON, !sql.IsBinary(a) && !sql.IsBinary(b)
+	case isMembers(a) || isMembers(b):
+		// ENUM and SET values are compared with strings and numbers by
+		// their own types, so the strings and numbers are converted to them.
+		if isMembers(a) && (sql.IsText(b) || isNumeric(b)) {
+			return a, true
+		}
+		if isMembers(b) && (sql.IsText(a) || isNumeric(a)) {
+			return b, true
+		}
+		return nil, false
+	case isTemporal(a) && isTemporal(b):
+		if a == sql.Time || b == sql.Time {
+			return nil, false
+		}
+		return sql.Datetime(sql.MaxDatetimePrecision), true
+	case isTemporal(a):
+		return a, sql.IsText(b) || isNumeric(b)
+	case isTemporal(b):
+		return b, sql.IsText(a) || isNumeric(a)
+	case a == sql.Year || b == sql.Year:
+		if sql.IsText(a) || sql.IsText(b) {
+			return sql.Year, true
+		}
+		if isNumeric(a) || isNumeric(b) {
+			return sql.Int64, true
+		}
+		return nil, false
+	case sql.IsText(a) && sql.IsText(b):
+		return nil, true
+	case sql.IsBinary(a) || sql.IsBinary(b):
+		if isString(a) && isString(b) {
+			return sql.LongBlob, true
+		}
+		return nil, false
+	case isNumeric(a) && isNumeric(b):
+		return numericType(a, b), true
+	case isNumeric(a) && sql.IsText(b), sql.IsText(a) && isNumeric(b):
+		return sql.Float64, true
+	default:
+		return nil, false
+	}
+}
+
+func isString(t sql.Type) bool {
+	_, ok := t.(sql.StringType)
+	return ok
+}
+
+func isMembers(t sql.Type) bool {
+	switch t.(type) {
+	case sql.EnumType, sql.SetType:
+		return true
+	default:
+		return false
+	}
+}
+
+func isTemporal(t sql.Type) bool {
+	switch t.Type() {
+	case sqltypes.Timestamp, sqltypes.Date, sqltypes.Datetime, sqltypes.Time:
+		return true
+	default:
+		return false
+	}
+}
+
+func isNumeric(t sql.Type) bool {
+	typ := t.Type()
+	return t != sql.Year && (sqltypes.IsIntegral(typ) || sqltypes.IsFloat(typ) ||
+		typ == sqltypes.Decimal || typ == sqltypes.Bit)
+}
+
+// numericType returns the type two numeric types are compared with, which
+// can hold the values of both.
+func numericType(a, b sql.Type) sql.Type {
+	at, bt := a.Type(), b.Type()
+	switch {
+	case sqltypes.IsFloat(at) || sqltypes.IsFloat(bt):
+		return sql.Float64
+	case sql.IsDecimal(a) || sql.IsDecimal(b):
+		digits, scale := integerDigits(a), decimalScale(a)
+		if d := integerDigits(b); d > digits {
+			digits = d
+		}
+		if s := decimalScale(b); s > scale {
+			scale = s
+		}
+		if digits+scale > sql.MaxDecimalPrecision {
+			return sql.Float64
+		}
+		return sql.Decimal(digits+scale, scale)
+	case isUnsigned64(a) && isUnsigned64(b):
+		return sql.Uint64
+	case isUnsigned64(a) || isUnsigned64(b):
+		if sqltypes.IsUnsigned(at) && sqltypes.IsUnsigned(bt) {
+			return sql.Uint64
+		}
+		// Neither int64 nor uint64 can hold both values.
+		return sql.Decimal(20, 0)
+	default:
+		return sql.Int64
+	}
+}
+
+// isUnsigned64 returns whether the values of an integer type may not fit in
+// an int64.
+func isUnsigned64(t sql.Type) bool {
+	return t.Type() == sqltypes.Uint64 || t.Type() == sqltypes.Bit
+}
+
+// integerDigits returns the number of digits before the decimal point of
+// the values of a numeric type.
+func integerDigits(t sql.Type) int {
+	if d, ok := t.(sql.DecimalType); ok {
+		return d.Precision() - d.Scale()
+	}
+
+	switch t.Type() {
+	case sqltypes.Int8, sqltypes.Uint8:
+		return 3
+	case sqltypes.Int16, sqltypes.Uint16:
+		return 5
+	case sqltypes.Int24, sqltypes.Uint24:
+		return 8
+	case sqltypes.Int32, sqltypes.Uint32:
+		return 10
+	case sqltypes.Int64:
+		return 19
+	default:
+		return 20
+	}
+}
+
+func decimalScale(t sql.Type) int {
+	if d, ok := t.(sql.DecimalType); ok {
+		return d.Scale()
+	}
+
+	return 0
+}
+
+// pushdownFilters moves the conditions of filters to the tables below them
+// when the tables can apply them, and removes the filters.
+func pushdownFilters(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
+	return n.TransformUp(func(n sql.Node) sql.Node {
+		f, ok := n.(*plan.Filter)
+		if !ok || !f.Resolved() {
+			return n
+		}
+
+		t, ok := f.Child.(sql.FilteredTable)
+		if !ok {
+			return n
+		}
+
+		filters := []sql.Expression{f.Expression()}
+		if len(t.HandledFilters(filters)) != len(filters) {
+			return n
+		}
+
+		return t.WithFilters(filters)
+	}), nil
+}
+
+// pushdownProjections makes the tables below projections and groupings read
+// only the columns used by them. Only filters, sorts and limits can be
+// between them and the table, as they don't change the columns of the rows.
+func pushdownProjections(ctx *sql.Context, a *Analyzer, n sql.Node) (sql.Node, error) {
+	return n.TransformUp(func(n sql.Node) sql.Node {
+		switch n.(type) {
+		case *plan.Project, *plan.GroupBy:
+		default:
+			return n
+		}
+
+		if !n.Resolved() {
+			return n
+		}
+
+		child := n.Children()[0]
+	chain:
+		for {
+			switch c := child.(type) {
+			case *plan.Filter:
+				child = c.Child
+			case *plan.Sort:
+				child = c.Child
+			case *plan.Limit:
+				child = c.Child
+			default:
+				break chain
+			}
+		}
+
+		if _, ok := child.(sql.ProjectedTable); !ok {
+			return n
+		}
+
+		columns := usedColumns(n)
+		return n.TransformUp(func(n sql.Node) sql.Node {
+			if t, ok := n.(sql.ProjectedTable); ok {
+				return t.WithProjection(columns)
+			}
+
+			return n
+		})
+	}), nil
+}
+
+// usedColumns returns the sorted indexes of the fields used by the
+// expressions of a node and its children.
+func usedColumns(n sql.Node) []int {
+	seen := make(map[int]bool)
+	columns := []int{}
+	n.TransformExpressionsUp(func(e sql.Expression) sql.Expression {
+		if gf, ok := e.(*expression.GetField); ok && !seen[gf.Index()] {
+			seen[gf.Index()] = true
+			columns = append(columns, gf.Index())
+		}
+
+		return e
+	})
+
+	sort.Ints(columns)
+	return columns
 }
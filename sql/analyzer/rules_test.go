@@ -9,12 +9,15 @@ import (
 	"github.com/src-d/go-mysql-server/sql/expression"
 	"github.com/src-d/go-mysql-server/sql/plan"
 
+	"github.com/src-d/go-vitess/sqltypes"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_resolveTables(t *testing.T) {
 	assert := assert.New(t)
 
+	ctx := sql.NewEmptyContext()
 	f := getRule("resolve_tables")
 
 	table := mem.NewTable("mytable", sql.Schema{{Name: "i", Type: sql.Int32}})
@@ -28,21 +31,30 @@ func Test_resolveTables(t *testing.T) {
 
 	a.CurrentDatabase = "mydb"
 	var notAnalyzed sql.Node = plan.NewUnresolvedTable("mytable")
-	analyzed := f.Apply(a, notAnalyzed)
+	analyzed, err := f.Apply(ctx, a, notAnalyzed)
+	assert.NoError(err)
 	assert.Equal(table, analyzed)
 
 	notAnalyzed = plan.NewUnresolvedTable("nonexistant")
-	analyzed = f.Apply(a, notAnalyzed)
+	analyzed, err = f.Apply(ctx, a, notAnalyzed)
+	assert.NoError(err)
 	assert.Equal(notAnalyzed, analyzed)
 
-	analyzed = f.Apply(a, table)
+	analyzed, err = f.Apply(ctx, a, table)
+	assert.NoError(err)
 	assert.Equal(table, analyzed)
 
+	a.CurrentDatabase = "otherdb"
+	notAnalyzed = plan.NewQualifiedUnresolvedTable("mydb", "mytable")
+	analyzed, err = f.Apply(ctx, a, notAnalyzed)
+	assert.NoError(err)
+	assert.Equal(table, analyzed)
 }
 
 func Test_resolveTables_Nested(t *testing.T) {
 	assert := assert.New(t)
 
+	ctx := sql.NewEmptyContext()
 	f := getRule("resolve_tables")
 
 	table := mem.NewTable("mytable", sql.Schema{{Name: "i", Type: sql.Int32}})
@@ -59,7 +71,8 @@ func Test_resolveTables_Nested(t *testing.T) {
 		[]sql.Expression{expression.NewGetField(0, sql.Int32, "i", true)},
 		plan.NewUnresolvedTable("mytable"),
 	)
-	analyzed := f.Apply(a, notAnalyzed)
+	analyzed, err := f.Apply(ctx, a, notAnalyzed)
+	assert.NoError(err)
 	expected := plan.NewProject(
 		[]sql.Expression{expression.NewGetField(0, sql.Int32, "i", true)},
 		table,
@@ -67,6 +80,349 @@ func Test_resolveTables_Nested(t *testing.T) {
 	assert.Equal(expected, analyzed)
 }
 
+func Test_resolveVariables(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+	ctx.SetUserVariable("foo", sql.Text, "bar")
+
+	f := getRule("resolve_variables")
+	a := analyzer.New(sql.NewCatalog())
+
+	notAnalyzed := plan.NewProject(
+		[]sql.Expression{
+			expression.NewSystemVar("@@autocommit", "autocommit", sql.DefaultScope),
+			expression.NewUserVar("foo"),
+		},
+		plan.NewUnresolvedTable("dual"),
+	)
+	analyzed, err := f.Apply(ctx, a, notAnalyzed)
+	require.NoError(err)
+	expected := plan.NewProject(
+		[]sql.Expression{
+			expression.NewSystemVar("@@autocommit", "autocommit", sql.DefaultScope).
+				WithValue(sql.Int64, int64(1)),
+			expression.NewUserVar("foo").WithValue(sql.Text, "bar"),
+		},
+		plan.NewUnresolvedTable("dual"),
+	)
+	require.Equal(expected, analyzed)
+
+	_, err = f.Apply(ctx, a, plan.NewProject(
+		[]sql.Expression{expression.NewSystemVar("@@unknown", "unknown", sql.DefaultScope)},
+		plan.NewUnresolvedTable("dual"),
+	))
+	require.EqualError(err, "unknown system variable: unknown")
+}
+
+func Test_resolveBindvars(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	f := getRule("resolve_bindvars")
+	a := analyzer.New(sql.NewCatalog())
+
+	table := mem.NewTable("mytable", sql.Schema{
+		{Name: "i", Type: sql.Int32},
+		{Name: "s", Type: sql.Text},
+	})
+
+	var notAnalyzed sql.Node = plan.NewFilter(
+		expression.NewEquals(
+			expression.NewBindvar("v1"),
+			expression.NewGetField(0, sql.Int32, "i", false),
+		),
+		table,
+	)
+	analyzed, err := f.Apply(ctx, a, notAnalyzed)
+	require.NoError(err)
+	var expected sql.Node = plan.NewFilter(
+		expression.NewEquals(
+			expression.NewBindvar("v1").WithType(sql.Int32),
+			expression.NewGetField(0, sql.Int32, "i", false),
+		),
+		table,
+	)
+	require.Equal(expected, analyzed)
+
+	notAnalyzed = plan.NewInsertInto(
+		table,
+		plan.NewValues([][]sql.Expression{{
+			expression.NewBindvar("v1"),
+			expression.NewBindvar("v2"),
+		}}),
+		[]string{"s", "i"},
+	)
+	analyzed, err = f.Apply(ctx, a, notAnalyzed)
+	require.NoError(err)
+	expected = plan.NewInsertInto(
+		table,
+		plan.NewValues([][]sql.Expression{{
+			expression.NewBindvar("v1").WithType(sql.Text),
+			expression.NewBindvar("v2").WithType(sql.Int32),
+		}}),
+		[]string{"s", "i"},
+	)
+	require.Equal(expected, analyzed)
+}
+
+func Test_coerceTypes(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	f := getRule("coerce_types")
+	a := analyzer.New(sql.NewCatalog())
+
+	table := mem.NewTable("mytable", sql.Schema{
+		{Name: "i", Type: sql.Int32},
+		{Name: "s", Type: sql.Text},
+	})
+	i := expression.NewGetField(0, sql.Int32, "i", false)
+	s := expression.NewGetField(1, sql.Text, "s", false)
+
+	// Literals whose value doesn't change take the type of the column.
+	node := plan.NewFilter(
+		expression.NewEquals(i, expression.NewLiteral("5", sql.Text)),
+		table,
+	)
+	expected := plan.NewFilter(
+		expression.NewEquals(i, expression.NewLiteral(int32(5), sql.Int32)),
+		table,
+	)
+	analyzed, err := f.Apply(ctx, a, node)
+	require.NoError(err)
+	require.Equal(expected, analyzed)
+
+	node = plan.NewFilter(
+		expression.NewLessThan(i, expression.NewLiteral(float64(1.5), sql.Float64)),
+		table,
+	)
+	expected = plan.NewFilter(
+		expression.NewLessThan(
+			expression.NewConvert(i, sql.Float64),
+			expression.NewLiteral(float64(1.5), sql.Float64),
+		),
+		table,
+	)
+	analyzed, err = f.Apply(ctx, a, node)
+	require.NoError(err)
+	require.Equal(expected, analyzed)
+
+	// Numbers are compared with strings as floats.
+	node = plan.NewFilter(expression.NewEquals(i, s), table)
+	expected = plan.NewFilter(
+		expression.NewEquals(
+			expression.NewConvert(i, sql.Float64),
+			expression.NewConvert(s, sql.Float64),
+		),
+		table,
+	)
+	analyzed, err = f.Apply(ctx, a, node)
+	require.NoError(err)
+	require.Equal(expected, analyzed)
+
+	var notCoerced sql.Node = plan.NewFilter(
+		expression.NewEquals(i, expression.NewLiteral(int32(1), sql.Int32)),
+		table,
+	)
+	analyzed, err = f.Apply(ctx, a, notCoerced)
+	require.NoError(err)
+	require.Equal(notCoerced, analyzed)
+}
+
+func Test_coerceTypes_Collations(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	f := getRule("coerce_types")
+	a := analyzer.New(sql.NewCatalog())
+
+	bin, err := sql.CreateString(sqltypes.Text, sql.TextLength, "", "utf8_bin")
+	require.NoError(err)
+
+	table := mem.NewTable("mytable", sql.Schema{{Name: "s", Type: sql.Text}})
+	s := expression.NewGetField(0, sql.Text, "s", false)
+
+	// The collation of the column is used to compare it with a literal.
+	var node sql.Node = plan.NewFilter(
+		expression.NewEquals(s, expression.NewLiteral("a", bin)),
+		table,
+	)
+	expected := plan.NewFilter(
+		expression.NewEquals(s, expression.NewLiteral("a", sql.Text)),
+		table,
+	)
+	analyzed, err := f.Apply(ctx, a, node)
+	require.NoError(err)
+	require.Equal(expected, analyzed)
+
+	// And the explicit collation is used over the one of the column.
+	collate := expression.NewCollate(expression.NewLiteral("a", sql.Text), "utf8_bin")
+	node = plan.NewFilter(expression.NewEquals(s, collate), table)
+	expected = plan.NewFilter(
+		expression.NewEquals(expression.NewConvert(s, bin), collate),
+		table,
+	)
+	analyzed, err = f.Apply(ctx, a, node)
+	require.NoError(err)
+	require.Equal(expected, analyzed)
+}
+
+func Test_coerceTypes_JSON(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	f := getRule("coerce_types")
+	a := analyzer.New(sql.NewCatalog())
+
+	table := mem.NewTable("mytable", sql.Schema{
+		{Name: "i", Type: sql.Int32},
+		{Name: "j", Type: sql.JSON},
+	})
+	i := expression.NewGetField(0, sql.Int32, "i", false)
+	j := expression.NewGetField(1, sql.JSON, "j", false)
+
+	// Strings are compared with JSON values as JSON strings, not as the
+	// JSON text in them.
+	var node sql.Node = plan.NewFilter(
+		expression.NewEquals(j, expression.NewLiteral("5", sql.Text)),
+		table,
+	)
+	expected := plan.NewFilter(
+		expression.NewEquals(j, expression.NewLiteral(sql.JSONDocument{Val: "5"}, sql.JSON)),
+		table,
+	)
+	analyzed, err := f.Apply(ctx, a, node)
+	require.NoError(err)
+	require.Equal(expected, analyzed)
+
+	node = plan.NewFilter(expression.NewLessThan(i, j), table)
+	expected = plan.NewFilter(
+		expression.NewLessThan(expression.NewJSONScalar(i), j),
+		table,
+	)
+	analyzed, err = f.Apply(ctx, a, node)
+	require.NoError(err)
+	require.Equal(expected, analyzed)
+}
+
+func Test_pushdownFilters(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	f := getRule("pushdown_filters")
+	a := analyzer.New(sql.NewCatalog())
+
+	table := &filteredTable{Table: mem.NewTable("mytable", sql.Schema{
+		{Name: "i", Type: sql.Int32},
+		{Name: "s", Type: sql.Text},
+	})}
+
+	equals := expression.NewEquals(
+		expression.NewGetField(0, sql.Int32, "i", false),
+		expression.NewLiteral(int32(1), sql.Int32),
+	)
+	node := plan.NewProject(
+		[]sql.Expression{expression.NewGetField(1, sql.Text, "s", false)},
+		plan.NewFilter(equals, table),
+	)
+	expected := plan.NewProject(
+		[]sql.Expression{expression.NewGetField(1, sql.Text, "s", false)},
+		&filteredTable{Table: table.Table, filters: []sql.Expression{equals}},
+	)
+	analyzed, err := f.Apply(ctx, a, node)
+	require.NoError(err)
+	require.Equal(expected, analyzed)
+
+	var notHandled sql.Node = plan.NewFilter(
+		expression.NewRegexp(
+			expression.NewGetField(1, sql.Text, "s", false),
+			expression.NewLiteral("a", sql.Text),
+		),
+		table,
+	)
+	analyzed, err = f.Apply(ctx, a, notHandled)
+	require.NoError(err)
+	require.Equal(notHandled, analyzed)
+
+	notHandled = plan.NewFilter(equals, table.Table)
+	analyzed, err = f.Apply(ctx, a, notHandled)
+	require.NoError(err)
+	require.Equal(notHandled, analyzed)
+}
+
+// filteredTable is a table that handles equality filters.
+type filteredTable struct {
+	*mem.Table
+	filters []sql.Expression
+}
+
+func (t *filteredTable) HandledFilters(filters []sql.Expression) []sql.Expression {
+	var handled []sql.Expression
+	for _, f := range filters {
+		if _, ok := f.(*expression.Equals); ok {
+			handled = append(handled, f)
+		}
+	}
+
+	return handled
+}
+
+func (t *filteredTable) WithFilters(filters []sql.Expression) sql.Table {
+	return &filteredTable{Table: t.Table, filters: filters}
+}
+
+func (t *filteredTable) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(t)
+}
+
+func Test_pushdownProjections(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	f := getRule("pushdown_projections")
+	a := analyzer.New(sql.NewCatalog())
+
+	table := &projectedTable{Table: mem.NewTable("mytable", sql.Schema{
+		{Name: "i", Type: sql.Int32},
+		{Name: "s", Type: sql.Text},
+		{Name: "f", Type: sql.Float64},
+	})}
+
+	project := []sql.Expression{expression.NewGetField(2, sql.Float64, "f", false)}
+	filter := expression.NewEquals(
+		expression.NewGetField(0, sql.Int32, "i", false),
+		expression.NewLiteral(int32(1), sql.Int32),
+	)
+
+	node := plan.NewProject(project, plan.NewFilter(filter, table))
+	expected := plan.NewProject(project, plan.NewFilter(
+		filter,
+		&projectedTable{Table: table.Table, columns: []int{0, 2}},
+	))
+	analyzed, err := f.Apply(ctx, a, node)
+	require.NoError(err)
+	require.Equal(expected, analyzed)
+
+	var notProjected sql.Node = plan.NewProject(project, plan.NewCrossJoin(table, table))
+	analyzed, err = f.Apply(ctx, a, notProjected)
+	require.NoError(err)
+	require.Equal(notProjected, analyzed)
+}
+
+// projectedTable is a table that records the columns it reads.
+type projectedTable struct {
+	*mem.Table
+	columns []int
+}
+
+func (t *projectedTable) WithProjection(columns []int) sql.Table {
+	return &projectedTable{Table: t.Table, columns: columns}
+}
+
+func (t *projectedTable) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(t)
+}
+
 func getRule(name string) analyzer.Rule {
 	for _, rule := range analyzer.DefaultRules {
 		if rule.Name == name {
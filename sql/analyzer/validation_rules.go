@@ -2,14 +2,19 @@ package analyzer
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/expression"
 	"github.com/src-d/go-mysql-server/sql/plan"
 )
 
 var DefaultValidationRules = []ValidationRule{
 	{"validate_resolved", validateIsResolved},
 	{"validate_order_by", validateOrderBy},
+	{"validate_comparisons", validateComparisons},
+	{"validate_json_paths", validateJSONPaths},
+	{"validate_json_arguments", validateJSONArguments},
 }
 
 func validateIsResolved(a *Analyzer, n sql.Node) error {
@@ -33,3 +38,128 @@ func validateOrderBy(a *Analyzer, n sql.Node) error {
 
 	return nil
 }
+
+// validateComparisons checks that the operands of the comparisons of a node
+// can be compared.
+func validateComparisons(a *Analyzer, n sql.Node) error {
+	var err error
+	n.TransformExpressionsUp(func(e sql.Expression) sql.Expression {
+		var left, right sql.Expression
+		switch e := e.(type) {
+		case *expression.Equals:
+			left, right = e.Left, e.Right
+		case *expression.GreaterThan:
+			left, right = e.Left, e.Right
+		case *expression.LessThan:
+			left, right = e.Left, e.Right
+		case *expression.GreaterThanOrEqual:
+			left, right = e.Left, e.Right
+		case *expression.LessThanOrEqual:
+			left, right = e.Left, e.Right
+		default:
+			return e
+		}
+
+		if err != nil || !left.Resolved() || !right.Resolved() {
+			return e
+		}
+
+		if sql.IsText(left.Type()) && sql.IsText(right.Type()) {
+			if _, cerr := comparisonCollation(left, right); cerr != nil {
+				err = fmt.Errorf("can't compare %s with %s: %s", left, right, cerr)
+			}
+		} else if _, ok := commonType(left.Type(), right.Type()); !ok {
+			err = fmt.Errorf(
+				"can't compare %s of type %s with %s of type %s",
+				left, sql.MySQLTypeName(left.Type()),
+				right, sql.MySQLTypeName(right.Type()),
+			)
+		}
+
+		return e
+	})
+
+	return err
+}
+
+// validateJSONPaths checks that the JSON paths of the JSON functions of a
+// node are valid, if they are literals.
+func validateJSONPaths(a *Analyzer, n sql.Node) error {
+	var err error
+	n.TransformExpressionsUp(func(e sql.Expression) sql.Expression {
+		f, ok := e.(expression.JSONPathFunction)
+		if !ok || err != nil {
+			return e
+		}
+
+		for _, p := range f.Paths() {
+			lit, ok := p.(*expression.Literal)
+			if !ok || lit.Eval(nil) == nil {
+				continue
+			}
+
+			path, cerr := sql.LongText.Convert(lit.Eval(nil))
+			if cerr == nil {
+				_, cerr = sql.ParseJSONPath(path.(string))
+			}
+
+			if cerr != nil {
+				err = fmt.Errorf("%s: %s", f, cerr)
+				return e
+			}
+		}
+
+		return e
+	})
+
+	return err
+}
+
+// validateJSONArguments checks that the JSON documents of the JSON functions
+// of a node are valid JSON text and that the arguments of JSON_OBJECT are
+// pairs of keys and values with keys that are not NULL, if they are
+// literals.
+func validateJSONArguments(a *Analyzer, n sql.Node) error {
+	var err error
+	n.TransformExpressionsUp(func(e sql.Expression) sql.Expression {
+		if err != nil {
+			return e
+		}
+
+		switch f := e.(type) {
+		case *expression.JSONObject:
+			err = validateJSONObject(f)
+		case expression.JSONDocumentFunction:
+			for i, d := range f.Documents() {
+				lit, ok := d.(*expression.Literal)
+				if !ok || lit.Eval(nil) == nil {
+					continue
+				}
+
+				if _, cerr := sql.JSON.Convert(lit.Eval(nil)); cerr != nil {
+					err = fmt.Errorf("%s: argument %d: %s", f, i+1, cerr)
+					break
+				}
+			}
+		}
+
+		return e
+	})
+
+	return err
+}
+
+func validateJSONObject(f *expression.JSONObject) error {
+	args := f.Args()
+	if len(args)%2 != 0 {
+		return fmt.Errorf("%s: incorrect number of arguments", f)
+	}
+
+	for i := 0; i < len(args); i += 2 {
+		if lit, ok := args[i].(*expression.Literal); ok && lit.Eval(nil) == nil {
+			return fmt.Errorf("%s: JSON documents may not contain NULL member names", f)
+		}
+	}
+
+	return nil
+}
@@ -3,11 +3,13 @@ package analyzer_test
 import (
 	"testing"
 
+	"github.com/src-d/go-mysql-server/mem"
 	"github.com/src-d/go-mysql-server/sql"
 	"github.com/src-d/go-mysql-server/sql/analyzer"
 	"github.com/src-d/go-mysql-server/sql/expression"
 	"github.com/src-d/go-mysql-server/sql/plan"
 
+	"github.com/src-d/go-vitess/sqltypes"
 	"github.com/stretchr/testify/require"
 )
 
@@ -45,13 +47,139 @@ func Test_orderBy(t *testing.T) {
 	assert.Error(err)
 }
 
+func Test_comparisons(t *testing.T) {
+	assert := require.New(t)
+
+	vr := getValidationRule("validate_comparisons")
+	table := mem.NewTable("mytable", sql.Schema{})
+
+	assert.Equal(vr.Name, "validate_comparisons")
+
+	err := vr.Apply(nil, dummyNode{true})
+	assert.NoError(err)
+
+	err = vr.Apply(nil, plan.NewFilter(
+		expression.NewEquals(
+			expression.NewGetField(0, sql.Int64, "i", false),
+			expression.NewLiteral("1", sql.Text),
+		),
+		table,
+	))
+	assert.NoError(err)
+
+	err = vr.Apply(nil, plan.NewFilter(
+		expression.NewEquals(
+			expression.NewGetField(0, sql.JSON, "j", false),
+			expression.NewLiteral([]byte("1"), sql.Blob),
+		),
+		table,
+	))
+	assert.EqualError(err, "can't compare j of type json with [49] of type blob")
+
+	bin, err := sql.CreateString(sqltypes.Text, sql.TextLength, "", "utf8_bin")
+	assert.NoError(err)
+
+	err = vr.Apply(nil, plan.NewFilter(
+		expression.NewEquals(
+			expression.NewGetField(0, sql.Text, "a", false),
+			expression.NewGetField(1, bin, "b", false),
+		),
+		table,
+	))
+	assert.NoError(err)
+
+	err = vr.Apply(nil, plan.NewFilter(
+		expression.NewEquals(
+			expression.NewCollate(expression.NewGetField(0, sql.Text, "a", false), "utf8_general_ci"),
+			expression.NewCollate(expression.NewGetField(1, sql.Text, "b", false), "utf8_bin"),
+		),
+		table,
+	))
+	assert.Error(err)
+
+	err = vr.Apply(nil, plan.NewFilter(
+		expression.NewGreaterThan(
+			expression.NewGetField(0, sql.Time, "t", false),
+			expression.NewGetField(1, sql.Date, "d", false),
+		),
+		table,
+	))
+	assert.Error(err)
+}
+
+func Test_jsonPaths(t *testing.T) {
+	assert := require.New(t)
+
+	vr := getValidationRule("validate_json_paths")
+	table := mem.NewTable("mytable", sql.Schema{})
+	doc := expression.NewGetField(0, sql.JSON, "j", false)
+
+	err := vr.Apply(nil, plan.NewProject([]sql.Expression{
+		expression.NewJSONExtract(doc, expression.NewLiteral("$.a", sql.Text)),
+		expression.NewJSONKeys(doc, expression.NewGetField(1, sql.Text, "p", false)),
+	}, table))
+	assert.NoError(err)
+
+	err = vr.Apply(nil, plan.NewProject([]sql.Expression{
+		expression.NewJSONExtract(doc, expression.NewLiteral("$.a", sql.Text), expression.NewLiteral("a", sql.Text)),
+	}, table))
+	assert.EqualError(err, `JSON_EXTRACT(j, "$.a", "a"): invalid JSON path "a"`)
+
+	err = vr.Apply(nil, plan.NewFilter(
+		expression.NewJSONContains(doc, doc, expression.NewLiteral("$[", sql.Text)),
+		table,
+	))
+	assert.Error(err)
+}
+
+func Test_jsonArguments(t *testing.T) {
+	assert := require.New(t)
+
+	vr := getValidationRule("validate_json_arguments")
+	table := mem.NewTable("mytable", sql.Schema{})
+	doc := expression.NewGetField(0, sql.Text, "t", false)
+	path := expression.NewLiteral("$.a", sql.Text)
+	key := expression.NewLiteral("a", sql.Text)
+	null := expression.NewLiteral(nil, sql.Null)
+
+	err := vr.Apply(nil, plan.NewProject([]sql.Expression{
+		expression.NewJSONExtract(doc, path),
+		expression.NewJSONExtract(expression.NewLiteral(`{"a": 1}`, sql.Text), path),
+		expression.NewJSONExtract(null, path),
+		expression.NewJSONObject(key, null, doc, doc),
+		expression.NewJSONObject(),
+	}, table))
+	assert.NoError(err)
+
+	err = vr.Apply(nil, plan.NewProject([]sql.Expression{
+		expression.NewJSONExtract(expression.NewLiteral(`{"a":`, sql.Text), path),
+	}, table))
+	assert.Error(err)
+
+	err = vr.Apply(nil, plan.NewFilter(
+		expression.NewJSONContains(doc, expression.NewLiteral("[1", sql.Text)),
+		table,
+	))
+	assert.Error(err)
+
+	err = vr.Apply(nil, plan.NewProject([]sql.Expression{
+		expression.NewJSONObject(key, doc, key),
+	}, table))
+	assert.EqualError(err, `JSON_OBJECT("a", t, "a"): incorrect number of arguments`)
+
+	err = vr.Apply(nil, plan.NewProject([]sql.Expression{
+		expression.NewJSONObject(null, doc),
+	}, table))
+	assert.Error(err)
+}
+
 type dummyNode struct{ resolved bool }
 
-func (n dummyNode) Resolved() bool                             { return n.resolved }
-func (dummyNode) Schema() sql.Schema                           { return sql.Schema{} }
-func (dummyNode) Children() []sql.Node                         { return nil }
-func (dummyNode) RowIter() (sql.RowIter, error)                { return nil, nil }
-func (dummyNode) TransformUp(func(sql.Node) sql.Node) sql.Node { return nil }
+func (n dummyNode) Resolved() bool                              { return n.resolved }
+func (dummyNode) Schema() sql.Schema                            { return sql.Schema{} }
+func (dummyNode) Children() []sql.Node                          { return nil }
+func (dummyNode) RowIter(ctx *sql.Context) (sql.RowIter, error) { return nil, nil }
+func (dummyNode) TransformUp(func(sql.Node) sql.Node) sql.Node  { return nil }
 func (dummyNode) TransformExpressionsUp(
 	func(sql.Expression) sql.Expression) sql.Node {
 	return nil
@@ -2,12 +2,15 @@ package sql
 
 import (
 	"fmt"
+	"strings"
 )
 
-// Catalog holds databases, tables and functions.
+// Catalog holds databases, tables, functions and the global values of the
+// system variables.
 type Catalog struct {
 	Databases
 	FunctionRegistry
+	SystemVariables *SystemVariables
 }
 
 // NewCatalog returns a new empty Catalog.
@@ -15,13 +18,15 @@ func NewCatalog() *Catalog {
 	return &Catalog{
 		Databases:        Databases{},
 		FunctionRegistry: NewFunctionRegistry(),
+		SystemVariables:  NewSystemVariables(),
 	}
 }
 
 // Databases is a collection of Database.
 type Databases []Database
 
-// Database returns the Database with the given name if it exists.
+// Database returns the Database with the given name if it exists. If there
+// is no database with exactly that name, names are compared ignoring case.
 func (d Databases) Database(name string) (Database, error) {
 	for _, db := range d {
 		if db.Name() == name {
@@ -29,10 +34,18 @@ func (d Databases) Database(name string) (Database, error) {
 		}
 	}
 
+	for _, db := range d {
+		if strings.EqualFold(db.Name(), name) {
+			return db, nil
+		}
+	}
+
 	return nil, fmt.Errorf("database not found: %s", name)
 }
 
-// Table returns the Table with the given name if it exists.
+// Table returns the Table with the given name if it exists. As with
+// databases, names are compared ignoring case if there is no table with
+// exactly that name.
 func (d Databases) Table(dbName string, tableName string) (Table, error) {
 	db, err := d.Database(dbName)
 	if err != nil {
@@ -40,10 +53,15 @@ func (d Databases) Table(dbName string, tableName string) (Table, error) {
 	}
 
 	tables := db.Tables()
-	table, found := tables[tableName]
-	if !found {
-		return nil, fmt.Errorf("table not found: %s", tableName)
+	if table, found := tables[tableName]; found {
+		return table, nil
+	}
+
+	for name, table := range tables {
+		if strings.EqualFold(name, tableName) {
+			return table, nil
+		}
 	}
 
-	return table, nil
+	return nil, fmt.Errorf("table not found: %s", tableName)
 }
@@ -23,6 +23,10 @@ func TestCatalog_Database(t *testing.T) {
 	db, err = c.Database("foo")
 	assert.NoError(err)
 	assert.Equal(mydb, db)
+
+	db, err = c.Database("FOO")
+	assert.NoError(err)
+	assert.Equal(mydb, db)
 }
 
 func TestCatalog_Table(t *testing.T) {
@@ -47,4 +51,8 @@ func TestCatalog_Table(t *testing.T) {
 	table, err = c.Table("foo", "bar")
 	assert.NoError(err)
 	assert.Equal(mytable, table)
+
+	table, err = c.Table("foo", "BAR")
+	assert.NoError(err)
+	assert.Equal(mytable, table)
 }
@@ -0,0 +1,134 @@
+package sql
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// collation is the way a collation compares strings.
+type collation struct {
+	charset string
+	// fold is true if letters are compared without case and accents.
+	fold bool
+	// padSpace is true if trailing spaces are ignored.
+	padSpace bool
+	// expand is true if letters like ß and æ are compared as the letters
+	// they stand for, such as ss and ae, instead of as a single letter.
+	expand bool
+}
+
+// collations are the supported collations by name. As in MySQL, _bin
+// collations compare the code points of the characters, _general_ci
+// collations compare them without case and accents, and _0900_ai_ci
+// collations also expand ligatures and don't ignore trailing spaces.
+var collations = map[string]collation{
+	"utf8_bin":           {charset: "utf8", padSpace: true},
+	"utf8_general_ci":    {charset: "utf8", fold: true, padSpace: true},
+	"utf8mb4_bin":        {charset: "utf8mb4", padSpace: true},
+	"utf8mb4_general_ci": {charset: "utf8mb4", fold: true, padSpace: true},
+	"utf8mb4_0900_bin":   {charset: "utf8mb4"},
+	"utf8mb4_0900_ai_ci": {charset: "utf8mb4", fold: true, expand: true},
+	"latin1_bin":         {charset: "latin1", padSpace: true},
+	"latin1_general_ci":  {charset: "latin1", fold: true, padSpace: true},
+	"ascii_bin":          {charset: "ascii", padSpace: true},
+	"ascii_general_ci":   {charset: "ascii", fold: true, padSpace: true},
+}
+
+// CollationCharacterSet returns the character set of a collation. It
+// returns an error if the collation is not supported.
+func CollationCharacterSet(name string) (string, error) {
+	c, ok := collations[strings.ToLower(name)]
+	if !ok {
+		return "", fmt.Errorf("unknown collation %s", name)
+	}
+
+	return c.charset, nil
+}
+
+// CharacterSetCollation returns the default collation of a character set,
+// which is its _general_ci collation. It returns an error if the character
+// set is not supported.
+func CharacterSetCollation(charset string) (string, error) {
+	name := charset + "_general_ci"
+	if _, ok := collations[name]; !ok {
+		return "", fmt.Errorf("unknown character set %s", charset)
+	}
+
+	return name, nil
+}
+
+// CompareStrings compares two strings with a collation, and returns -1 if
+// a < b, 0 if a == b and +1 if a > b. Unknown collations compare the bytes
+// of the strings.
+func CompareStrings(collation, a, b string) int {
+	c := collations[collation]
+	if !c.fold {
+		if c.padSpace {
+			a, b = strings.TrimRight(a, " "), strings.TrimRight(b, " ")
+		}
+
+		return strings.Compare(a, b)
+	}
+
+	return strings.Compare(c.key(a), c.key(b))
+}
+
+// CollationKey returns a key of a string in a collation. The keys of two
+// strings are equal if and only if the collation compares the strings as
+// equal, so they can be used to group strings.
+func CollationKey(collation, s string) string {
+	return collations[collation].key(s)
+}
+
+func (c collation) key(s string) string {
+	if c.padSpace {
+		s = strings.TrimRight(s, " ")
+	}
+
+	if !c.fold {
+		return s
+	}
+
+	var buf bytes.Buffer
+	for _, r := range s {
+		if c.expand {
+			if e, ok := expansions[r]; ok {
+				buf.WriteString(e)
+				continue
+			}
+		}
+
+		buf.WriteRune(baseLetter(r))
+	}
+
+	return buf.String()
+}
+
+// latinLetters are the base letters of the letters from U+00C0 to U+017F,
+// which are the accented letters of Latin-1 and Latin Extended-A. Dots are
+// letters without a base letter.
+const latinLetters = "AAAAAA.CEEEEIIIIDNOOOOO.OUUUUY.SAAAAAA.CEEEEIIIIDNOOOOO.OUUUUY.Y" +
+	"AAAAAACCCCCCCCDDDDEEEEEEEEEEGGGGGGGGHHHHIIIIIIIIII..JJKKKLLLLLLLLLL" +
+	"NNNNNNNNNOOOOOO..RRRRRRSSSSSSSSTTTTTTUUUUUUUUUUUUWWYYYZZZZZZS"
+
+const firstLatinLetter = 0xc0
+
+// expansions are the letters compared as several letters by the _0900_ai_ci
+// collations.
+var expansions = map[rune]string{
+	'ß': "SS", 'Æ': "AE", 'æ': "AE", 'Œ': "OE", 'œ': "OE",
+	'Þ': "TH", 'þ': "TH", 'Ĳ': "IJ", 'ĳ': "IJ",
+}
+
+// baseLetter returns the upper case letter without accents of a letter.
+func baseLetter(r rune) rune {
+	if r >= firstLatinLetter && r < firstLatinLetter+rune(len(latinLetters)) {
+		if b := latinLetters[r-firstLatinLetter]; b != '.' {
+			return rune(b)
+		}
+	}
+
+	return unicode.ToUpper(r)
+}
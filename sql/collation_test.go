@@ -0,0 +1,69 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/src-d/go-vitess/sqltypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareStrings(t *testing.T) {
+	testCases := []struct {
+		collation string
+		a, b      string
+		expected  int
+	}{
+		{"utf8_bin", "a", "A", 1},
+		{"utf8_bin", "a", "a  ", 0},
+		{"utf8mb4_0900_bin", "a", "a  ", -1},
+		{"utf8_general_ci", "John", "jOHN", 0},
+		{"utf8_general_ci", "café", "CAFE", 0},
+		{"utf8_general_ci", "a", "a  ", 0},
+		{"utf8_general_ci", "a", "B", -1},
+		{"utf8_general_ci", "Łódź", "lodz", 0},
+		{"utf8mb4_general_ci", "ß", "s", 0},
+		{"utf8mb4_0900_ai_ci", "ß", "ss", 0},
+		{"utf8mb4_0900_ai_ci", "Æble", "aeble", 0},
+		{"utf8mb4_0900_ai_ci", "a", "a  ", -1},
+		{"unknown", "a", "A", 1},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.collation+" "+tt.a+" "+tt.b, func(t *testing.T) {
+			require := require.New(t)
+			require.Equal(tt.expected, CompareStrings(tt.collation, tt.a, tt.b))
+			require.Equal(-tt.expected, CompareStrings(tt.collation, tt.b, tt.a))
+
+			equalKeys := CollationKey(tt.collation, tt.a) == CollationKey(tt.collation, tt.b)
+			require.Equal(tt.expected == 0, equalKeys)
+		})
+	}
+}
+
+func TestCollationCharacterSet(t *testing.T) {
+	require := require.New(t)
+
+	charset, err := CollationCharacterSet("UTF8MB4_0900_AI_CI")
+	require.NoError(err)
+	require.Equal("utf8mb4", charset)
+
+	_, err = CollationCharacterSet("utf8mb4_unknown")
+	require.Error(err)
+
+	collation, err := CharacterSetCollation("latin1")
+	require.NoError(err)
+	require.Equal("latin1_general_ci", collation)
+
+	_, err = CharacterSetCollation("binary")
+	require.Error(err)
+}
+
+func TestType_TextCollation(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(0, Text.Compare("John", "john"))
+
+	bin, err := CreateString(sqltypes.VarChar, 10, "", "utf8_bin")
+	require.NoError(err)
+	require.Equal(-1, bin.Compare("John", "john"))
+}
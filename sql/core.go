@@ -53,7 +53,7 @@ type Node interface {
 	Transformable
 	Schema() Schema
 	Children() []Node
-	RowIter() (RowIter, error)
+	RowIter(*Context) (RowIter, error)
 }
 
 type Table interface {
@@ -61,8 +61,32 @@ type Table interface {
 	Node
 }
 
+// Inserter is a table that accepts new rows. The rows are inserted in the
+// transaction of the session of the context, if there is one.
 type Inserter interface {
-	Insert(row Row) error
+	Insert(ctx *Context, row Row) error
+}
+
+// FilteredTable is a table that can filter its own rows, so rows that don't
+// match the filters can be skipped before they are completely read.
+type FilteredTable interface {
+	Table
+	// HandledFilters returns the filters of the given ones that the table
+	// can apply.
+	HandledFilters(filters []Expression) []Expression
+	// WithFilters returns a copy of the table that only returns the rows
+	// that match all the given filters, which must be handled by the table.
+	WithFilters(filters []Expression) Table
+}
+
+// ProjectedTable is a table that can read only some of its columns. Its
+// schema doesn't change, but the values of the columns that are not read
+// are nil.
+type ProjectedTable interface {
+	Table
+	// WithProjection returns a copy of the table that only reads the
+	// columns with the given indexes.
+	WithProjection(columns []int) Table
 }
 
 type Database interface {
@@ -0,0 +1,331 @@
+// Package decimal implements exact fixed-point decimal numbers, which are
+// the values of the DECIMAL type.
+package decimal
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// ErrDivisionByZero is returned when a decimal is divided by zero.
+var ErrDivisionByZero = errors.New("decimal: division by zero")
+
+// MaxPrecision is the maximum number of digits of the decimals of the
+// DECIMAL type.
+const MaxPrecision = 65
+
+// RoundingMode tells how a decimal is rounded when digits after the
+// decimal point are discarded.
+type RoundingMode byte
+
+const (
+	// HalfUp rounds to the nearest value, and halves away from zero. It is
+	// the rounding MySQL uses for DECIMAL values.
+	HalfUp RoundingMode = iota
+	// HalfEven rounds to the nearest value, and halves to the nearest even
+	// value.
+	HalfEven
+	// Down rounds towards zero, truncating the discarded digits.
+	Down
+	// Up rounds away from zero.
+	Up
+	// Ceiling rounds towards positive infinity.
+	Ceiling
+	// Floor rounds towards negative infinity.
+	Floor
+)
+
+// Decimal is an exact decimal number, with a value of unscaled * 10^-scale.
+// The zero value is 0. Decimals are immutable, so operations return new
+// ones.
+type Decimal struct {
+	// unscaled is nil if the value is zero, so equal decimals with the same
+	// scale are deeply equal.
+	unscaled *big.Int
+	scale    int
+}
+
+var ten = big.NewInt(10)
+
+func newDecimal(unscaled *big.Int, scale int) Decimal {
+	if unscaled.Sign() == 0 {
+		unscaled = nil
+	}
+
+	return Decimal{unscaled: unscaled, scale: scale}
+}
+
+// New returns the decimal unscaled * 10^-scale.
+func New(unscaled int64, scale int) Decimal {
+	return newDecimal(big.NewInt(unscaled), scale)
+}
+
+// NewFromInt returns the decimal with the value of an integer.
+func NewFromInt(n int64) Decimal {
+	return New(n, 0)
+}
+
+// NewFromUint returns the decimal with the value of an unsigned integer.
+func NewFromUint(n uint64) Decimal {
+	return newDecimal(new(big.Int).SetUint64(n), 0)
+}
+
+// NewFromFloat returns the decimal with the shortest representation that
+// is converted back to the given float. Bits is 32 for float32 values and
+// 64 for float64 ones.
+func NewFromFloat(f float64, bits int) (Decimal, error) {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return Decimal{}, fmt.Errorf("decimal: can't convert %v", f)
+	}
+
+	return Parse(strconv.FormatFloat(f, 'f', -1, bits))
+}
+
+// Parse parses a decimal written as an optionally signed number with an
+// optional fractional part and exponent, such as -12.50 or 1.5e3. Numbers
+// with more than MaxPrecision integer digits are out of range, and the ones
+// smaller than 10^-MaxPrecision are zero, so huge exponents don't make it
+// compute huge powers of ten.
+func Parse(s string) (Decimal, error) {
+	str := strings.TrimSpace(s)
+	var exp int
+	if i := strings.IndexAny(str, "eE"); i >= 0 {
+		var err error
+		exp, err = strconv.Atoi(str[i+1:])
+		if err != nil {
+			return Decimal{}, fmt.Errorf("decimal: can't parse %q", s)
+		}
+		str = str[:i]
+	}
+
+	var scale int
+	if i := strings.IndexByte(str, '.'); i >= 0 {
+		scale = len(str) - i - 1
+		str = str[:i] + str[i+1:]
+	}
+
+	digits := strings.TrimLeft(str, "+-")
+	if len(str)-len(digits) > 1 || digits == "" || strings.Trim(digits, "0123456789") != "" {
+		return Decimal{}, fmt.Errorf("decimal: can't parse %q", s)
+	}
+
+	unscaled, _ := new(big.Int).SetString(str, 10)
+
+	// The exponent is compared with the number of integer digits before
+	// it's applied, so it can't overflow.
+	n := len(strings.TrimLeft(digits, "0")) - scale
+	switch {
+	case unscaled.Sign() == 0 && (exp > MaxPrecision || exp < -MaxPrecision):
+		return newDecimal(unscaled, scale), nil
+	case exp > MaxPrecision-n:
+		return Decimal{}, fmt.Errorf("decimal: %q is out of range", s)
+	case exp <= -MaxPrecision-n:
+		return Decimal{}, nil
+	}
+
+	scale -= exp
+	if scale < 0 {
+		unscaled.Mul(unscaled, pow10(-scale))
+		scale = 0
+	}
+
+	return newDecimal(unscaled, scale), nil
+}
+
+// MustParse parses a decimal like Parse does, and panics if it can't.
+func MustParse(s string) Decimal {
+	d, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return d
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
+
+func (d Decimal) int() *big.Int {
+	if d.unscaled == nil {
+		return new(big.Int)
+	}
+
+	return d.unscaled
+}
+
+// Scale returns the number of digits after the decimal point.
+func (d Decimal) Scale() int {
+	return d.scale
+}
+
+// Precision returns the number of digits of the decimal, including the
+// ones after the decimal point, so it's never less than the scale.
+func (d Decimal) Precision() int {
+	n := 1
+	if d.unscaled != nil {
+		n = len(new(big.Int).Abs(d.unscaled).String())
+	}
+
+	if n < d.scale {
+		return d.scale
+	}
+
+	return n
+}
+
+// Sign returns -1 if the decimal is negative, 0 if it's zero and +1 if it's
+// positive.
+func (d Decimal) Sign() int {
+	return d.int().Sign()
+}
+
+// rescale returns the unscaled values of two decimals with the same scale,
+// and the scale.
+func rescale(a, b Decimal) (*big.Int, *big.Int, int) {
+	switch {
+	case a.scale < b.scale:
+		return new(big.Int).Mul(a.int(), pow10(b.scale-a.scale)), b.int(), b.scale
+	case a.scale > b.scale:
+		return a.int(), new(big.Int).Mul(b.int(), pow10(a.scale-b.scale)), a.scale
+	default:
+		return a.int(), b.int(), a.scale
+	}
+}
+
+// Cmp compares two decimals, and returns -1 if d < o, 0 if d == o and +1
+// if d > o.
+func (d Decimal) Cmp(o Decimal) int {
+	a, b, _ := rescale(d, o)
+	return a.Cmp(b)
+}
+
+// Add returns d + o, with the largest scale of both.
+func (d Decimal) Add(o Decimal) Decimal {
+	a, b, scale := rescale(d, o)
+	return newDecimal(new(big.Int).Add(a, b), scale)
+}
+
+// Sub returns d - o, with the largest scale of both.
+func (d Decimal) Sub(o Decimal) Decimal {
+	a, b, scale := rescale(d, o)
+	return newDecimal(new(big.Int).Sub(a, b), scale)
+}
+
+// Mul returns d * o, with the sum of the scales of both.
+func (d Decimal) Mul(o Decimal) Decimal {
+	return newDecimal(new(big.Int).Mul(d.int(), o.int()), d.scale+o.scale)
+}
+
+// Neg returns -d.
+func (d Decimal) Neg() Decimal {
+	return newDecimal(new(big.Int).Neg(d.int()), d.scale)
+}
+
+// Div returns d / o rounded to the given scale with the given mode.
+func (d Decimal) Div(o Decimal, scale int, mode RoundingMode) (Decimal, error) {
+	if o.Sign() == 0 {
+		return Decimal{}, ErrDivisionByZero
+	}
+
+	// d / o = d.unscaled * 10^(o.scale + scale - d.scale) / o.unscaled at
+	// the given scale.
+	num := new(big.Int).Set(d.int())
+	den := new(big.Int).Set(o.int())
+	if exp := o.scale + scale - d.scale; exp >= 0 {
+		num.Mul(num, pow10(exp))
+	} else {
+		den.Mul(den, pow10(-exp))
+	}
+
+	return newDecimal(quo(num, den, mode), scale), nil
+}
+
+// Round returns the decimal with the given scale, rounded with the given
+// mode if digits are discarded.
+func (d Decimal) Round(scale int, mode RoundingMode) Decimal {
+	switch {
+	case scale == d.scale:
+		return d
+	case scale > d.scale:
+		return newDecimal(new(big.Int).Mul(d.int(), pow10(scale-d.scale)), scale)
+	default:
+		return newDecimal(quo(d.int(), pow10(d.scale-scale), mode), scale)
+	}
+}
+
+// quo returns num / den rounded to an integer with the given mode.
+func quo(num, den *big.Int, mode RoundingMode) *big.Int {
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return q
+	}
+
+	// The sign of the exact result, which q can't tell when it's zero.
+	sign := num.Sign() * den.Sign()
+	var away bool
+	switch mode {
+	case Down:
+	case Up:
+		away = true
+	case Ceiling:
+		away = sign > 0
+	case Floor:
+		away = sign < 0
+	default:
+		half := new(big.Int).Abs(r)
+		half.Lsh(half, 1)
+		switch half.Cmp(new(big.Int).Abs(den)) {
+		case 1:
+			away = true
+		case 0:
+			away = mode == HalfUp || q.Bit(0) == 1
+		}
+	}
+
+	if away {
+		q.Add(q, big.NewInt(int64(sign)))
+	}
+
+	return q
+}
+
+// Float64 returns the nearest float64 to the decimal.
+func (d Decimal) Float64() float64 {
+	f, _ := strconv.ParseFloat(d.String(), 64)
+	return f
+}
+
+// String returns the decimal written with all the digits of its scale,
+// such as -12.50.
+func (d Decimal) String() string {
+	if d.scale <= 0 {
+		return d.Round(0, Down).int().String()
+	}
+
+	digits := new(big.Int).Abs(d.int()).String()
+	if len(digits) <= d.scale {
+		digits = strings.Repeat("0", d.scale-len(digits)+1) + digits
+	}
+
+	var buf bytes.Buffer
+	if d.Sign() < 0 {
+		buf.WriteByte('-')
+	}
+	point := len(digits) - d.scale
+	buf.WriteString(digits[:point])
+	buf.WriteByte('.')
+	buf.WriteString(digits[point:])
+	return buf.String()
+}
+
+// MarshalJSON implements the json.Marshaler interface. Decimals are encoded
+// as JSON numbers.
+func (d Decimal) MarshalJSON() ([]byte, error) {
+	return []byte(d.String()), nil
+}
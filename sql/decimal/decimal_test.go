@@ -0,0 +1,144 @@
+package decimal
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+		scale    int
+	}{
+		{"0", "0", 0},
+		{"12.50", "12.50", 2},
+		{"-0.05", "-0.05", 2},
+		{"+3", "3", 0},
+		{" .5 ", "0.5", 1},
+		{"1.5e3", "1500", 0},
+		{"1.25E-2", "0.0125", 4},
+		{"123456789012345678901234567890.123", "123456789012345678901234567890.123", 3},
+		{"0e999999999", "0", 0},
+		{"1e-999999999", "0", 0},
+		{"0.001e-62", "0.00000000000000000000000000000000000000000000000000000000000000001", 65},
+		{"0.001e-63", "0", 0},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.input, func(t *testing.T) {
+			require := require.New(t)
+			d, err := Parse(tt.input)
+			require.NoError(err)
+			require.Equal(tt.expected, d.String())
+			require.Equal(tt.scale, d.Scale())
+		})
+	}
+
+	for _, input := range []string{"", "-", "1.2.3", "--1", "1e", "abc", "1,5", "1e999999999", "10e64", "1e99999999999999999999"} {
+		_, err := Parse(input)
+		require.Error(t, err, input)
+	}
+}
+
+func TestNewFromFloat(t *testing.T) {
+	require := require.New(t)
+
+	d, err := NewFromFloat(0.1, 64)
+	require.NoError(err)
+	require.Equal("0.1", d.String())
+
+	d, err = NewFromFloat(float64(float32(19.99)), 32)
+	require.NoError(err)
+	require.Equal("19.99", d.String())
+
+	_, err = NewFromFloat(1/zero(), 64)
+	require.Error(err)
+}
+
+func zero() float64 { return 0 }
+
+func TestArithmetic(t *testing.T) {
+	require := require.New(t)
+
+	a, b := MustParse("10.25"), MustParse("-0.1")
+	require.Equal("10.15", a.Add(b).String())
+	require.Equal("10.35", a.Sub(b).String())
+	require.Equal("-1.025", a.Mul(b).String())
+	require.Equal("-10.25", a.Neg().String())
+
+	// 0.1 + 0.2 is exactly 0.3, unlike with floats.
+	require.Equal(0, MustParse("0.1").Add(MustParse("0.2")).Cmp(MustParse("0.30")))
+
+	d, err := a.Div(b, 4, HalfUp)
+	require.NoError(err)
+	require.Equal("-102.5000", d.String())
+
+	d, err = NewFromInt(2).Div(NewFromInt(3), 4, HalfUp)
+	require.NoError(err)
+	require.Equal("0.6667", d.String())
+
+	_, err = a.Div(Decimal{}, 2, HalfUp)
+	require.Equal(ErrDivisionByZero, err)
+}
+
+func TestCmp(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(-1, MustParse("-1").Cmp(MustParse("0.5")))
+	require.Equal(0, MustParse("1.50").Cmp(MustParse("1.5")))
+	require.Equal(1, MustParse("2").Cmp(MustParse("1.999")))
+	require.Equal(0, Decimal{}.Cmp(MustParse("0.00")))
+}
+
+func TestRound(t *testing.T) {
+	testCases := []struct {
+		input    string
+		mode     RoundingMode
+		expected string
+	}{
+		{"2.345", HalfUp, "2.35"},
+		{"-2.345", HalfUp, "-2.35"},
+		{"2.345", HalfEven, "2.34"},
+		{"2.355", HalfEven, "2.36"},
+		{"2.349", Down, "2.34"},
+		{"-2.349", Down, "-2.34"},
+		{"2.341", Up, "2.35"},
+		{"-2.341", Up, "-2.35"},
+		{"-2.341", Ceiling, "-2.34"},
+		{"-2.341", Floor, "-2.35"},
+		{"0.004", Ceiling, "0.01"},
+		{"-0.004", Floor, "-0.01"},
+		{"2.3", HalfUp, "2.30"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.input, func(t *testing.T) {
+			d := MustParse(tt.input).Round(2, tt.mode)
+			require.Equal(t, tt.expected, d.String())
+		})
+	}
+}
+
+func TestPrecision(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(1, Decimal{}.Precision())
+	require.Equal(4, MustParse("-12.50").Precision())
+	require.Equal(3, MustParse("0.001").Precision())
+}
+
+func TestEqual(t *testing.T) {
+	require := require.New(t)
+
+	require.Equal(MustParse("0.00"), MustParse("1.25").Sub(MustParse("1.25")))
+	require.Equal(MustParse("3.75"), MustParse("1.25").Add(MustParse("2.50")))
+}
+
+func TestMarshalJSON(t *testing.T) {
+	b, err := json.Marshal([]interface{}{MustParse("-1.50")})
+	require.NoError(t, err)
+	require.Equal(t, "[-1.50]", string(b))
+}
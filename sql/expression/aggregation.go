@@ -4,6 +4,10 @@ import (
 	"fmt"
 
 	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/decimal"
+
+	"github.com/src-d/go-vitess/sqltypes"
+	"github.com/src-d/go-vitess/vt/proto/query"
 )
 
 type Count struct {
@@ -38,6 +42,10 @@ func (c *Count) Name() string {
 	return fmt.Sprintf("count(%s)", c.Child.Name())
 }
 
+func (c *Count) String() string {
+	return fmt.Sprintf("COUNT(%s)", c.Child)
+}
+
 func (c *Count) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
 	nc := c.UnaryExpression.Child.TransformUp(f)
 	return f(NewCount(nc))
@@ -87,6 +95,10 @@ func (e *First) Name() string {
 	return fmt.Sprintf("first(%s)", e.Child.Name())
 }
 
+func (e *First) String() string {
+	return fmt.Sprintf("FIRST(%s)", e.Child)
+}
+
 func (e *First) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
 	nc := e.UnaryExpression.Child.TransformUp(f)
 	return f(NewFirst(nc))
@@ -107,3 +119,195 @@ func (e *First) Merge(buffer, partial sql.Row) {
 func (e *First) Eval(buffer sql.Row) interface{} {
 	return buffer[0]
 }
+
+// Sum is an aggregation that adds the values of its child. As in MySQL, the
+// sum of exact values is an exact DECIMAL, and the sum of other values is a
+// double.
+type Sum struct {
+	UnaryExpression
+}
+
+func NewSum(e sql.Expression) *Sum {
+	return &Sum{UnaryExpression{e}}
+}
+
+func (s *Sum) NewBuffer() sql.Row {
+	return sql.NewRow(nil)
+}
+
+func (s *Sum) Type() sql.Type {
+	return sumType(s.Child.Type())
+}
+
+// IsNullable implements the Expression interface. The sum is NULL if there
+// are no values.
+func (s *Sum) IsNullable() bool {
+	return true
+}
+
+func (s *Sum) Name() string {
+	return fmt.Sprintf("sum(%s)", s.Child.Name())
+}
+
+func (s *Sum) String() string {
+	return fmt.Sprintf("SUM(%s)", s.Child)
+}
+
+func (s *Sum) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	nc := s.UnaryExpression.Child.TransformUp(f)
+	return f(NewSum(nc))
+}
+
+func (s *Sum) Update(buffer, row sql.Row) {
+	buffer[0] = add(s.Type(), buffer[0], s.Child.Eval(row))
+}
+
+func (s *Sum) Merge(buffer, partial sql.Row) {
+	buffer[0] = add(s.Type(), buffer[0], partial[0])
+}
+
+func (s *Sum) Eval(buffer sql.Row) interface{} {
+	return buffer[0]
+}
+
+// Avg is an aggregation that returns the average of the values of its
+// child. As in MySQL, the average of exact values is a DECIMAL with 4 more
+// digits after the decimal point, and the average of other values is a
+// double.
+type Avg struct {
+	UnaryExpression
+}
+
+func NewAvg(e sql.Expression) *Avg {
+	return &Avg{UnaryExpression{e}}
+}
+
+// NewBuffer implements the AggregationExpression interface. The buffer
+// holds the sum of the values and how many there are.
+func (a *Avg) NewBuffer() sql.Row {
+	return sql.NewRow(nil, int64(0))
+}
+
+func (a *Avg) Type() sql.Type {
+	t := a.Child.Type()
+	switch {
+	case sql.IsDecimal(t):
+		d := t.(sql.DecimalType)
+		return sql.Decimal(
+			min(d.Precision()+4, sql.MaxDecimalPrecision),
+			min(d.Scale()+4, sql.MaxDecimalScale),
+		)
+	case sqltypes.IsIntegral(t.Type()):
+		return sql.Decimal(min(integerDigits(t)+4, sql.MaxDecimalPrecision), 4)
+	default:
+		return sql.Float64
+	}
+}
+
+// IsNullable implements the Expression interface. The average is NULL if
+// there are no values.
+func (a *Avg) IsNullable() bool {
+	return true
+}
+
+func (a *Avg) Name() string {
+	return fmt.Sprintf("avg(%s)", a.Child.Name())
+}
+
+func (a *Avg) String() string {
+	return fmt.Sprintf("AVG(%s)", a.Child)
+}
+
+func (a *Avg) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	nc := a.UnaryExpression.Child.TransformUp(f)
+	return f(NewAvg(nc))
+}
+
+func (a *Avg) Update(buffer, row sql.Row) {
+	v := a.Child.Eval(row)
+	if v == nil {
+		return
+	}
+
+	buffer[0] = add(sumType(a.Child.Type()), buffer[0], v)
+	buffer[1] = buffer[1].(int64) + 1
+}
+
+func (a *Avg) Merge(buffer, partial sql.Row) {
+	buffer[0] = add(sumType(a.Child.Type()), buffer[0], partial[0])
+	buffer[1] = buffer[1].(int64) + partial[1].(int64)
+}
+
+func (a *Avg) Eval(buffer sql.Row) interface{} {
+	n := buffer[1].(int64)
+	if buffer[0] == nil || n == 0 {
+		return nil
+	}
+
+	switch sum := buffer[0].(type) {
+	case decimal.Decimal:
+		scale := a.Type().(sql.DecimalType).Scale()
+		avg, _ := sum.Div(decimal.NewFromInt(n), scale, decimal.HalfUp)
+		return avg
+	default:
+		return sum.(float64) / float64(n)
+	}
+}
+
+// sumType returns the type of the sum of values of the given type, which
+// has 22 more digits than the values if they are exact.
+func sumType(t sql.Type) sql.Type {
+	switch {
+	case sql.IsDecimal(t):
+		d := t.(sql.DecimalType)
+		return sql.Decimal(min(d.Precision()+22, sql.MaxDecimalPrecision), d.Scale())
+	case sqltypes.IsIntegral(t.Type()):
+		return sql.Decimal(min(integerDigits(t)+22, sql.MaxDecimalPrecision), 0)
+	default:
+		return sql.Float64
+	}
+}
+
+// integerDigits returns the number of digits of the largest values of an
+// integer type.
+func integerDigits(t sql.Type) int {
+	switch t.Type() {
+	case query.Type_INT32, query.Type_UINT32:
+		return 10
+	case query.Type_INT64:
+		return 19
+	default:
+		return 20
+	}
+}
+
+// add adds a value to a sum of the given type, which is nil if no values
+// have been added yet. NULL values and values that can't be converted to
+// the type of the sum are skipped.
+func add(typ sql.Type, sum, v interface{}) interface{} {
+	if v == nil {
+		return sum
+	}
+
+	v, err := typ.Convert(v)
+	if err != nil {
+		return sum
+	}
+
+	switch sum := sum.(type) {
+	case nil:
+		return v
+	case decimal.Decimal:
+		return sum.Add(v.(decimal.Decimal))
+	default:
+		return sum.(float64) + v.(float64)
+	}
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+
+	return b
+}
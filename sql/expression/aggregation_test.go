@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/decimal"
 
 	"github.com/stretchr/testify/require"
 )
@@ -96,3 +97,92 @@ func TestFirst_Eval(t *testing.T) {
 	c.Merge(b, b2)
 	assert.Equal(int32(1), c.Eval(b))
 }
+
+func TestSum_Decimal(t *testing.T) {
+	require := require.New(t)
+
+	s := NewSum(NewGetField(0, sql.Decimal(10, 2), "price", true))
+	require.Equal("sum(price)", s.Name())
+	require.Equal(sql.Decimal(32, 2), s.Type())
+
+	b := s.NewBuffer()
+	require.Nil(s.Eval(b))
+
+	s.Update(b, sql.NewRow(decimal.MustParse("0.10")))
+	s.Update(b, sql.NewRow(nil))
+	s.Update(b, sql.NewRow(decimal.MustParse("0.20")))
+
+	b2 := s.NewBuffer()
+	s.Update(b2, sql.NewRow(decimal.MustParse("99999999.99")))
+	s.Merge(b, b2)
+	s.Merge(b, s.NewBuffer())
+	require.Equal(decimal.MustParse("100000000.29"), s.Eval(b))
+}
+
+func TestSum_Int(t *testing.T) {
+	require := require.New(t)
+
+	s := NewSum(NewGetField(0, sql.Int64, "n", true))
+	require.Equal(sql.Decimal(41, 0), s.Type())
+
+	b := s.NewBuffer()
+	s.Update(b, sql.NewRow(int64(9223372036854775807)))
+	s.Update(b, sql.NewRow(int64(1)))
+	require.Equal(decimal.MustParse("9223372036854775808"), s.Eval(b))
+}
+
+func TestSum_Float(t *testing.T) {
+	require := require.New(t)
+
+	s := NewSum(NewGetField(0, sql.Float64, "f", true))
+	require.Equal(sql.Float64, s.Type())
+
+	b := s.NewBuffer()
+	s.Update(b, sql.NewRow(1.5))
+	s.Update(b, sql.NewRow(float64(2)))
+	require.Equal(3.5, s.Eval(b))
+}
+
+func TestAvg_Decimal(t *testing.T) {
+	require := require.New(t)
+
+	a := NewAvg(NewGetField(0, sql.Decimal(10, 2), "price", true))
+	require.Equal("avg(price)", a.Name())
+	require.Equal(sql.Decimal(14, 6), a.Type())
+
+	b := a.NewBuffer()
+	require.Nil(a.Eval(b))
+
+	a.Update(b, sql.NewRow(decimal.MustParse("1.00")))
+	a.Update(b, sql.NewRow(nil))
+	a.Update(b, sql.NewRow(decimal.MustParse("1.00")))
+
+	b2 := a.NewBuffer()
+	a.Update(b2, sql.NewRow(decimal.MustParse("2.00")))
+	a.Merge(b, b2)
+	require.Equal(decimal.MustParse("1.333333"), a.Eval(b))
+}
+
+func TestAvg_Int(t *testing.T) {
+	require := require.New(t)
+
+	a := NewAvg(NewGetField(0, sql.Int32, "n", true))
+	require.Equal(sql.Decimal(14, 4), a.Type())
+
+	b := a.NewBuffer()
+	a.Update(b, sql.NewRow(int32(1)))
+	a.Update(b, sql.NewRow(int32(2)))
+	require.Equal(decimal.MustParse("1.5000"), a.Eval(b))
+}
+
+func TestAvg_Float(t *testing.T) {
+	require := require.New(t)
+
+	a := NewAvg(NewGetField(0, sql.Float64, "f", true))
+	require.Equal(sql.Float64, a.Type())
+
+	b := a.NewBuffer()
+	a.Update(b, sql.NewRow(1.0))
+	a.Update(b, sql.NewRow(2.0))
+	require.Equal(1.5, a.Eval(b))
+}
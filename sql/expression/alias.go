@@ -1,6 +1,10 @@
 package expression
 
-import "github.com/src-d/go-mysql-server/sql"
+import (
+	"fmt"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
 
 type Alias struct {
 	UnaryExpression
@@ -23,6 +27,10 @@ func (e *Alias) Name() string {
 	return e.name
 }
 
+func (e *Alias) String() string {
+	return fmt.Sprintf("%s as %s", e.Child, e.name)
+}
+
 func (e *Alias) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
 	c := e.Child.TransformUp(f)
 	n := NewAlias(c, e.name)
@@ -0,0 +1,134 @@
+package expression
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// Bindvar is a placeholder for a value that is given when a prepared query
+// is executed, such as ? or :name. Its type is inferred by the analyzer from
+// the expression it is compared with or the column its value is inserted
+// into. Placeholders whose type can't be inferred take the type of the value
+// bound to them.
+type Bindvar struct {
+	Variable string
+	typ      sql.Type
+	value    interface{}
+	bound    bool
+}
+
+// NewBindvar creates a new placeholder for the bind variable with the given
+// name. Positional placeholders are named v1, v2, ... vN.
+func NewBindvar(variable string) *Bindvar {
+	return &Bindvar{Variable: variable}
+}
+
+// Typed returns whether the type of the placeholder is known.
+func (b *Bindvar) Typed() bool {
+	return b.typ != nil
+}
+
+// Bound returns whether a value has been bound to the placeholder.
+func (b *Bindvar) Bound() bool {
+	return b.bound
+}
+
+// WithType returns a copy of the placeholder with the given type.
+func (b *Bindvar) WithType(typ sql.Type) *Bindvar {
+	n := *b
+	n.typ = typ
+	return &n
+}
+
+// Bind returns a copy of the placeholder with the given value, converted to
+// the type of the placeholder. If the placeholder has no type, it takes the
+// type of the value.
+func (b *Bindvar) Bind(value interface{}) (*Bindvar, error) {
+	n := *b
+	n.bound = true
+	if value == nil {
+		return &n, nil
+	}
+
+	if n.typ == nil {
+		typ, err := valueType(value)
+		if err != nil {
+			return nil, fmt.Errorf("can't bind variable %s: %s", b.Variable, err)
+		}
+
+		n.typ = typ
+	}
+
+	v, err := n.typ.Convert(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for variable %s: %v", b.Variable, value)
+	}
+
+	n.value = v
+	return &n, nil
+}
+
+// valueType returns the SQL type of a Go value.
+func valueType(v interface{}) (sql.Type, error) {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint8, uint16, uint32:
+		return sql.Int64, nil
+	case uint, uint64:
+		return sql.Uint64, nil
+	case float32, float64:
+		return sql.Float64, nil
+	case bool:
+		return sql.Boolean, nil
+	case string:
+		return sql.Text, nil
+	case []byte:
+		return sql.Blob, nil
+	case time.Time:
+		return sql.Timestamp, nil
+	default:
+		return nil, fmt.Errorf("unsupported type %T", v)
+	}
+}
+
+// Resolved implements the Expression interface. A placeholder is always
+// resolved, so queries containing them can be analyzed before their values
+// are known.
+func (b *Bindvar) Resolved() bool {
+	return true
+}
+
+// IsNullable implements the Expression interface.
+func (b *Bindvar) IsNullable() bool {
+	return b.value == nil
+}
+
+// Type implements the Expression interface.
+func (b *Bindvar) Type() sql.Type {
+	if b.typ == nil {
+		return sql.Null
+	}
+
+	return b.typ
+}
+
+// Name implements the Expression interface.
+func (b *Bindvar) Name() string {
+	return ":" + b.Variable
+}
+
+func (b *Bindvar) String() string {
+	return b.Name()
+}
+
+// Eval implements the Expression interface.
+func (b *Bindvar) Eval(sql.Row) interface{} {
+	return b.value
+}
+
+// TransformUp implements the Expression interface.
+func (b *Bindvar) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	n := *b
+	return f(&n)
+}
@@ -0,0 +1,45 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBindvar(t *testing.T) {
+	require := require.New(t)
+
+	b := NewBindvar("v1")
+	require.True(b.Resolved())
+	require.False(b.Typed())
+	require.False(b.Bound())
+	require.Equal(":v1", b.Name())
+	require.Equal(sql.Null, b.Type())
+
+	bb, err := b.Bind("foo")
+	require.NoError(err)
+	require.True(bb.Bound())
+	require.Equal(sql.Text, bb.Type())
+	require.Equal("foo", bb.Eval(nil))
+
+	tb := b.WithType(sql.Int64)
+	require.True(tb.Typed())
+	require.False(b.Typed())
+
+	bb, err = tb.Bind("42")
+	require.NoError(err)
+	require.Equal(int64(42), bb.Eval(nil))
+
+	bb, err = tb.Bind(nil)
+	require.NoError(err)
+	require.True(bb.IsNullable())
+	require.Nil(bb.Eval(nil))
+
+	_, err = tb.Bind("foo")
+	require.Error(err)
+
+	_, err = b.Bind(struct{}{})
+	require.Error(err)
+}
@@ -1,6 +1,10 @@
 package expression
 
-import "github.com/src-d/go-mysql-server/sql"
+import (
+	"fmt"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
 
 type Not struct {
 	UnaryExpression
@@ -22,6 +26,10 @@ func (e Not) Name() string {
 	return "Not(" + e.Child.Name() + ")"
 }
 
+func (e Not) String() string {
+	return fmt.Sprintf("NOT(%s)", e.Child)
+}
+
 func (e *Not) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
 	c := e.UnaryExpression.Child.TransformUp(f)
 	n := &Not{UnaryExpression{c}}
@@ -0,0 +1,275 @@
+package expression
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/decimal"
+	"github.com/src-d/go-vitess/sqltypes"
+)
+
+// cast returns the value converted to the type of the expression, and
+// false if it's not the same value.
+func (e *Convert) cast(v interface{}) (interface{}, bool) {
+	switch t := e.typ.(type) {
+	case sql.DecimalType:
+		return castDecimal(t, v)
+	case sql.StringType:
+		return e.castString(t, v)
+	}
+
+	switch e.typ {
+	case sql.Int64:
+		return castInt64(v)
+	case sql.Uint64:
+		return castUint64(v)
+	case sql.JSON:
+		return e.castJSON(v)
+	}
+
+	converted, err := sql.ConvertIn(e.typ, v, e.loc)
+	if err != nil {
+		return nil, false
+	}
+
+	return converted, true
+}
+
+// text returns a value of the child as MySQL writes it.
+func (e *Convert) text(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case []byte:
+		return string(v)
+	}
+
+	typ := e.Child.Type()
+	if converted, err := typ.Convert(v); err == nil && typ != sql.Null {
+		return typ.SQL(converted).ToString()
+	}
+
+	return fmt.Sprint(v)
+}
+
+func (e *Convert) castString(t sql.StringType, v interface{}) (interface{}, bool) {
+	var s interface{} = e.text(v)
+	if sql.IsBinary(t) {
+		s = []byte(e.text(v))
+	}
+
+	converted, err := t.Convert(s)
+	if err != nil {
+		return nil, false
+	}
+
+	converted, truncated := sql.TruncateString(t, converted)
+	return converted, !truncated
+}
+
+func (e *Convert) castJSON(v interface{}) (interface{}, bool) {
+	switch v.(type) {
+	case string, []byte:
+		if e.Child.Type() != sql.JSON {
+			doc, err := sql.JSON.Convert(e.text(v))
+			if err != nil {
+				return nil, false
+			}
+			return doc, true
+		}
+	}
+
+	doc, err := jsonScalar(e.Child.Type(), v)
+	if err != nil {
+		return nil, false
+	}
+
+	return sql.JSONDocument{Val: doc}, true
+}
+
+var (
+	integerPrefix = regexp.MustCompile(`^\s*[+-]?\d+`)
+	numberPrefix  = regexp.MustCompile(`^\s*[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?`)
+)
+
+// numericPrefix returns the number at the start of a string, as MySQL reads
+// strings as numbers, and whether the rest of the string is only spaces.
+func numericPrefix(re *regexp.Regexp, s string) (string, bool) {
+	prefix := re.FindString(s)
+	rest := strings.TrimSpace(s[len(prefix):])
+	if prefix == "" {
+		return "0", false
+	}
+
+	return strings.TrimSpace(prefix), rest == ""
+}
+
+func castInt64(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case []byte:
+		return castInt64(string(v))
+	case string:
+		prefix, ok := numericPrefix(integerPrefix, v)
+		n, err := strconv.ParseInt(prefix, 10, 64)
+		if err != nil {
+			if strings.HasPrefix(prefix, "-") {
+				return int64(math.MinInt64), false
+			}
+			return int64(math.MaxInt64), false
+		}
+		return n, ok
+	case uint64:
+		// Like in MySQL, unsigned values wrap around.
+		return int64(v), true
+	case uint:
+		return int64(v), true
+	}
+
+	n, err := sql.Int64.Convert(v)
+	if err == nil {
+		return n, true
+	}
+
+	f, err := sql.Float64.Convert(v)
+	if err != nil {
+		return nil, false
+	}
+
+	if f.(float64) < 0 {
+		return int64(math.MinInt64), false
+	}
+
+	return int64(math.MaxInt64), false
+}
+
+func castUint64(v interface{}) (interface{}, bool) {
+	switch v := v.(type) {
+	case []byte:
+		return castUint64(string(v))
+	case string:
+		prefix, ok := numericPrefix(integerPrefix, v)
+		if strings.HasPrefix(prefix, "-") {
+			n, ok := castInt64(v)
+			return uint64(n.(int64)), ok
+		}
+
+		n, err := strconv.ParseUint(strings.TrimPrefix(prefix, "+"), 10, 64)
+		if err != nil {
+			return uint64(math.MaxUint64), false
+		}
+		return n, ok
+	}
+
+	if n, err := sql.Uint64.Convert(v); err == nil {
+		return n, true
+	}
+
+	// Like in MySQL, negative values wrap around.
+	if n, err := sql.Int64.Convert(v); err == nil {
+		return uint64(n.(int64)), true
+	}
+
+	f, err := sql.Float64.Convert(v)
+	if err != nil {
+		return nil, false
+	}
+
+	if f.(float64) < 0 {
+		return uint64(0), false
+	}
+
+	return uint64(math.MaxUint64), false
+}
+
+// castDecimal converts a value to a decimal type. Values out of the range of
+// the type are the largest or smallest value of the type.
+func castDecimal(t sql.DecimalType, v interface{}) (interface{}, bool) {
+	if b, ok := v.([]byte); ok {
+		v = string(b)
+	}
+
+	exact := true
+	if s, ok := v.(string); ok {
+		v, exact = numericPrefix(numberPrefix, s)
+	}
+
+	d, err := t.Convert(v)
+	if err == nil {
+		return d, exact
+	}
+
+	f, ok := castFloat64(v)
+	if !ok {
+		return nil, false
+	}
+
+	max := decimal.MustParse(
+		strings.Repeat("9", t.Precision()-t.Scale()) + "." + strings.Repeat("9", t.Scale()),
+	)
+	if f < 0 {
+		return max.Neg(), false
+	}
+
+	return max, false
+}
+
+// castFloat64 returns a value as a float. Numbers out of the range of
+// floats are infinities.
+func castFloat64(v interface{}) (float64, bool) {
+	if s, ok := v.(string); ok {
+		f, err := strconv.ParseFloat(s, 64)
+		if err, ok := err.(*strconv.NumError); ok && err.Err != strconv.ErrRange {
+			return 0, false
+		}
+		return f, true
+	}
+
+	f, err := sql.Float64.Convert(v)
+	if err != nil {
+		return 0, false
+	}
+
+	return f.(float64), true
+}
+
+// warningTypeName returns the name of the type in warnings.
+func (e *Convert) warningTypeName() string {
+	switch e.typ {
+	case sql.Int64, sql.Uint64:
+		return "INTEGER"
+	default:
+		return castTypeName(e.typ)
+	}
+}
+
+// castTypeName returns the name of a type as it's written in CAST.
+func castTypeName(t sql.Type) string {
+	switch t {
+	case sql.Int64:
+		return "SIGNED"
+	case sql.Uint64:
+		return "UNSIGNED"
+	case sql.LongBlob:
+		return "BINARY"
+	}
+
+	st, ok := t.(sql.StringType)
+	if !ok || sql.IsBinary(st) {
+		return strings.ToUpper(sql.MySQLTypeName(t))
+	}
+
+	name := "CHAR"
+	if st.Type() == sqltypes.VarChar {
+		name = fmt.Sprintf("CHAR(%d)", st.MaxLength())
+	}
+
+	if st.CharacterSet() != sql.DefaultCharacterSet {
+		name += " CHARACTER SET " + st.CharacterSet()
+	}
+
+	return name
+}
@@ -0,0 +1,107 @@
+package expression
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/decimal"
+
+	"github.com/src-d/go-vitess/sqltypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCast(t *testing.T) {
+	utf8mb4, err := sql.CreateString(sqltypes.VarChar, 3, "utf8mb4", "")
+	require.NoError(t, err)
+
+	testCases := []struct {
+		name     string
+		value    interface{}
+		from     sql.Type
+		to       sql.Type
+		expected interface{}
+		warning  string
+	}{
+		{"int", "42", sql.Text, sql.Int64, int64(42), ""},
+		{"int prefix", "12abc", sql.Text, sql.Int64, int64(12), "Truncated incorrect INTEGER value: '12abc'"},
+		{"int no digits", "abc", sql.Text, sql.Int64, int64(0), "Truncated incorrect INTEGER value: 'abc'"},
+		{"int from float", 1.5, sql.Float64, sql.Int64, int64(2), ""},
+		{"int overflow", "99999999999999999999", sql.Text, sql.Int64, int64(math.MaxInt64), "Truncated incorrect INTEGER value: '99999999999999999999'"},
+		{"int from unsigned", uint64(math.MaxUint64), sql.Uint64, sql.Int64, int64(-1), ""},
+		{"unsigned from negative", int64(-1), sql.Int64, sql.Uint64, uint64(math.MaxUint64), ""},
+		{"unsigned", "18446744073709551615", sql.Text, sql.Uint64, uint64(math.MaxUint64), ""},
+		{"char", int64(12345), sql.Int64, sql.Varchar(3), "123", "Truncated incorrect CHAR(3) value: '12345'"},
+		{"char from date", time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC), sql.Date, sql.LongText, "2018-01-02", ""},
+		{"char with charset", "ab", sql.Text, utf8mb4, "ab", ""},
+		{"binary", "ab", sql.Text, sql.Binary(3), []byte{'a', 'b', 0}, ""},
+		{"date", "2018-01-02", sql.Text, sql.Date, time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC), ""},
+		{"invalid date", "2018-13-02", sql.Text, sql.Date, nil, "Truncated incorrect DATE value: '2018-13-02'"},
+		{"decimal", "1.255", sql.Text, sql.Decimal(5, 2), decimal.MustParse("1.26"), ""},
+		{"decimal prefix", "1.5x", sql.Text, sql.Decimal(5, 2), decimal.MustParse("1.50"), "Truncated incorrect DECIMAL(5,2) value: '1.5x'"},
+		{"decimal out of range", -12345.6, sql.Float64, sql.Decimal(5, 2), decimal.MustParse("-999.99"), "Truncated incorrect DECIMAL(5,2) value: '-12345.6'"},
+		{"decimal huge exponent", "1e999999999", sql.Text, sql.Decimal(5, 2), decimal.MustParse("999.99"), "Truncated incorrect DECIMAL(5,2) value: '1e999999999'"},
+		{"decimal tiny exponent", "-1e-999999999", sql.Text, sql.Decimal(5, 2), decimal.MustParse("0.00"), ""},
+		{"json", `{"a": [1, true]}`, sql.Text, sql.JSON, sql.JSONDocument{Val: map[string]interface{}{"a": []interface{}{1.0, true}}}, ""},
+		{"json number", int64(1), sql.Int64, sql.JSON, sql.JSONDocument{Val: 1.0}, ""},
+		{"invalid json", `{"a"`, sql.Text, sql.JSON, nil, `Truncated incorrect JSON value: '{"a"'`},
+		{"null", nil, sql.Text, sql.Int64, nil, ""},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require := require.New(t)
+			session := sql.NewBaseSession()
+
+			e := NewCast(NewLiteral(tt.value, tt.from), tt.to).WithSession(session)
+			require.Equal(tt.to, e.Type())
+			require.Equal(tt.expected, e.Eval(nil))
+
+			if tt.warning == "" {
+				require.Empty(session.Warnings())
+			} else {
+				require.Equal([]sql.Warning{{Code: sql.ErrTruncatedWrongValue, Message: tt.warning}}, session.Warnings())
+			}
+		})
+	}
+}
+
+func TestCast_String(t *testing.T) {
+	require := require.New(t)
+
+	e := NewCast(NewGetField(0, sql.Text, "foo", true), sql.Uint64)
+	require.Equal("CAST(foo AS UNSIGNED)", e.String())
+	require.Equal("cast(foo as unsigned)", e.Name())
+
+	e = NewCast(NewGetField(0, sql.Text, "foo", true), sql.Varchar(10))
+	require.Equal("CAST(foo AS CHAR(10))", e.String())
+}
+
+func TestConvert_Implicit(t *testing.T) {
+	require := require.New(t)
+	session := sql.NewBaseSession()
+
+	e := NewConvert(NewGetField(0, sql.Text, "foo", true), sql.Int64).WithSession(session)
+	require.False(e.Explicit())
+	require.Equal(int64(12), e.Eval(sql.NewRow("12")))
+	require.Nil(e.Eval(sql.NewRow("12abc")))
+	require.Empty(session.Warnings())
+	require.Equal("CONVERT(foo, bigint)", e.String())
+	require.Equal("foo", e.Name())
+}
+
+func TestConvert_TimeZone(t *testing.T) {
+	require := require.New(t)
+	loc := time.FixedZone("+02:00", 2*60*60)
+	expected := time.Date(2020, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	field := NewGetField(0, sql.Text, "foo", true)
+	for _, e := range []*Convert{NewConvert(field, sql.Timestamp), NewCast(field, sql.Timestamp)} {
+		v := e.WithTimeZone(loc).Eval(sql.NewRow("2020-01-01 10:00:00"))
+		require.True(expected.Equal(v.(time.Time)))
+
+		v = e.Eval(sql.NewRow("2020-01-01 10:00:00"))
+		require.True(expected.Add(2 * time.Hour).Equal(v.(time.Time)))
+	}
+}
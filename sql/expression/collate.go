@@ -0,0 +1,82 @@
+package expression
+
+import (
+	"fmt"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-vitess/sqltypes"
+)
+
+// Collate is an expression that gives the value of its child a collation,
+// as the COLLATE clause does. The collation is explicit, so comparisons
+// with other strings use it.
+type Collate struct {
+	UnaryExpression
+	charset   string
+	collation string
+}
+
+// NewCollate creates a new Collate expression with the given collation,
+// which must be one of the supported ones.
+func NewCollate(child sql.Expression, collation string) *Collate {
+	charset, _ := sql.CollationCharacterSet(collation)
+	return &Collate{UnaryExpression{child}, charset, collation}
+}
+
+// Collation returns the collation of the expression.
+func (e *Collate) Collation() string {
+	return e.collation
+}
+
+// Type implements the Expression interface. It's the type of the child with
+// the collation, or LONGTEXT if the child is not a character string.
+func (e *Collate) Type() sql.Type {
+	if st, ok := e.Child.Type().(sql.StringType); ok && sql.IsText(st) {
+		t, err := sql.CreateString(st.Type(), st.MaxLength(), e.charset, e.collation)
+		if err == nil {
+			return t
+		}
+	}
+
+	t, err := sql.CreateString(sqltypes.Text, sql.LongTextLength, e.charset, e.collation)
+	if err != nil {
+		return sql.LongText
+	}
+
+	return t
+}
+
+// IsNullable implements the Expression interface.
+func (e *Collate) IsNullable() bool {
+	return e.Child.IsNullable()
+}
+
+// Eval implements the Expression interface.
+func (e *Collate) Eval(row sql.Row) interface{} {
+	v := e.Child.Eval(row)
+	if v == nil {
+		return nil
+	}
+
+	s, err := e.Type().Convert(v)
+	if err != nil {
+		return nil
+	}
+
+	return s
+}
+
+// Name implements the Expression interface.
+func (e *Collate) Name() string {
+	return e.Child.Name() + " COLLATE " + e.collation
+}
+
+func (e *Collate) String() string {
+	return fmt.Sprintf("%s COLLATE %s", e.Child, e.collation)
+}
+
+// TransformUp implements the Expression interface.
+func (e *Collate) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	c := e.UnaryExpression.Child.TransformUp(f)
+	return f(&Collate{UnaryExpression{c}, e.charset, e.collation})
+}
@@ -0,0 +1,26 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/src-d/go-vitess/sqltypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCollate(t *testing.T) {
+	require := require.New(t)
+
+	e := NewCollate(NewGetField(0, sql.Varchar(10), "name", true), "utf8mb4_bin")
+	typ, err := sql.CreateString(sqltypes.VarChar, 10, "utf8mb4", "utf8mb4_bin")
+	require.NoError(err)
+	require.Equal(typ, e.Type())
+	require.Equal("John", e.Eval(sql.NewRow("John")))
+	require.Nil(e.Eval(sql.NewRow(nil)))
+	require.Equal("name COLLATE utf8mb4_bin", e.String())
+
+	e = NewCollate(NewGetField(0, sql.Int64, "n", false), "utf8_general_ci")
+	require.Equal(sql.LongText, e.Type())
+	require.Equal("12", e.Eval(sql.NewRow(int64(12))))
+}
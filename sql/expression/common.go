@@ -30,6 +30,16 @@ func (p BinaryExpression) IsNullable() bool {
 var defaultFunctions = map[string]interface{}{
 	"count": NewCount,
 	"first": NewFirst,
+	"sum":   NewSum,
+	"avg":   NewAvg,
+
+	"json_extract":  NewJSONExtract,
+	"json_unquote":  NewJSONUnquote,
+	"json_contains": NewJSONContains,
+	"json_length":   NewJSONLength,
+	"json_keys":     NewJSONKeys,
+	"json_object":   NewJSONObject,
+	"json_array":    NewJSONArray,
 }
 
 func RegisterDefaults(c *sql.Catalog) error {
@@ -51,6 +51,10 @@ func (e Equals) Name() string {
 	return e.Left.Name() + "==" + e.Right.Name()
 }
 
+func (e Equals) String() string {
+	return fmt.Sprintf("%s = %s", e.Left, e.Right)
+}
+
 type Regexp struct {
 	Comparison
 }
@@ -94,6 +98,10 @@ func (e Regexp) Name() string {
 	return e.Left.Name() + " REGEXP " + e.Right.Name()
 }
 
+func (e Regexp) String() string {
+	return fmt.Sprintf("%s REGEXP %s", e.Left, e.Right)
+}
+
 type GreaterThan struct {
 	Comparison
 }
@@ -121,6 +129,10 @@ func (c *GreaterThan) TransformUp(f func(sql.Expression) sql.Expression) sql.Exp
 	return f(NewGreaterThan(lc, rc))
 }
 
+func (e GreaterThan) String() string {
+	return fmt.Sprintf("%s > %s", e.Left, e.Right)
+}
+
 type LessThan struct {
 	Comparison
 }
@@ -148,6 +160,10 @@ func (c *LessThan) TransformUp(f func(sql.Expression) sql.Expression) sql.Expres
 	return f(NewLessThan(lc, rc))
 }
 
+func (e LessThan) String() string {
+	return fmt.Sprintf("%s < %s", e.Left, e.Right)
+}
+
 type GreaterThanOrEqual struct {
 	Comparison
 }
@@ -175,6 +191,10 @@ func (c *GreaterThanOrEqual) TransformUp(f func(sql.Expression) sql.Expression)
 	return f(NewGreaterThanOrEqual(lc, rc))
 }
 
+func (e GreaterThanOrEqual) String() string {
+	return fmt.Sprintf("%s >= %s", e.Left, e.Right)
+}
+
 type LessThanOrEqual struct {
 	Comparison
 }
@@ -202,6 +222,10 @@ func (c *LessThanOrEqual) TransformUp(f func(sql.Expression) sql.Expression) sql
 	return f(NewLessThanOrEqual(lc, rc))
 }
 
+func (e LessThanOrEqual) String() string {
+	return fmt.Sprintf("%s <= %s", e.Left, e.Right)
+}
+
 func checkEqualTypes(a sql.Expression, b sql.Expression) {
 	if a.Resolved() && b.Resolved() && a.Type() != b.Type() {
 		panic(fmt.Errorf("both types should be equal: %v and %v\n", a, b))
@@ -0,0 +1,132 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// Convert is an expression that converts the value of its child to a type.
+// Implicit conversions, such as the ones of the operands of comparisons,
+// are NULL if the value can't be converted. Explicit conversions, made by
+// CAST and CONVERT, record a warning in the session when the value changes
+// instead: values take the nearest value of the type, such as 12 for
+// '12abc' cast as SIGNED, and values that can't be converted at all are
+// NULL.
+type Convert struct {
+	UnaryExpression
+	typ      sql.Type
+	explicit bool
+	// session records the warnings of explicit conversions. It's set by the
+	// analyzer, and there are no warnings until then.
+	session sql.Session
+	// loc is the time zone in which strings and numbers are read as
+	// TIMESTAMP values. It's set by the analyzer, and it's UTC until then.
+	loc *time.Location
+}
+
+// NewConvert creates a new Convert expression for an implicit conversion.
+func NewConvert(child sql.Expression, typ sql.Type) *Convert {
+	return &Convert{UnaryExpression: UnaryExpression{child}, typ: typ, loc: time.UTC}
+}
+
+// NewCast creates a new Convert expression for an explicit conversion, as
+// CAST and CONVERT make.
+func NewCast(child sql.Expression, typ sql.Type) *Convert {
+	return &Convert{
+		UnaryExpression: UnaryExpression{child},
+		typ:             typ,
+		explicit:        true,
+		loc:             time.UTC,
+	}
+}
+
+// Explicit returns whether the conversion is explicit.
+func (e *Convert) Explicit() bool {
+	return e.explicit
+}
+
+// WithSession returns the expression recording its warnings in the given
+// session.
+func (e *Convert) WithSession(s sql.Session) *Convert {
+	return &Convert{e.UnaryExpression, e.typ, e.explicit, s, e.loc}
+}
+
+// WithTimeZone returns the expression reading strings and numbers as
+// TIMESTAMP values in the given time zone.
+func (e *Convert) WithTimeZone(loc *time.Location) *Convert {
+	return &Convert{e.UnaryExpression, e.typ, e.explicit, e.session, loc}
+}
+
+// Session returns the session the warnings are recorded in, or nil if it
+// has not been set.
+func (e *Convert) Session() sql.Session {
+	return e.session
+}
+
+// Type implements the Expression interface.
+func (e *Convert) Type() sql.Type {
+	return e.typ
+}
+
+// IsNullable implements the Expression interface.
+func (e *Convert) IsNullable() bool {
+	return true
+}
+
+// Eval implements the Expression interface.
+func (e *Convert) Eval(row sql.Row) interface{} {
+	v := e.Child.Eval(row)
+	if v == nil {
+		return nil
+	}
+
+	if !e.explicit {
+		converted, err := sql.ConvertIn(e.typ, v, e.loc)
+		if err != nil {
+			return nil
+		}
+
+		return converted
+	}
+
+	converted, ok := e.cast(v)
+	if !ok && e.session != nil {
+		e.session.Warn(sql.Warning{
+			Code: sql.ErrTruncatedWrongValue,
+			Message: fmt.Sprintf(
+				"Truncated incorrect %s value: '%s'",
+				e.warningTypeName(), e.text(v),
+			),
+		})
+	}
+
+	return converted
+}
+
+// Name implements the Expression interface. The name of an implicit
+// conversion is the name of the child, so converting a column doesn't
+// rename it.
+func (e *Convert) Name() string {
+	if !e.explicit {
+		return e.Child.Name()
+	}
+
+	return fmt.Sprintf("cast(%s as %s)", e.Child.Name(), strings.ToLower(castTypeName(e.typ)))
+}
+
+func (e *Convert) String() string {
+	if !e.explicit {
+		return fmt.Sprintf("CONVERT(%s, %s)", e.Child, sql.MySQLTypeName(e.typ))
+	}
+
+	return fmt.Sprintf("CAST(%s AS %s)", e.Child, castTypeName(e.typ))
+}
+
+// TransformUp implements the Expression interface.
+func (e *Convert) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	c := e.UnaryExpression.Child.TransformUp(f)
+	return f(&Convert{UnaryExpression{c}, e.typ, e.explicit, e.session, e.loc})
+}
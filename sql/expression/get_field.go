@@ -18,6 +18,11 @@ func NewGetField(index int, fieldType sql.Type, fieldName string, nullable bool)
 	}
 }
 
+// Index returns the index of the field in the row.
+func (p GetField) Index() int {
+	return p.fieldIndex
+}
+
 func (p GetField) Resolved() bool {
 	return true
 }
@@ -38,6 +43,10 @@ func (p GetField) Name() string {
 	return p.fieldName
 }
 
+func (p GetField) String() string {
+	return p.fieldName
+}
+
 func (p *GetField) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
 	n := *p
 	return f(&n)
@@ -1,6 +1,10 @@
 package expression
 
-import "github.com/src-d/go-mysql-server/sql"
+import (
+	"fmt"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
 
 type IsNull struct {
 	UnaryExpression
@@ -26,6 +30,10 @@ func (e *IsNull) Name() string {
 	return "IsNull(" + e.Child.Name() + ")"
 }
 
+func (e *IsNull) String() string {
+	return fmt.Sprintf("%s IS NULL", e.Child)
+}
+
 func (e *IsNull) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
 	c := e.UnaryExpression.Child.TransformUp(f)
 	n := &IsNull{UnaryExpression{c}}
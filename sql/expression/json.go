@@ -0,0 +1,675 @@
+package expression
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// jsonFunction has the arguments of a JSON function and implements the
+// methods of the Expression interface that only depend on them.
+type jsonFunction struct {
+	name string
+	args []sql.Expression
+}
+
+// Resolved implements the Expression interface.
+func (f jsonFunction) Resolved() bool {
+	for _, a := range f.args {
+		if !a.Resolved() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// IsNullable implements the Expression interface.
+func (f jsonFunction) IsNullable() bool {
+	return true
+}
+
+// Name implements the Expression interface.
+func (f jsonFunction) Name() string {
+	names := make([]string, len(f.args))
+	for i, a := range f.args {
+		names[i] = a.Name()
+	}
+
+	return fmt.Sprintf("%s(%s)", f.name, strings.Join(names, ", "))
+}
+
+func (f jsonFunction) String() string {
+	args := make([]string, len(f.args))
+	for i, a := range f.args {
+		args[i] = fmt.Sprint(a)
+	}
+
+	return fmt.Sprintf("%s(%s)", strings.ToUpper(f.name), strings.Join(args, ", "))
+}
+
+func (f jsonFunction) transformArgs(fn func(sql.Expression) sql.Expression) []sql.Expression {
+	args := make([]sql.Expression, len(f.args))
+	for i, a := range f.args {
+		args[i] = a.TransformUp(fn)
+	}
+
+	return args
+}
+
+// JSONPathFunction is a JSON function that takes JSON paths as arguments.
+type JSONPathFunction interface {
+	sql.Expression
+	// Paths returns the arguments of the function that are JSON paths.
+	Paths() []sql.Expression
+}
+
+// JSONDocumentFunction is a JSON function that takes JSON documents as
+// arguments.
+type JSONDocumentFunction interface {
+	sql.Expression
+	// Documents returns the arguments of the function that are JSON
+	// documents. They are its first arguments.
+	Documents() []sql.Expression
+}
+
+// jsonArgument returns the JSON document of the value of an argument, and
+// false if it's NULL or not a valid document. Like in MySQL, strings are
+// read as JSON text. Documents that are literals are validated when the plan
+// is analyzed, so only the documents computed from other values are NULL
+// when they are not valid.
+func jsonArgument(e sql.Expression, row sql.Row) (interface{}, bool) {
+	v := e.Eval(row)
+	if v == nil {
+		return nil, false
+	}
+
+	doc, err := sql.JSON.Convert(v)
+	if err != nil {
+		return nil, false
+	}
+
+	return doc.(sql.JSONDocument).Val, true
+}
+
+// jsonPathArgument returns the JSON path in the value of an argument, and
+// false if it's NULL or not a valid path. Paths that are literals are
+// validated when the plan is analyzed, so only the paths computed from
+// other values are NULL when they are not valid.
+func jsonPathArgument(e sql.Expression, row sql.Row) (sql.JSONPath, bool) {
+	v := e.Eval(row)
+	if v == nil {
+		return sql.JSONPath{}, false
+	}
+
+	s, err := sql.LongText.Convert(v)
+	if err != nil {
+		return sql.JSONPath{}, false
+	}
+
+	p, err := sql.ParseJSONPath(s.(string))
+	return p, err == nil
+}
+
+// jsonScalar returns the JSON value of a value of the given type. Unlike
+// converting them to the JSON type, strings are JSON strings, and values
+// written as strings in SQL, such as times and ENUM members, are too.
+func jsonScalar(t sql.Type, v interface{}) (interface{}, error) {
+	if t == sql.JSON {
+		return jsonDocumentValue(v)
+	}
+
+	switch t.(type) {
+	case sql.EnumType, sql.SetType:
+		return t.SQL(v).ToString(), nil
+	}
+
+	switch v := v.(type) {
+	case string:
+		return v, nil
+	case []byte:
+		return string(v), nil
+	case time.Time, time.Duration:
+		return t.SQL(v).ToString(), nil
+	default:
+		return jsonDocumentValue(v)
+	}
+}
+
+// jsonDocumentValue returns the document of a value converted to JSON.
+func jsonDocumentValue(v interface{}) (interface{}, error) {
+	doc, err := sql.JSON.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return doc.(sql.JSONDocument).Val, nil
+}
+
+// JSONScalar is an expression that converts the value of its child to a
+// JSON value, as MySQL does when JSON values are compared with values of
+// other types. Unlike Convert to JSON, strings are JSON strings instead of
+// JSON text. Values that can't be converted are NULL.
+type JSONScalar struct {
+	UnaryExpression
+}
+
+// NewJSONScalar creates a new JSONScalar expression.
+func NewJSONScalar(child sql.Expression) *JSONScalar {
+	return &JSONScalar{UnaryExpression{child}}
+}
+
+// Type implements the Expression interface.
+func (e *JSONScalar) Type() sql.Type {
+	return sql.JSON
+}
+
+// IsNullable implements the Expression interface.
+func (e *JSONScalar) IsNullable() bool {
+	return true
+}
+
+// Eval implements the Expression interface.
+func (e *JSONScalar) Eval(row sql.Row) interface{} {
+	v := e.Child.Eval(row)
+	if v == nil {
+		return nil
+	}
+
+	doc, err := jsonScalar(e.Child.Type(), v)
+	if err != nil {
+		return nil
+	}
+
+	return sql.JSONDocument{Val: doc}
+}
+
+// Name implements the Expression interface. It's the name of the child, so
+// converting a column doesn't rename it.
+func (e *JSONScalar) Name() string {
+	return e.Child.Name()
+}
+
+func (e *JSONScalar) String() string {
+	return fmt.Sprintf("CONVERT(%s, json)", e.Child)
+}
+
+// TransformUp implements the Expression interface.
+func (e *JSONScalar) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	c := e.UnaryExpression.Child.TransformUp(f)
+	return f(NewJSONScalar(c))
+}
+
+// JSONExtract is the JSON_EXTRACT function, which returns the values of a
+// JSON document matched by paths. With a single path that has no wildcards
+// it returns the matched value, and otherwise an array with all of them.
+// It's NULL if no value is matched, and the JSON null if the matched value
+// is null.
+type JSONExtract struct {
+	jsonFunction
+}
+
+// NewJSONExtract creates a new JSONExtract expression.
+func NewJSONExtract(doc, path sql.Expression, paths ...sql.Expression) *JSONExtract {
+	args := append([]sql.Expression{doc, path}, paths...)
+	return &JSONExtract{jsonFunction{"json_extract", args}}
+}
+
+// Type implements the Expression interface.
+func (e *JSONExtract) Type() sql.Type {
+	return sql.JSON
+}
+
+// Eval implements the Expression interface.
+func (e *JSONExtract) Eval(row sql.Row) interface{} {
+	doc, ok := jsonArgument(e.args[0], row)
+	if !ok {
+		return nil
+	}
+
+	var values []interface{}
+	wrap := len(e.args) > 2
+	for _, a := range e.args[1:] {
+		p, ok := jsonPathArgument(a, row)
+		if !ok {
+			return nil
+		}
+
+		values = append(values, p.Extract(doc)...)
+		wrap = wrap || p.HasWildcard()
+	}
+
+	switch {
+	case len(values) == 0:
+		return nil
+	case wrap:
+		return sql.JSONDocument{Val: values}
+	default:
+		return sql.JSONDocument{Val: values[0]}
+	}
+}
+
+// Paths implements the JSONPathFunction interface.
+func (e *JSONExtract) Paths() []sql.Expression {
+	return e.args[1:]
+}
+
+// Documents implements the JSONDocumentFunction interface.
+func (e *JSONExtract) Documents() []sql.Expression {
+	return e.args[:1]
+}
+
+// TransformUp implements the Expression interface.
+func (e *JSONExtract) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	args := e.transformArgs(f)
+	return f(NewJSONExtract(args[0], args[1], args[2:]...))
+}
+
+// JSONUnquote is the JSON_UNQUOTE function, which returns a JSON value as
+// text. JSON strings are their unquoted value, and other values are their
+// JSON text. Strings that are not values of the JSON type are unquoted if
+// they are JSON strings, and otherwise they are returned as they are.
+type JSONUnquote struct {
+	jsonFunction
+}
+
+// NewJSONUnquote creates a new JSONUnquote expression.
+func NewJSONUnquote(json sql.Expression) *JSONUnquote {
+	return &JSONUnquote{jsonFunction{"json_unquote", []sql.Expression{json}}}
+}
+
+// Type implements the Expression interface.
+func (e *JSONUnquote) Type() sql.Type {
+	return sql.LongText
+}
+
+// Eval implements the Expression interface.
+func (e *JSONUnquote) Eval(row sql.Row) interface{} {
+	arg := e.args[0]
+	v := arg.Eval(row)
+	if v == nil {
+		return nil
+	}
+
+	if arg.Type() != sql.JSON {
+		s, err := sql.LongText.Convert(v)
+		if err != nil {
+			return nil
+		}
+
+		doc, err := sql.JSON.Convert(s)
+		if err == nil {
+			if u, ok := doc.(sql.JSONDocument).Val.(string); ok {
+				return u
+			}
+		}
+		return s
+	}
+
+	doc, err := sql.JSON.Convert(v)
+	if err != nil {
+		return nil
+	}
+
+	if s, ok := doc.(sql.JSONDocument).Val.(string); ok {
+		return s
+	}
+
+	return sql.JSON.SQL(doc).ToString()
+}
+
+// TransformUp implements the Expression interface.
+func (e *JSONUnquote) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	return f(NewJSONUnquote(e.transformArgs(f)[0]))
+}
+
+// JSONContains is the JSON_CONTAINS function, which returns whether a JSON
+// document, or its value at an optional path, contains another document.
+// As in MySQL, a scalar contains an equal scalar, an array contains the
+// values contained by any of its elements and the arrays of them, and an
+// object contains the objects whose members are contained by its own.
+type JSONContains struct {
+	jsonFunction
+}
+
+// NewJSONContains creates a new JSONContains expression. Only the first of
+// the optional paths is used.
+func NewJSONContains(target, candidate sql.Expression, path ...sql.Expression) *JSONContains {
+	args := []sql.Expression{target, candidate}
+	if len(path) > 0 {
+		args = append(args, path[0])
+	}
+
+	return &JSONContains{jsonFunction{"json_contains", args}}
+}
+
+// Type implements the Expression interface.
+func (e *JSONContains) Type() sql.Type {
+	return sql.Boolean
+}
+
+// Eval implements the Expression interface.
+func (e *JSONContains) Eval(row sql.Row) interface{} {
+	target, ok := jsonArgument(e.args[0], row)
+	if !ok {
+		return nil
+	}
+
+	candidate, ok := jsonArgument(e.args[1], row)
+	if !ok {
+		return nil
+	}
+
+	if len(e.args) > 2 {
+		p, ok := jsonPathArgument(e.args[2], row)
+		if !ok || p.HasWildcard() {
+			return nil
+		}
+
+		values := p.Extract(target)
+		if len(values) == 0 {
+			return nil
+		}
+		target = values[0]
+	}
+
+	return jsonContains(target, candidate)
+}
+
+func jsonContains(target, candidate interface{}) bool {
+	switch t := target.(type) {
+	case []interface{}:
+		if c, ok := candidate.([]interface{}); ok {
+			for _, v := range c {
+				if !jsonContains(t, v) {
+					return false
+				}
+			}
+			return true
+		}
+
+		for _, v := range t {
+			if jsonContains(v, candidate) {
+				return true
+			}
+		}
+		return false
+	case map[string]interface{}:
+		c, ok := candidate.(map[string]interface{})
+		if !ok {
+			return false
+		}
+
+		for k, v := range c {
+			if tv, ok := t[k]; !ok || !jsonContains(tv, v) {
+				return false
+			}
+		}
+		return true
+	default:
+		return sql.JSON.Compare(sql.JSONDocument{Val: target}, sql.JSONDocument{Val: candidate}) == 0
+	}
+}
+
+// Paths implements the JSONPathFunction interface.
+func (e *JSONContains) Paths() []sql.Expression {
+	return e.args[2:]
+}
+
+// Documents implements the JSONDocumentFunction interface.
+func (e *JSONContains) Documents() []sql.Expression {
+	return e.args[:2]
+}
+
+// TransformUp implements the Expression interface.
+func (e *JSONContains) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	args := e.transformArgs(f)
+	return f(NewJSONContains(args[0], args[1], args[2:]...))
+}
+
+// JSONLength is the JSON_LENGTH function, which returns the length of a
+// JSON document, or of its value at an optional path. The length of an
+// array is its number of elements, the length of an object its number of
+// members, and the length of a scalar is 1.
+type JSONLength struct {
+	jsonFunction
+}
+
+// NewJSONLength creates a new JSONLength expression. Only the first of the
+// optional paths is used.
+func NewJSONLength(doc sql.Expression, path ...sql.Expression) *JSONLength {
+	return &JSONLength{jsonFunction{"json_length", jsonPathArgs(doc, path)}}
+}
+
+// Type implements the Expression interface.
+func (e *JSONLength) Type() sql.Type {
+	return sql.Int64
+}
+
+// Eval implements the Expression interface.
+func (e *JSONLength) Eval(row sql.Row) interface{} {
+	v, ok := jsonValueAt(e.args, row)
+	if !ok {
+		return nil
+	}
+
+	switch v := v.(type) {
+	case []interface{}:
+		return int64(len(v))
+	case map[string]interface{}:
+		return int64(len(v))
+	default:
+		return int64(1)
+	}
+}
+
+// Paths implements the JSONPathFunction interface.
+func (e *JSONLength) Paths() []sql.Expression {
+	return e.args[1:]
+}
+
+// Documents implements the JSONDocumentFunction interface.
+func (e *JSONLength) Documents() []sql.Expression {
+	return e.args[:1]
+}
+
+// TransformUp implements the Expression interface.
+func (e *JSONLength) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	args := e.transformArgs(f)
+	return f(NewJSONLength(args[0], args[1:]...))
+}
+
+// JSONKeys is the JSON_KEYS function, which returns an array with the keys
+// of a JSON object, or of the object at an optional path of a document. It's
+// NULL if the value is not an object.
+type JSONKeys struct {
+	jsonFunction
+}
+
+// NewJSONKeys creates a new JSONKeys expression. Only the first of the
+// optional paths is used.
+func NewJSONKeys(doc sql.Expression, path ...sql.Expression) *JSONKeys {
+	return &JSONKeys{jsonFunction{"json_keys", jsonPathArgs(doc, path)}}
+}
+
+// Type implements the Expression interface.
+func (e *JSONKeys) Type() sql.Type {
+	return sql.JSON
+}
+
+// Eval implements the Expression interface.
+func (e *JSONKeys) Eval(row sql.Row) interface{} {
+	v, ok := jsonValueAt(e.args, row)
+	if !ok {
+		return nil
+	}
+
+	o, ok := v.(map[string]interface{})
+	if !ok {
+		return nil
+	}
+
+	keys := []interface{}{}
+	for _, k := range sql.JSONKeys(o) {
+		keys = append(keys, k)
+	}
+
+	return sql.JSONDocument{Val: keys}
+}
+
+// Paths implements the JSONPathFunction interface.
+func (e *JSONKeys) Paths() []sql.Expression {
+	return e.args[1:]
+}
+
+// Documents implements the JSONDocumentFunction interface.
+func (e *JSONKeys) Documents() []sql.Expression {
+	return e.args[:1]
+}
+
+// TransformUp implements the Expression interface.
+func (e *JSONKeys) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	args := e.transformArgs(f)
+	return f(NewJSONKeys(args[0], args[1:]...))
+}
+
+// jsonPathArgs returns the arguments of a function that takes a document
+// and an optional path.
+func jsonPathArgs(doc sql.Expression, path []sql.Expression) []sql.Expression {
+	if len(path) > 0 {
+		return []sql.Expression{doc, path[0]}
+	}
+
+	return []sql.Expression{doc}
+}
+
+// jsonValueAt returns the value of the document in the first argument at
+// the path in the second one, or the whole document if there is no path. It
+// returns false if there is no such value.
+func jsonValueAt(args []sql.Expression, row sql.Row) (interface{}, bool) {
+	doc, ok := jsonArgument(args[0], row)
+	if !ok || len(args) < 2 {
+		return doc, ok
+	}
+
+	p, ok := jsonPathArgument(args[1], row)
+	if !ok || p.HasWildcard() {
+		return nil, false
+	}
+
+	values := p.Extract(doc)
+	if len(values) == 0 {
+		return nil, false
+	}
+
+	return values[0], true
+}
+
+// JSONObject is the JSON_OBJECT function, which returns a JSON object with
+// the given pairs of keys and values. An odd number of arguments and keys
+// that are NULL literals are errors when the plan is analyzed, and it's NULL
+// if a key computed from other values is NULL.
+type JSONObject struct {
+	jsonFunction
+}
+
+// NewJSONObject creates a new JSONObject expression.
+func NewJSONObject(args ...sql.Expression) *JSONObject {
+	return &JSONObject{jsonFunction{"json_object", args}}
+}
+
+// Type implements the Expression interface.
+func (e *JSONObject) Type() sql.Type {
+	return sql.JSON
+}
+
+// Eval implements the Expression interface.
+func (e *JSONObject) Eval(row sql.Row) interface{} {
+	if len(e.args)%2 != 0 {
+		return nil
+	}
+
+	o := map[string]interface{}{}
+	for i := 0; i < len(e.args); i += 2 {
+		k := e.args[i].Eval(row)
+		if k == nil {
+			return nil
+		}
+
+		key, err := sql.LongText.Convert(k)
+		if err != nil {
+			return nil
+		}
+
+		v, ok := jsonValue(e.args[i+1], row)
+		if !ok {
+			return nil
+		}
+
+		o[key.(string)] = v
+	}
+
+	return sql.JSONDocument{Val: o}
+}
+
+// Args returns the arguments of the function, which are pairs of keys and
+// values.
+func (e *JSONObject) Args() []sql.Expression {
+	return e.args
+}
+
+// TransformUp implements the Expression interface.
+func (e *JSONObject) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	return f(NewJSONObject(e.transformArgs(f)...))
+}
+
+// JSONArray is the JSON_ARRAY function, which returns a JSON array with the
+// given values.
+type JSONArray struct {
+	jsonFunction
+}
+
+// NewJSONArray creates a new JSONArray expression.
+func NewJSONArray(args ...sql.Expression) *JSONArray {
+	return &JSONArray{jsonFunction{"json_array", args}}
+}
+
+// Type implements the Expression interface.
+func (e *JSONArray) Type() sql.Type {
+	return sql.JSON
+}
+
+// Eval implements the Expression interface.
+func (e *JSONArray) Eval(row sql.Row) interface{} {
+	a := []interface{}{}
+	for _, arg := range e.args {
+		v, ok := jsonValue(arg, row)
+		if !ok {
+			return nil
+		}
+
+		a = append(a, v)
+	}
+
+	return sql.JSONDocument{Val: a}
+}
+
+// TransformUp implements the Expression interface.
+func (e *JSONArray) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	return f(NewJSONArray(e.transformArgs(f)...))
+}
+
+// jsonValue returns the value of an argument as a value of a JSON document.
+// NULL values are JSON nulls. It returns false if the value can't be
+// converted.
+func jsonValue(e sql.Expression, row sql.Row) (interface{}, bool) {
+	v := e.Eval(row)
+	if v == nil {
+		return nil, true
+	}
+
+	doc, err := jsonScalar(e.Type(), v)
+	return doc, err == nil
+}
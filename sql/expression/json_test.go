@@ -0,0 +1,163 @@
+package expression
+
+import (
+	"testing"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONExtract(t *testing.T) {
+	doc := NewGetField(0, sql.JSON, "doc", true)
+	text := NewGetField(1, sql.Text, "text", true)
+	row := sql.NewRow(
+		map[string]interface{}{"a": []interface{}{1.0, "b"}, "c": "d", "e": nil},
+		`{"a": [2, 3]}`,
+	)
+
+	path := func(p string) sql.Expression {
+		return NewLiteral(p, sql.Text)
+	}
+
+	testCases := []struct {
+		name     string
+		e        sql.Expression
+		expected interface{}
+	}{
+		{"value", NewJSONExtract(doc, path("$.c")), sql.JSONDocument{Val: "d"}},
+		{"array", NewJSONExtract(doc, path("$.a")), sql.JSONDocument{Val: []interface{}{1.0, "b"}}},
+		{"element", NewJSONExtract(doc, path("$.a[1]")), sql.JSONDocument{Val: "b"}},
+		{"null", NewJSONExtract(doc, path("$.e")), sql.JSONDocument{}},
+		{"wildcard", NewJSONExtract(doc, path("$.a[*]")), sql.JSONDocument{Val: []interface{}{1.0, "b"}}},
+		{"paths", NewJSONExtract(doc, path("$.c"), path("$.a[0]")), sql.JSONDocument{Val: []interface{}{"d", 1.0}}},
+		{"missing", NewJSONExtract(doc, path("$.f")), nil},
+		{"invalid path", NewJSONExtract(doc, path("a")), nil},
+		{"text", NewJSONExtract(text, path("$.a[last]")), sql.JSONDocument{Val: 3.0}},
+		{"unquote", NewJSONUnquote(NewJSONExtract(doc, path("$.c"))), "d"},
+		{"unquote array", NewJSONUnquote(NewJSONExtract(doc, path("$.a"))), `[1,"b"]`},
+		{"unquote null", NewJSONUnquote(NewJSONExtract(doc, path("$.e"))), "null"},
+		{"unquote text", NewJSONUnquote(NewLiteral(`"a\tb"`, sql.Text)), "a\tb"},
+		{"unquote not quoted", NewJSONUnquote(NewLiteral(`[1]`, sql.Text)), "[1]"},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.expected, tt.e.Eval(row))
+		})
+	}
+
+	e := NewJSONExtract(doc, path("$.c"))
+	require.Equal(t, sql.JSON, e.Type())
+	require.Equal(t, `JSON_EXTRACT(doc, "$.c")`, e.String())
+	require.Nil(t, e.Eval(sql.NewRow(nil, nil)))
+}
+
+func TestJSONContains(t *testing.T) {
+	doc := NewGetField(0, sql.JSON, "doc", true)
+	row := sql.NewRow(map[string]interface{}{
+		"a": []interface{}{1.0, "b", map[string]interface{}{"c": 2.0}},
+		"d": "e",
+	})
+
+	testCases := []struct {
+		candidate string
+		path      string
+		expected  interface{}
+	}{
+		{`{"d": "e"}`, "", true},
+		{`{"d": "f"}`, "", false},
+		{`"e"`, "", false},
+		{`"e"`, "$.d", true},
+		{`1`, "$.a", true},
+		{`[1, "b"]`, "$.a", true},
+		{`[1, "c"]`, "$.a", false},
+		{`{"c": 2}`, "$.a", true},
+		{`1`, "$.f", nil},
+		{`{"a"`, "", nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.candidate+tt.path, func(t *testing.T) {
+			var path []sql.Expression
+			if tt.path != "" {
+				path = append(path, NewLiteral(tt.path, sql.Text))
+			}
+
+			e := NewJSONContains(doc, NewLiteral(tt.candidate, sql.Text), path...)
+			require.Equal(t, tt.expected, e.Eval(row))
+		})
+	}
+}
+
+func TestJSONLengthAndKeys(t *testing.T) {
+	require := require.New(t)
+
+	doc := NewGetField(0, sql.JSON, "doc", true)
+	row := sql.NewRow(map[string]interface{}{
+		"bb": []interface{}{1.0, 2.0},
+		"a":  map[string]interface{}{},
+		"c":  "d",
+	})
+	path := func(p string) sql.Expression {
+		return NewLiteral(p, sql.Text)
+	}
+
+	require.Equal(int64(3), NewJSONLength(doc).Eval(row))
+	require.Equal(int64(2), NewJSONLength(doc, path("$.bb")).Eval(row))
+	require.Equal(int64(0), NewJSONLength(doc, path("$.a")).Eval(row))
+	require.Equal(int64(1), NewJSONLength(doc, path("$.c")).Eval(row))
+	require.Nil(NewJSONLength(doc, path("$.e")).Eval(row))
+	require.Equal(sql.Int64, NewJSONLength(doc).Type())
+
+	require.Equal(sql.JSONDocument{Val: []interface{}{"a", "c", "bb"}}, NewJSONKeys(doc).Eval(row))
+	require.Equal(sql.JSONDocument{Val: []interface{}{}}, NewJSONKeys(doc, path("$.a")).Eval(row))
+	require.Nil(NewJSONKeys(doc, path("$.bb")).Eval(row))
+}
+
+func TestJSONObjectAndArray(t *testing.T) {
+	require := require.New(t)
+
+	date := time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)
+	row := sql.NewRow("John", int64(30), nil, []interface{}{"555"}, date)
+	name := NewGetField(0, sql.Text, "name", true)
+	age := NewGetField(1, sql.Int64, "age", true)
+	null := NewGetField(2, sql.Text, "null", true)
+	phones := NewGetField(3, sql.JSON, "phones", true)
+	birthday := NewGetField(4, sql.Date, "birthday", true)
+
+	e := NewJSONObject(
+		NewLiteral("name", sql.Text), name,
+		NewLiteral("age", sql.Text), age,
+		NewLiteral("nick", sql.Text), null,
+		NewLiteral("phones", sql.Text), phones,
+		NewLiteral("birthday", sql.Text), birthday,
+	)
+	require.Equal(sql.JSONDocument{Val: map[string]interface{}{
+		"name":     "John",
+		"age":      30.0,
+		"nick":     nil,
+		"phones":   []interface{}{"555"},
+		"birthday": "2018-01-02",
+	}}, e.Eval(row))
+	require.Nil(NewJSONObject(name).Eval(row))
+	require.Nil(NewJSONObject(null, name).Eval(row))
+
+	a := NewJSONArray(name, age, null, NewLiteral(`{"a": 1}`, sql.Text))
+	require.Equal(sql.JSONDocument{Val: []interface{}{"John", 30.0, nil, `{"a": 1}`}}, a.Eval(row))
+	require.Equal(sql.JSONDocument{Val: []interface{}{}}, NewJSONArray().Eval(row))
+	require.Equal(`JSON_ARRAY()`, NewJSONArray().String())
+}
+
+func TestJSONScalar(t *testing.T) {
+	require := require.New(t)
+
+	e := NewJSONScalar(NewGetField(0, sql.Text, "s", true))
+	require.Equal(sql.JSON, e.Type())
+	require.Equal(sql.JSONDocument{Val: "[1]"}, e.Eval(sql.NewRow("[1]")))
+	require.Nil(e.Eval(sql.NewRow(nil)))
+
+	e = NewJSONScalar(NewGetField(0, sql.Int64, "n", true))
+	require.Equal(sql.JSONDocument{Val: 1.0}, e.Eval(sql.NewRow(int64(1))))
+}
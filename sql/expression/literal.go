@@ -1,6 +1,10 @@
 package expression
 
-import "github.com/src-d/go-mysql-server/sql"
+import (
+	"fmt"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
 
 type Literal struct {
 	value     interface{}
@@ -36,6 +40,17 @@ func (p Literal) Name() string {
 	return p.name
 }
 
+func (p Literal) String() string {
+	switch v := p.value.(type) {
+	case nil:
+		return "NULL"
+	case string:
+		return fmt.Sprintf("%q", v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
 func (p *Literal) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
 	n := *p
 	return f(&n)
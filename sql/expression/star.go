@@ -25,6 +25,10 @@ func (Star) Name() string {
 	return "*"
 }
 
+func (Star) String() string {
+	return "*"
+}
+
 func (Star) Eval(r sql.Row) interface{} {
 	return "FAIL" //FIXME
 }
@@ -1,6 +1,11 @@
 package expression
 
-import "github.com/src-d/go-mysql-server/sql"
+import (
+	"fmt"
+	"strings"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
 
 type UnresolvedColumn struct {
 	name string
@@ -26,6 +31,10 @@ func (c UnresolvedColumn) Name() string {
 	return c.name
 }
 
+func (c UnresolvedColumn) String() string {
+	return c.name
+}
+
 func (UnresolvedColumn) Eval(r sql.Row) interface{} {
 	return "FAIL" //FIXME
 }
@@ -62,6 +71,15 @@ func (c UnresolvedFunction) Name() string {
 	return c.name
 }
 
+func (c UnresolvedFunction) String() string {
+	args := make([]string, len(c.Children))
+	for i, e := range c.Children {
+		args[i] = fmt.Sprint(e)
+	}
+
+	return fmt.Sprintf("%s(%s)", c.name, strings.Join(args, ", "))
+}
+
 func (UnresolvedFunction) Eval(r sql.Row) interface{} {
 	return "FAIL" //FIXME
 }
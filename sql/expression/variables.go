@@ -0,0 +1,135 @@
+package expression
+
+import "github.com/src-d/go-mysql-server/sql"
+
+// SystemVar is a reference to a system variable, such as @@autocommit or
+// @@session.max_allowed_packet. It is unresolved until the analyzer sets the
+// value the variable has in the current session.
+type SystemVar struct {
+	name     string
+	Variable string
+	Scope    sql.SystemVariableScope
+	typ      sql.Type
+	value    interface{}
+	resolved bool
+}
+
+// NewSystemVar creates a new unresolved reference to the system variable
+// with the given name in the given scope. The name is the text used to
+// refer to the variable in the query, and it's also the name of the
+// expression.
+func NewSystemVar(name, variable string, scope sql.SystemVariableScope) *SystemVar {
+	return &SystemVar{name: name, Variable: variable, Scope: scope}
+}
+
+// WithValue returns a resolved copy of the expression with the given value.
+func (v *SystemVar) WithValue(typ sql.Type, value interface{}) *SystemVar {
+	n := *v
+	n.typ = typ
+	n.value = value
+	n.resolved = true
+	return &n
+}
+
+// Resolved implements the Expression interface.
+func (v *SystemVar) Resolved() bool {
+	return v.resolved
+}
+
+// IsNullable implements the Expression interface.
+func (v *SystemVar) IsNullable() bool {
+	return v.value == nil
+}
+
+// Type implements the Expression interface.
+func (v *SystemVar) Type() sql.Type {
+	if v.typ == nil {
+		return sql.Null
+	}
+
+	return v.typ
+}
+
+// Name implements the Expression interface.
+func (v *SystemVar) Name() string {
+	return v.name
+}
+
+func (v *SystemVar) String() string {
+	return v.name
+}
+
+// Eval implements the Expression interface.
+func (v *SystemVar) Eval(sql.Row) interface{} {
+	return v.value
+}
+
+// TransformUp implements the Expression interface.
+func (v *SystemVar) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	n := *v
+	return f(&n)
+}
+
+// UserVar is a reference to a user-defined variable, such as @foo. It is
+// unresolved until the analyzer sets the value the variable has in the
+// current session.
+type UserVar struct {
+	Variable string
+	typ      sql.Type
+	value    interface{}
+	resolved bool
+}
+
+// NewUserVar creates a new unresolved reference to the user-defined
+// variable with the given name.
+func NewUserVar(variable string) *UserVar {
+	return &UserVar{Variable: variable}
+}
+
+// WithValue returns a resolved copy of the expression with the given value.
+func (v *UserVar) WithValue(typ sql.Type, value interface{}) *UserVar {
+	n := *v
+	n.typ = typ
+	n.value = value
+	n.resolved = true
+	return &n
+}
+
+// Resolved implements the Expression interface.
+func (v *UserVar) Resolved() bool {
+	return v.resolved
+}
+
+// IsNullable implements the Expression interface.
+func (v *UserVar) IsNullable() bool {
+	return v.value == nil
+}
+
+// Type implements the Expression interface.
+func (v *UserVar) Type() sql.Type {
+	if v.typ == nil {
+		return sql.Null
+	}
+
+	return v.typ
+}
+
+// Name implements the Expression interface.
+func (v *UserVar) Name() string {
+	return "@" + v.Variable
+}
+
+func (v *UserVar) String() string {
+	return v.Name()
+}
+
+// Eval implements the Expression interface.
+func (v *UserVar) Eval(sql.Row) interface{} {
+	return v.value
+}
+
+// TransformUp implements the Expression interface.
+func (v *UserVar) TransformUp(f func(sql.Expression) sql.Expression) sql.Expression {
+	n := *v
+	return f(&n)
+}
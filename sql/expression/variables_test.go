@@ -0,0 +1,38 @@
+package expression
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSystemVar(t *testing.T) {
+	require := require.New(t)
+
+	v := NewSystemVar("@@SESSION.autocommit", "autocommit", sql.SessionScope)
+	require.False(v.Resolved())
+	require.Equal("@@SESSION.autocommit", v.Name())
+
+	rv := v.WithValue(sql.Int64, int64(1))
+	require.False(v.Resolved())
+	require.True(rv.Resolved())
+	require.False(rv.IsNullable())
+	require.Equal(sql.Int64, rv.Type())
+	require.Equal(int64(1), rv.Eval(nil))
+}
+
+func TestUserVar(t *testing.T) {
+	require := require.New(t)
+
+	v := NewUserVar("foo")
+	require.False(v.Resolved())
+	require.Equal("@foo", v.Name())
+
+	rv := v.WithValue(sql.Null, nil)
+	require.True(rv.Resolved())
+	require.True(rv.IsNullable())
+	require.Equal(sql.Null, rv.Type())
+	require.Nil(rv.Eval(nil))
+}
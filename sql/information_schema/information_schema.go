@@ -0,0 +1,48 @@
+// Package information_schema implements the INFORMATION_SCHEMA database,
+// whose tables describe the databases, tables and columns of a catalog.
+//
+// The rows of its tables are built from the catalog every time they are
+// read, so they always reflect its current databases.
+package information_schema
+
+import (
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// Name is the name of the INFORMATION_SCHEMA database.
+const Name = "information_schema"
+
+// Names of the tables of the database.
+const (
+	SchemataTable       = "SCHEMATA"
+	TablesTable         = "TABLES"
+	ColumnsTable        = "COLUMNS"
+	StatisticsTable     = "STATISTICS"
+	KeyColumnUsageTable = "KEY_COLUMN_USAGE"
+)
+
+// Database is the INFORMATION_SCHEMA database of a catalog.
+type Database struct {
+	tables map[string]sql.Table
+}
+
+// NewDatabase creates the INFORMATION_SCHEMA database of the given catalog.
+func NewDatabase(c *sql.Catalog) *Database {
+	return &Database{tables: map[string]sql.Table{
+		SchemataTable:       newTable(SchemataTable, schemataSchema, c, schemataRows),
+		TablesTable:         newTable(TablesTable, tablesSchema, c, tablesRows),
+		ColumnsTable:        newTable(ColumnsTable, columnsSchema, c, columnsRows),
+		StatisticsTable:     newTable(StatisticsTable, statisticsSchema, c, emptyRows),
+		KeyColumnUsageTable: newTable(KeyColumnUsageTable, keyColumnUsageSchema, c, emptyRows),
+	}}
+}
+
+// Name implements the sql.Database interface.
+func (*Database) Name() string {
+	return Name
+}
+
+// Tables implements the sql.Database interface.
+func (d *Database) Tables() map[string]sql.Table {
+	return d.tables
+}
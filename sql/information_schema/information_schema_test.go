@@ -0,0 +1,141 @@
+package information_schema
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/mem"
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/src-d/go-vitess/sqltypes"
+	"github.com/stretchr/testify/require"
+)
+
+func testCatalog() *sql.Catalog {
+	c := sql.NewCatalog()
+	c.Databases = append(c.Databases, NewDatabase(c))
+
+	email, err := sql.CreateString(sqltypes.VarChar, 255, "utf8mb4", "")
+	if err != nil {
+		panic(err)
+	}
+
+	status, err := sql.CreateEnum([]string{"active", "disabled"})
+	if err != nil {
+		panic(err)
+	}
+
+	db := mem.NewDatabase("mydb")
+	db.AddTable("people", mem.NewTable("people", sql.Schema{
+		{Name: "id", Type: sql.Int64},
+		{Name: "name", Type: sql.Text, Nullable: true, Default: "anonymous"},
+		{Name: "admin", Type: sql.Boolean, Default: false},
+		{Name: "balance", Type: sql.Decimal(10, 2), Default: 0},
+		{Name: "seen", Type: sql.Datetime(3), Nullable: true},
+		{Name: "email", Type: email},
+		{Name: "status", Type: status},
+	}))
+	db.AddTable("empty", mem.NewTable("empty", sql.Schema{}))
+	c.Databases = append(c.Databases, db)
+
+	return c
+}
+
+func rows(t *testing.T, c *sql.Catalog, table string) []sql.Row {
+	tbl, err := c.Table(Name, table)
+	require.NoError(t, err)
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), tbl)
+	require.NoError(t, err)
+	return rows
+}
+
+func TestSchemata(t *testing.T) {
+	require.Equal(t, []sql.Row{
+		{"def", "information_schema", "utf8", "utf8_general_ci", nil},
+		{"def", "mydb", "utf8", "utf8_general_ci", nil},
+	}, rows(t, testCatalog(), SchemataTable))
+}
+
+func TestTables(t *testing.T) {
+	require := require.New(t)
+
+	var names []string
+	for _, row := range rows(t, testCatalog(), TablesTable) {
+		require.Len(row, len(tablesSchema))
+		names = append(names, row[1].(string)+"."+row[2].(string)+" "+row[3].(string))
+	}
+
+	require.Equal([]string{
+		"information_schema.COLUMNS SYSTEM VIEW",
+		"information_schema.KEY_COLUMN_USAGE SYSTEM VIEW",
+		"information_schema.SCHEMATA SYSTEM VIEW",
+		"information_schema.STATISTICS SYSTEM VIEW",
+		"information_schema.TABLES SYSTEM VIEW",
+		"mydb.empty BASE TABLE",
+		"mydb.people BASE TABLE",
+	}, names)
+}
+
+func TestColumns(t *testing.T) {
+	require := require.New(t)
+
+	var columns []sql.Row
+	for _, row := range rows(t, testCatalog(), ColumnsTable) {
+		if row[1] == "mydb" {
+			columns = append(columns, row)
+		}
+	}
+
+	require.Equal([]sql.Row{
+		{
+			"def", "mydb", "people", "id", uint64(1), nil, "NO", "bigint",
+			nil, nil, uint64(19), uint64(0), nil, nil, nil, "bigint",
+			"", "", "select", "",
+		},
+		{
+			"def", "mydb", "people", "name", uint64(2), "anonymous", "YES", "text",
+			uint64(65535), uint64(65535), nil, nil, nil, "utf8", "utf8_general_ci", "text",
+			"", "", "select", "",
+		},
+		{
+			"def", "mydb", "people", "admin", uint64(3), "0", "NO", "tinyint",
+			nil, nil, uint64(3), uint64(0), nil, nil, nil, "tinyint(1)",
+			"", "", "select", "",
+		},
+		{
+			"def", "mydb", "people", "balance", uint64(4), "0.00", "NO", "decimal",
+			nil, nil, uint64(10), uint64(2), nil, nil, nil, "decimal(10,2)",
+			"", "", "select", "",
+		},
+		{
+			"def", "mydb", "people", "seen", uint64(5), nil, "YES", "datetime",
+			nil, nil, nil, nil, uint64(3), nil, nil, "datetime(3)",
+			"", "", "select", "",
+		},
+		{
+			"def", "mydb", "people", "email", uint64(6), nil, "NO", "varchar",
+			uint64(255), uint64(1020), nil, nil, nil, "utf8mb4", "utf8mb4_general_ci", "varchar(255)",
+			"", "", "select", "",
+		},
+		{
+			"def", "mydb", "people", "status", uint64(7), nil, "NO", "enum",
+			uint64(8), uint64(24), nil, nil, nil, "utf8", "utf8_general_ci", "enum('active','disabled')",
+			"", "", "select", "",
+		},
+	}, columns)
+}
+
+func TestIndexes(t *testing.T) {
+	c := testCatalog()
+	require.Empty(t, rows(t, c, StatisticsTable))
+	require.Empty(t, rows(t, c, KeyColumnUsageTable))
+}
+
+func TestTableNames(t *testing.T) {
+	require := require.New(t)
+	c := testCatalog()
+
+	table, err := c.Table("INFORMATION_SCHEMA", "tables")
+	require.NoError(err)
+	require.Equal(TablesTable, table.Name())
+}
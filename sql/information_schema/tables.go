@@ -0,0 +1,375 @@
+package information_schema
+
+import (
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/src-d/go-vitess/vt/proto/query"
+)
+
+// catalogName is the name of the catalog of every database.
+const catalogName = "def"
+
+const (
+	characterSet = sql.DefaultCharacterSet
+	collation    = sql.DefaultCollation
+)
+
+var schemataSchema = sql.Schema{
+	{Name: "CATALOG_NAME", Type: sql.Text},
+	{Name: "SCHEMA_NAME", Type: sql.Text},
+	{Name: "DEFAULT_CHARACTER_SET_NAME", Type: sql.Text},
+	{Name: "DEFAULT_COLLATION_NAME", Type: sql.Text},
+	{Name: "SQL_PATH", Type: sql.Text, Nullable: true},
+}
+
+var tablesSchema = sql.Schema{
+	{Name: "TABLE_CATALOG", Type: sql.Text},
+	{Name: "TABLE_SCHEMA", Type: sql.Text},
+	{Name: "TABLE_NAME", Type: sql.Text},
+	{Name: "TABLE_TYPE", Type: sql.Text},
+	{Name: "ENGINE", Type: sql.Text, Nullable: true},
+	{Name: "VERSION", Type: sql.Uint64, Nullable: true},
+	{Name: "ROW_FORMAT", Type: sql.Text, Nullable: true},
+	{Name: "TABLE_ROWS", Type: sql.Uint64, Nullable: true},
+	{Name: "CREATE_TIME", Type: sql.Timestamp, Nullable: true},
+	{Name: "TABLE_COLLATION", Type: sql.Text, Nullable: true},
+	{Name: "TABLE_COMMENT", Type: sql.Text},
+}
+
+var columnsSchema = sql.Schema{
+	{Name: "TABLE_CATALOG", Type: sql.Text},
+	{Name: "TABLE_SCHEMA", Type: sql.Text},
+	{Name: "TABLE_NAME", Type: sql.Text},
+	{Name: "COLUMN_NAME", Type: sql.Text},
+	{Name: "ORDINAL_POSITION", Type: sql.Uint64},
+	{Name: "COLUMN_DEFAULT", Type: sql.Text, Nullable: true},
+	{Name: "IS_NULLABLE", Type: sql.Text},
+	{Name: "DATA_TYPE", Type: sql.Text},
+	{Name: "CHARACTER_MAXIMUM_LENGTH", Type: sql.Uint64, Nullable: true},
+	{Name: "CHARACTER_OCTET_LENGTH", Type: sql.Uint64, Nullable: true},
+	{Name: "NUMERIC_PRECISION", Type: sql.Uint64, Nullable: true},
+	{Name: "NUMERIC_SCALE", Type: sql.Uint64, Nullable: true},
+	{Name: "DATETIME_PRECISION", Type: sql.Uint64, Nullable: true},
+	{Name: "CHARACTER_SET_NAME", Type: sql.Text, Nullable: true},
+	{Name: "COLLATION_NAME", Type: sql.Text, Nullable: true},
+	{Name: "COLUMN_TYPE", Type: sql.Text},
+	{Name: "COLUMN_KEY", Type: sql.Text},
+	{Name: "EXTRA", Type: sql.Text},
+	{Name: "PRIVILEGES", Type: sql.Text},
+	{Name: "COLUMN_COMMENT", Type: sql.Text},
+}
+
+var statisticsSchema = sql.Schema{
+	{Name: "TABLE_CATALOG", Type: sql.Text},
+	{Name: "TABLE_SCHEMA", Type: sql.Text},
+	{Name: "TABLE_NAME", Type: sql.Text},
+	{Name: "NON_UNIQUE", Type: sql.Int64},
+	{Name: "INDEX_SCHEMA", Type: sql.Text},
+	{Name: "INDEX_NAME", Type: sql.Text},
+	{Name: "SEQ_IN_INDEX", Type: sql.Uint64},
+	{Name: "COLUMN_NAME", Type: sql.Text},
+	{Name: "COLLATION", Type: sql.Text, Nullable: true},
+	{Name: "CARDINALITY", Type: sql.Int64, Nullable: true},
+	{Name: "SUB_PART", Type: sql.Int64, Nullable: true},
+	{Name: "PACKED", Type: sql.Text, Nullable: true},
+	{Name: "NULLABLE", Type: sql.Text},
+	{Name: "INDEX_TYPE", Type: sql.Text},
+	{Name: "COMMENT", Type: sql.Text},
+	{Name: "INDEX_COMMENT", Type: sql.Text},
+}
+
+var keyColumnUsageSchema = sql.Schema{
+	{Name: "CONSTRAINT_CATALOG", Type: sql.Text},
+	{Name: "CONSTRAINT_SCHEMA", Type: sql.Text},
+	{Name: "CONSTRAINT_NAME", Type: sql.Text},
+	{Name: "TABLE_CATALOG", Type: sql.Text},
+	{Name: "TABLE_SCHEMA", Type: sql.Text},
+	{Name: "TABLE_NAME", Type: sql.Text},
+	{Name: "COLUMN_NAME", Type: sql.Text},
+	{Name: "ORDINAL_POSITION", Type: sql.Uint64},
+	{Name: "POSITION_IN_UNIQUE_CONSTRAINT", Type: sql.Uint64, Nullable: true},
+	{Name: "REFERENCED_TABLE_SCHEMA", Type: sql.Text, Nullable: true},
+	{Name: "REFERENCED_TABLE_NAME", Type: sql.Text, Nullable: true},
+	{Name: "REFERENCED_COLUMN_NAME", Type: sql.Text, Nullable: true},
+}
+
+// table is a table of the INFORMATION_SCHEMA database, whose rows are
+// built from the catalog when it's read.
+type table struct {
+	name    string
+	schema  sql.Schema
+	catalog *sql.Catalog
+	rows    func(*sql.Catalog) []sql.Row
+}
+
+func newTable(
+	name string,
+	schema sql.Schema,
+	catalog *sql.Catalog,
+	rows func(*sql.Catalog) []sql.Row,
+) *table {
+	return &table{name: name, schema: schema, catalog: catalog, rows: rows}
+}
+
+// Resolved implements the Resolvable interface.
+func (*table) Resolved() bool {
+	return true
+}
+
+// Name implements the Nameable interface.
+func (t *table) Name() string {
+	return t.name
+}
+
+// Schema implements the Node interface.
+func (t *table) Schema() sql.Schema {
+	return t.schema
+}
+
+// Children implements the Node interface.
+func (*table) Children() []sql.Node {
+	return []sql.Node{}
+}
+
+// RowIter implements the Node interface.
+func (t *table) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	return sql.RowsToRowIter(t.rows(t.catalog)...), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (t *table) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(t)
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (t *table) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return t
+}
+
+// databases returns the databases of the catalog sorted by name.
+func databases(c *sql.Catalog) []sql.Database {
+	dbs := make([]sql.Database, len(c.Databases))
+	copy(dbs, c.Databases)
+	sort.Slice(dbs, func(i, j int) bool {
+		return dbs[i].Name() < dbs[j].Name()
+	})
+
+	return dbs
+}
+
+// tables returns the tables of a database sorted by name.
+func tables(db sql.Database) []sql.Table {
+	all := db.Tables()
+	names := make([]string, 0, len(all))
+	for name := range all {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+	tables := make([]sql.Table, len(names))
+	for i, name := range names {
+		tables[i] = all[name]
+	}
+
+	return tables
+}
+
+func schemataRows(c *sql.Catalog) []sql.Row {
+	var rows []sql.Row
+	for _, db := range databases(c) {
+		rows = append(rows, sql.NewRow(
+			catalogName, db.Name(), characterSet, collation, nil,
+		))
+	}
+
+	return rows
+}
+
+func tablesRows(c *sql.Catalog) []sql.Row {
+	var rows []sql.Row
+	for _, db := range databases(c) {
+		typ, engine := "BASE TABLE", interface{}(nil)
+		if _, ok := db.(*Database); ok {
+			typ, engine = "SYSTEM VIEW", "MEMORY"
+		}
+
+		for _, t := range tables(db) {
+			rows = append(rows, sql.NewRow(
+				catalogName, db.Name(), t.Name(), typ, engine,
+				nil, nil, nil, nil, collation, "",
+			))
+		}
+	}
+
+	return rows
+}
+
+func columnsRows(c *sql.Catalog) []sql.Row {
+	var rows []sql.Row
+	for _, db := range databases(c) {
+		for _, t := range tables(db) {
+			for i, col := range t.Schema() {
+				rows = append(rows, columnRow(db.Name(), t.Name(), i, col))
+			}
+		}
+	}
+
+	return rows
+}
+
+func columnRow(db, table string, i int, c *sql.Column) sql.Row {
+	var def interface{}
+	if c.Default != nil {
+		def = c.Type.SQL(c.Default).ToString()
+	}
+
+	nullable := "NO"
+	if c.Nullable {
+		nullable = "YES"
+	}
+
+	info := typeInfoOf(c.Type)
+	var charset, coll interface{}
+	switch t := c.Type.(type) {
+	case sql.StringType:
+		if sql.IsText(t) {
+			charset, coll = t.CharacterSet(), t.Collation()
+		}
+	case sql.EnumType, sql.SetType:
+		charset, coll = characterSet, collation
+	}
+
+	return sql.NewRow(
+		catalogName, db, table, c.Name, uint64(i+1),
+		def, nullable, info.dataType,
+		info.maxLength, info.octetLength,
+		info.precision, info.scale, info.datetimePrecision,
+		charset, coll, sql.MySQLTypeName(c.Type),
+		"", "", "select", "",
+	)
+}
+
+func emptyRows(*sql.Catalog) []sql.Row {
+	return nil
+}
+
+// typeInfo describes a type as MySQL does in the COLUMNS table. Lengths,
+// precisions and scales are nil if they don't apply to the type.
+type typeInfo struct {
+	dataType          string
+	maxLength         interface{}
+	octetLength       interface{}
+	precision         interface{}
+	scale             interface{}
+	datetimePrecision interface{}
+}
+
+func typeInfoOf(t sql.Type) typeInfo {
+	if st, ok := t.(sql.StringType); ok {
+		// The lengths of CHAR and VARCHAR are in characters, and the rest
+		// of lengths are in bytes.
+		octets := uint64(st.MaxLength())
+		if st.Type() == query.Type_CHAR || st.Type() == query.Type_VARCHAR {
+			octets *= uint64(sql.MaxCharacterBytes(st.CharacterSet()))
+		}
+
+		name := sql.MySQLTypeName(t)
+		if i := strings.IndexByte(name, '('); i >= 0 {
+			name = name[:i]
+		}
+
+		return typeInfo{
+			dataType:  name,
+			maxLength: uint64(st.MaxLength()), octetLength: octets,
+		}
+	}
+
+	switch t := t.(type) {
+	case sql.EnumType:
+		return membersInfo("enum", t.Values(), false)
+	case sql.SetType:
+		return membersInfo("set", t.Values(), true)
+	}
+
+	switch t.Type() {
+	case query.Type_INT8:
+		return typeInfo{dataType: "tinyint", precision: uint64(3), scale: uint64(0)}
+	case query.Type_INT16:
+		return typeInfo{dataType: "smallint", precision: uint64(5), scale: uint64(0)}
+	case query.Type_INT24:
+		return typeInfo{dataType: "mediumint", precision: uint64(7), scale: uint64(0)}
+	case query.Type_INT32:
+		return typeInfo{dataType: "int", precision: uint64(10), scale: uint64(0)}
+	case query.Type_INT64:
+		return typeInfo{dataType: "bigint", precision: uint64(19), scale: uint64(0)}
+	case query.Type_UINT8:
+		return typeInfo{dataType: "tinyint", precision: uint64(3), scale: uint64(0)}
+	case query.Type_UINT16:
+		return typeInfo{dataType: "smallint", precision: uint64(5), scale: uint64(0)}
+	case query.Type_UINT24:
+		return typeInfo{dataType: "mediumint", precision: uint64(8), scale: uint64(0)}
+	case query.Type_UINT32:
+		return typeInfo{dataType: "int", precision: uint64(10), scale: uint64(0)}
+	case query.Type_UINT64:
+		return typeInfo{dataType: "bigint", precision: uint64(20), scale: uint64(0)}
+	case query.Type_FLOAT32:
+		return typeInfo{dataType: "float", precision: uint64(12)}
+	case query.Type_FLOAT64:
+		return typeInfo{dataType: "double", precision: uint64(22)}
+	case query.Type_DECIMAL:
+		d, ok := t.(sql.DecimalType)
+		if !ok {
+			return typeInfo{dataType: "decimal"}
+		}
+		return typeInfo{
+			dataType:  "decimal",
+			precision: uint64(d.Precision()), scale: uint64(d.Scale()),
+		}
+	case query.Type_TIMESTAMP:
+		return typeInfo{dataType: "timestamp", datetimePrecision: uint64(0)}
+	case query.Type_DATETIME:
+		var precision int
+		if d, ok := t.(sql.DatetimeType); ok {
+			precision = d.Precision()
+		}
+		return typeInfo{dataType: "datetime", datetimePrecision: uint64(precision)}
+	case query.Type_TIME:
+		return typeInfo{dataType: "time", datetimePrecision: uint64(0)}
+	case query.Type_BIT:
+		var length int
+		if b, ok := t.(sql.BitType); ok {
+			length = b.Length()
+		}
+		return typeInfo{dataType: "bit", precision: uint64(length)}
+	default:
+		return typeInfo{dataType: sql.MySQLTypeName(t)}
+	}
+}
+
+// membersInfo returns the type info of an ENUM or SET type with the given
+// members. The values of ENUM types are as long as their longest member,
+// and the values of SET types may have all the members separated by commas.
+func membersInfo(dataType string, values []string, set bool) typeInfo {
+	var length uint64
+	for i, v := range values {
+		n := uint64(utf8.RuneCountInString(v))
+		switch {
+		case !set && n > length:
+			length = n
+		case set:
+			if i > 0 {
+				length++
+			}
+			length += n
+		}
+	}
+
+	return typeInfo{
+		dataType:  dataType,
+		maxLength: length, octetLength: length * uint64(sql.MaxCharacterBytes(characterSet)),
+	}
+}
@@ -0,0 +1,436 @@
+package sql
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql/decimal"
+)
+
+// JSONDocument is a value of the JSON type. Val is the document, made of
+// nil, bool, float64, string, []interface{} and map[string]interface{}
+// values, as encoding/json decodes them. A document with a nil Val is the
+// JSON null, which, unlike a nil value, is not SQL NULL.
+type JSONDocument struct {
+	Val interface{}
+}
+
+// MarshalJSON implements the json.Marshaler interface.
+func (d JSONDocument) MarshalJSON() ([]byte, error) {
+	return marshalJSON(d.Val)
+}
+
+// jsonDocument returns the document of a value. Strings and byte slices are
+// JSON text, while other values, such as the slices and structs of Go
+// tables, are documents as encoding/json encodes them.
+func jsonDocument(v interface{}) (JSONDocument, error) {
+	switch v := v.(type) {
+	case JSONDocument:
+		return v, nil
+	case string:
+		return parseJSON([]byte(v))
+	case []byte:
+		return parseJSON(v)
+	case nil, bool, float64:
+		return JSONDocument{v}, nil
+	default:
+		b, err := json.Marshal(v)
+		if err != nil {
+			return JSONDocument{}, err
+		}
+		return parseJSON(b)
+	}
+}
+
+func parseJSON(b []byte) (JSONDocument, error) {
+	var doc interface{}
+	if err := json.Unmarshal(b, &doc); err != nil {
+		return JSONDocument{}, fmt.Errorf("invalid JSON text: %s", err)
+	}
+
+	return JSONDocument{doc}, nil
+}
+
+// marshalJSON returns the JSON text of a document. Unlike json.Marshal, it
+// doesn't escape HTML characters.
+func marshalJSON(doc interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	if err := enc.Encode(doc); err != nil {
+		return nil, err
+	}
+
+	return bytes.TrimSuffix(buf.Bytes(), []byte{'\n'}), nil
+}
+
+// Kinds of JSON values in the order MySQL sorts them when it compares values
+// of different kinds.
+const (
+	jsonNull = iota
+	jsonNumber
+	jsonString
+	jsonObject
+	jsonArray
+	jsonBoolean
+	jsonDatetime
+)
+
+func jsonKind(v interface{}) int {
+	switch v.(type) {
+	case nil:
+		return jsonNull
+	case float64:
+		return jsonNumber
+	case string:
+		return jsonString
+	case map[string]interface{}:
+		return jsonObject
+	case []interface{}:
+		return jsonArray
+	case bool:
+		return jsonBoolean
+	default:
+		return jsonDatetime
+	}
+}
+
+// jsonComparable returns a value to compare as a JSON value. Numbers are
+// compared as floats, and times are compared as datetimes instead of as the
+// strings they are encoded as.
+func jsonComparable(v interface{}) interface{} {
+	switch v := v.(type) {
+	case time.Time:
+		return v
+	case decimal.Decimal:
+		return v.Float64()
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32:
+		f, _ := Float64.Convert(v)
+		return f
+	}
+
+	doc, err := jsonDocument(v)
+	if err != nil {
+		return nil
+	}
+
+	return doc.Val
+}
+
+// compareJSON compares two JSON values as MySQL does. Values of different
+// kinds are ordered by their kind: null, numbers, strings, objects, arrays,
+// booleans and datetimes. Strings are compared by their bytes, and arrays
+// by their elements. Objects are only equal if they have the same members,
+// and otherwise they are ordered by their size, keys and values.
+func compareJSON(a, b interface{}) int {
+	ka, kb := jsonKind(a), jsonKind(b)
+	if ka != kb {
+		if ka < kb {
+			return -1
+		}
+		return +1
+	}
+
+	switch a := a.(type) {
+	case float64:
+		return compareFloats(a, b.(float64))
+	case string:
+		return strings.Compare(a, b.(string))
+	case bool:
+		return Boolean.Compare(a, b)
+	case time.Time:
+		return compareTimes(a, b.(time.Time))
+	case []interface{}:
+		b := b.([]interface{})
+		for i := 0; i < len(a) && i < len(b); i++ {
+			if c := compareJSON(a[i], b[i]); c != 0 {
+				return c
+			}
+		}
+		return compareInts(len(a), len(b))
+	case map[string]interface{}:
+		b := b.(map[string]interface{})
+		if c := compareInts(len(a), len(b)); c != 0 {
+			return c
+		}
+
+		ak, bk := JSONKeys(a), JSONKeys(b)
+		for i := range ak {
+			if c := strings.Compare(ak[i], bk[i]); c != 0 {
+				return c
+			}
+		}
+
+		for _, k := range ak {
+			if c := compareJSON(a[k], b[k]); c != 0 {
+				return c
+			}
+		}
+	}
+
+	return 0
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return +1
+	default:
+		return 0
+	}
+}
+
+func compareInts(a, b int) int {
+	return compareFloats(float64(a), float64(b))
+}
+
+// JSONKeys returns the keys of a JSON object in the order MySQL stores them,
+// which is by their length and then by their bytes.
+func JSONKeys(o map[string]interface{}) []string {
+	keys := make([]string, 0, len(o))
+	for k := range o {
+		keys = append(keys, k)
+	}
+
+	sort.Slice(keys, func(i, j int) bool {
+		if len(keys[i]) != len(keys[j]) {
+			return len(keys[i]) < len(keys[j])
+		}
+		return keys[i] < keys[j]
+	})
+
+	return keys
+}
+
+// JSONPath is a path to values of a JSON document, such as $.a[0]. The path
+// starts with $, the whole document, followed by legs:
+//
+//	.key or ."key"  the member of an object with the given key
+//	.*              all the members of an object
+//	[n]             the n-th element of an array, starting at 0
+//	[last] [last-n] the last or the n-th last element of an array
+//	[*]             all the elements of an array
+//	**              the value and all the values nested in it
+//
+// As in MySQL, a value that is not an array is the only element of an array
+// with that value, so $[0] of a value is the value itself.
+type JSONPath struct {
+	text string
+	legs []jsonPathLeg
+}
+
+type jsonPathLeg struct {
+	kind jsonLegKind
+	key  string
+	// index is the index of the element, counted from the last one if
+	// fromLast is true.
+	index    int
+	fromLast bool
+}
+
+type jsonLegKind byte
+
+const (
+	memberLeg jsonLegKind = iota
+	memberWildcardLeg
+	indexLeg
+	indexWildcardLeg
+	descendantsLeg
+)
+
+// ParseJSONPath parses a JSON path. It returns an error if it's not a valid
+// path.
+func ParseJSONPath(s string) (JSONPath, error) {
+	invalid := fmt.Errorf("invalid JSON path %q", s)
+
+	p := strings.TrimSpace(s)
+	if !strings.HasPrefix(p, "$") {
+		return JSONPath{}, invalid
+	}
+	p = strings.TrimLeft(p[1:], " ")
+
+	path := JSONPath{text: s}
+	for p != "" {
+		var leg jsonPathLeg
+		switch {
+		case strings.HasPrefix(p, "**"):
+			leg.kind = descendantsLeg
+			p = p[2:]
+		case strings.HasPrefix(p, "."):
+			p = strings.TrimLeft(p[1:], " ")
+			switch {
+			case strings.HasPrefix(p, "*"):
+				leg.kind = memberWildcardLeg
+				p = p[1:]
+			case strings.HasPrefix(p, `"`):
+				end := quotedKeyEnd(p)
+				key, err := strconv.Unquote(p[:end])
+				if err != nil {
+					return JSONPath{}, invalid
+				}
+				leg.key, p = key, p[end:]
+			default:
+				end := strings.IndexAny(p, ".[* ")
+				if end < 0 {
+					end = len(p)
+				}
+				if end == 0 {
+					return JSONPath{}, invalid
+				}
+				leg.key, p = p[:end], p[end:]
+			}
+		case strings.HasPrefix(p, "["):
+			end := strings.Index(p, "]")
+			if end < 0 {
+				return JSONPath{}, invalid
+			}
+
+			var ok bool
+			if leg, ok = parseIndexLeg(strings.TrimSpace(p[1:end])); !ok {
+				return JSONPath{}, invalid
+			}
+			p = p[end+1:]
+		default:
+			return JSONPath{}, invalid
+		}
+
+		path.legs = append(path.legs, leg)
+		p = strings.TrimLeft(p, " ")
+	}
+
+	if n := len(path.legs); n > 0 && path.legs[n-1].kind == descendantsLeg {
+		return JSONPath{}, invalid
+	}
+
+	return path, nil
+}
+
+// quotedKeyEnd returns the index after the closing quote of the quoted key
+// at the start of s, or the length of s if it's not closed.
+func quotedKeyEnd(s string) int {
+	for i := 1; i < len(s); i++ {
+		switch s[i] {
+		case '\\':
+			i++
+		case '"':
+			return i + 1
+		}
+	}
+
+	return len(s)
+}
+
+func parseIndexLeg(s string) (jsonPathLeg, bool) {
+	if s == "*" {
+		return jsonPathLeg{kind: indexWildcardLeg}, true
+	}
+
+	leg := jsonPathLeg{kind: indexLeg}
+	if strings.HasPrefix(s, "last") {
+		leg.fromLast = true
+		s = strings.TrimSpace(s[len("last"):])
+		if s == "" {
+			return leg, true
+		}
+
+		if !strings.HasPrefix(s, "-") {
+			return leg, false
+		}
+		s = strings.TrimSpace(s[1:])
+	}
+
+	n, err := strconv.ParseUint(s, 10, 31)
+	if err != nil {
+		return leg, false
+	}
+
+	leg.index = int(n)
+	return leg, true
+}
+
+// HasWildcard returns whether the path may match more than one value.
+func (p JSONPath) HasWildcard() bool {
+	for _, leg := range p.legs {
+		switch leg.kind {
+		case memberWildcardLeg, indexWildcardLeg, descendantsLeg:
+			return true
+		}
+	}
+
+	return false
+}
+
+// Extract returns the values of a JSON document matched by the path, in the
+// order they are found in the document.
+func (p JSONPath) Extract(doc interface{}) []interface{} {
+	values := []interface{}{doc}
+	for _, leg := range p.legs {
+		var matched []interface{}
+		for _, v := range values {
+			matched = leg.extract(matched, v)
+		}
+		values = matched
+	}
+
+	return values
+}
+
+func (leg jsonPathLeg) extract(matched []interface{}, v interface{}) []interface{} {
+	switch leg.kind {
+	case memberLeg:
+		if o, ok := v.(map[string]interface{}); ok {
+			if m, ok := o[leg.key]; ok {
+				matched = append(matched, m)
+			}
+		}
+	case memberWildcardLeg:
+		if o, ok := v.(map[string]interface{}); ok {
+			for _, k := range JSONKeys(o) {
+				matched = append(matched, o[k])
+			}
+		}
+	case indexLeg:
+		a, ok := v.([]interface{})
+		if !ok {
+			a = []interface{}{v}
+		}
+
+		i := leg.index
+		if leg.fromLast {
+			i = len(a) - 1 - i
+		}
+
+		if i >= 0 && i < len(a) {
+			matched = append(matched, a[i])
+		}
+	case indexWildcardLeg:
+		if a, ok := v.([]interface{}); ok {
+			matched = append(matched, a...)
+		}
+	case descendantsLeg:
+		matched = append(matched, v)
+		switch v := v.(type) {
+		case map[string]interface{}:
+			for _, k := range JSONKeys(v) {
+				matched = leg.extract(matched, v[k])
+			}
+		case []interface{}:
+			for _, e := range v {
+				matched = leg.extract(matched, e)
+			}
+		}
+	}
+
+	return matched
+}
+
+func (p JSONPath) String() string {
+	return p.text
+}
@@ -0,0 +1,54 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONPath(t *testing.T) {
+	doc := map[string]interface{}{
+		"a":   []interface{}{1.0, 2.0, map[string]interface{}{"b": "c"}},
+		"b":   "d",
+		"e f": true,
+	}
+
+	testCases := []struct {
+		path     string
+		expected []interface{}
+	}{
+		{"$", []interface{}{doc}},
+		{"$.b", []interface{}{"d"}},
+		{`$."e f"`, []interface{}{true}},
+		{"$.a[0]", []interface{}{1.0}},
+		{"$.a[last]", []interface{}{map[string]interface{}{"b": "c"}}},
+		{"$.a[last - 1]", []interface{}{2.0}},
+		{"$.a[3]", nil},
+		{"$.b[0]", []interface{}{"d"}},
+		{"$.a[*]", []interface{}{1.0, 2.0, map[string]interface{}{"b": "c"}}},
+		{"$.*", []interface{}{doc["a"], "d", true}},
+		{"$**.b", []interface{}{"d", "c"}},
+		{"$.c", nil},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.path, func(t *testing.T) {
+			require := require.New(t)
+
+			p, err := ParseJSONPath(tt.path)
+			require.NoError(err)
+			require.Equal(tt.expected, p.Extract(doc))
+			require.Equal(tt.path, p.String())
+		})
+	}
+
+	for _, path := range []string{"", "a", "$.", "$[", "$[a]", "$**", `$."a`, "$ b"} {
+		_, err := ParseJSONPath(path)
+		require.Error(t, err, path)
+	}
+
+	p, _ := ParseJSONPath("$.a[*]")
+	require.True(t, p.HasWildcard())
+	p, _ = ParseJSONPath("$.a[0]")
+	require.False(t, p.HasWildcard())
+}
@@ -0,0 +1,99 @@
+package sql
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/src-d/go-vitess/sqltypes"
+	"github.com/src-d/go-vitess/vt/proto/query"
+)
+
+// OkResultColumnName is the name of the only column of OkResultSchema.
+const OkResultColumnName = "__ok_result"
+
+// OkResultSchema is the schema of the nodes of statements that don't return
+// rows but a summary of what they did, such as INSERT. Those nodes return a
+// single row with an OkResult as its only value.
+var OkResultSchema = Schema{{
+	Name: OkResultColumnName,
+	Type: OkResultType,
+}}
+
+// OkResultType is the type of the values of OkResultSchema, which are
+// OkResult. They are written as their number of affected rows.
+var OkResultType = okResultT{}
+
+type okResultT struct{}
+
+// Type implements Type interface.
+func (t okResultT) Type() query.Type {
+	return sqltypes.Uint64
+}
+
+// SQL implements Type interface.
+func (t okResultT) SQL(v interface{}) sqltypes.Value {
+	r := MustConvert(t, v).(OkResult)
+	return sqltypes.MakeTrusted(sqltypes.Uint64, strconv.AppendUint(nil, r.RowsAffected, 10))
+}
+
+// Convert implements Type interface. Only OkResult values can be
+// converted.
+func (t okResultT) Convert(v interface{}) (interface{}, error) {
+	r, ok := v.(OkResult)
+	if !ok {
+		return nil, fmt.Errorf("value %v is not an OkResult", v)
+	}
+
+	return r, nil
+}
+
+// Compare implements Type interface. Results are compared by their number
+// of affected rows.
+func (t okResultT) Compare(a interface{}, b interface{}) int {
+	x, y := a.(OkResult).RowsAffected, b.(OkResult).RowsAffected
+	switch {
+	case x < y:
+		return -1
+	case x > y:
+		return +1
+	default:
+		return 0
+	}
+}
+
+// OkResult is the result of a statement that modifies data.
+type OkResult struct {
+	// RowsAffected is the number of rows changed by the statement.
+	RowsAffected uint64
+	// InsertID is the first value generated for an auto increment column,
+	// or 0 if none was generated.
+	InsertID uint64
+	// Warnings is the number of warnings found while executing the
+	// statement.
+	Warnings uint16
+}
+
+// NewOkResult returns an OkResult with the given number of affected rows.
+func NewOkResult(rowsAffected int) OkResult {
+	return OkResult{RowsAffected: uint64(rowsAffected)}
+}
+
+// IsOkResult returns whether the given schema is the schema of an OkResult.
+// Both the name and the type of the column must match, so a user column
+// aliased as OkResultColumnName is not taken for one.
+func IsOkResult(s Schema) bool {
+	return len(s) == 1 &&
+		s[0].Name == OkResultColumnName &&
+		s[0].Type == OkResultType
+}
+
+// GetOkResult returns the OkResult of a row returned by a node with the
+// OkResultSchema.
+func GetOkResult(row Row) (OkResult, bool) {
+	if len(row) != 1 {
+		return OkResult{}, false
+	}
+
+	r, ok := row[0].(OkResult)
+	return r, ok
+}
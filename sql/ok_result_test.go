@@ -0,0 +1,25 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestOkResultType(t *testing.T) {
+	require := require.New(t)
+
+	r := OkResult{RowsAffected: 3, Warnings: 1}
+	v, err := OkResultType.Convert(r)
+	require.NoError(err)
+	require.Equal(r, v)
+
+	_, err = OkResultType.Convert(int64(3))
+	require.Error(err)
+
+	require.Equal("3", OkResultType.SQL(r).ToString())
+	require.Equal(-1, OkResultType.Compare(NewOkResult(1), r))
+	require.Equal(0, OkResultType.Compare(NewOkResult(3), r))
+	require.True(IsOkResult(OkResultSchema))
+	require.False(IsOkResult(Schema{{Name: OkResultColumnName, Type: Int64}}))
+}
@@ -0,0 +1,56 @@
+package parse
+
+import (
+	"regexp"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/plan"
+	"github.com/src-d/go-vitess/vt/sqlparser"
+)
+
+var describeRegex = regexp.MustCompile(`(?is)^\s*(?:describe|desc|explain)\s+(.*)$`)
+
+// describeTableRegex matches the table of a DESCRIBE statement, which may be
+// preceded by the TABLE keyword.
+var describeTableRegex = regexp.MustCompile(`(?is)^(?:table\s+)?(.+)$`)
+
+var statementRegex = regexp.MustCompile(`(?is)^\(?\s*(?:select|insert|replace|update|delete)\b`)
+
+// parseDescribe parses DESCRIBE, DESC and EXPLAIN statements, which describe
+// the columns of a table or the plan of a query. It returns a nil node if
+// the statement is not one of them.
+func parseDescribe(s string) (sql.Node, error) {
+	t := describeRegex.FindStringSubmatch(s)
+	if len(t) != 2 {
+		return nil, nil
+	}
+
+	if statementRegex.MatchString(t[1]) {
+		stmt, err := sqlparser.Parse(t[1])
+		if err != nil {
+			return nil, err
+		}
+
+		n, err := convert(stmt)
+		if err != nil {
+			return nil, err
+		}
+
+		return plan.NewExplain(n), nil
+	}
+
+	// The table is parsed as the one of a SHOW COLUMNS statement, so it can
+	// be qualified and quoted.
+	table := describeTableRegex.FindStringSubmatch(t[1])[1]
+	stmt, err := sqlparser.Parse("SHOW COLUMNS FROM " + table)
+	if err != nil {
+		return nil, err
+	}
+
+	show, ok := stmt.(*sqlparser.Show)
+	if !ok || show.ShowTablesOpt == nil || show.ShowTablesOpt.Filter != nil {
+		return nil, errUnsupported(stmt)
+	}
+
+	return plan.NewDescribe(showTable(show)), nil
+}
@@ -0,0 +1,254 @@
+package parse
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/expression"
+	"github.com/src-d/go-mysql-server/sql/plan"
+	"github.com/src-d/go-vitess/vt/sqlparser"
+)
+
+// jsonTablePrefix is the prefix of the names of the tables that replace the
+// JSON_TABLE table functions of a query, so the rest of the query can be
+// parsed by the SQL parser, which has no table functions.
+const jsonTablePrefix = "__json_table_"
+
+var (
+	jsonTableRegex = regexp.MustCompile(`(?i)^json_table\s*\(`)
+
+	// jsonTableArgsRegex matches the path and the columns of a JSON_TABLE.
+	jsonTableArgsRegex = regexp.MustCompile(
+		`(?is)^\s*('(?:[^'\\]|\\.|'')*'|"(?:[^"\\]|\\.|"")*")\s+columns\s*\((.*)\)\s*$`,
+	)
+
+	ordinalityColumnRegex = regexp.MustCompile("(?is)^(`[^`]+`|\\S+)\\s+for\\s+ordinality$")
+	pathColumnRegex       = regexp.MustCompile(
+		"(?is)^(`[^`]+`|\\S+)\\s+(.+?)\\s+path\\s+('(?:[^'\\\\]|\\\\.|'')*'|\"(?:[^\"\\\\]|\\\\.|\"\")*\")$",
+	)
+)
+
+// extractJSONTables replaces the JSON_TABLE table functions of a query with
+// tables named after their position, and returns the query and the nodes
+// of the functions.
+func extractJSONTables(s string) (string, []*plan.JSONTable, error) {
+	var buf bytes.Buffer
+	var tables []*plan.JSONTable
+	for i := 0; i < len(s); {
+		switch c := s[i]; {
+		case c == '\'' || c == '"' || c == '`':
+			end := quotedEnd(s, i)
+			buf.WriteString(s[i:end])
+			i = end
+		case (i == 0 || !isIdentifierChar(s[i-1])) && jsonTableRegex.MatchString(s[i:]):
+			open := i + len(jsonTableRegex.FindString(s[i:])) - 1
+			end := closingParen(s, open)
+			if end < 0 {
+				return "", nil, fmt.Errorf("unclosed JSON_TABLE in %q", s)
+			}
+
+			t, err := parseJSONTable(s[open+1 : end])
+			if err != nil {
+				return "", nil, err
+			}
+
+			fmt.Fprintf(&buf, "%s%d", jsonTablePrefix, len(tables))
+			tables = append(tables, t)
+			i = end + 1
+		default:
+			buf.WriteByte(c)
+			i++
+		}
+	}
+
+	return buf.String(), tables, nil
+}
+
+// replaceJSONTables replaces the tables named by extractJSONTables with the
+// nodes of their JSON_TABLE functions.
+func replaceJSONTables(n sql.Node, tables []*plan.JSONTable) sql.Node {
+	return n.TransformUp(func(n sql.Node) sql.Node {
+		t, ok := n.(*plan.UnresolvedTable)
+		if !ok || t.Database != "" || !strings.HasPrefix(t.Name, jsonTablePrefix) {
+			return n
+		}
+
+		i, err := strconv.Atoi(strings.TrimPrefix(t.Name, jsonTablePrefix))
+		if err != nil || i >= len(tables) {
+			return n
+		}
+
+		return tables[i]
+	})
+}
+
+// parseJSONTable parses the arguments of a JSON_TABLE function:
+//
+//	expr, path COLUMNS (name FOR ORDINALITY | name type PATH path, ...)
+func parseJSONTable(args string) (*plan.JSONTable, error) {
+	parts := splitTopLevel(args)
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("invalid JSON_TABLE arguments %q", args)
+	}
+
+	doc, err := parseExpression(parts[0])
+	if err != nil {
+		return nil, err
+	}
+
+	m := jsonTableArgsRegex.FindStringSubmatch(parts[1])
+	if m == nil {
+		return nil, fmt.Errorf("invalid JSON_TABLE arguments %q", args)
+	}
+
+	path, err := parseString(m[1])
+	if err != nil {
+		return nil, err
+	}
+
+	var columns []plan.JSONTableColumn
+	for _, def := range splitTopLevel(m[2]) {
+		c, err := parseJSONTableColumn(strings.TrimSpace(def))
+		if err != nil {
+			return nil, err
+		}
+
+		columns = append(columns, c)
+	}
+
+	return plan.NewJSONTable(doc, path, columns)
+}
+
+func parseJSONTableColumn(def string) (plan.JSONTableColumn, error) {
+	if m := ordinalityColumnRegex.FindStringSubmatch(def); m != nil {
+		return plan.JSONTableColumn{Name: unquoteIdentifier(m[1]), Type: sql.Int64, Ordinality: true}, nil
+	}
+
+	m := pathColumnRegex.FindStringSubmatch(def)
+	if m == nil {
+		return plan.JSONTableColumn{}, errUnsupportedFeature(fmt.Sprintf("JSON_TABLE column %q", def))
+	}
+
+	typ, err := ColumnType(m[2])
+	if err != nil {
+		return plan.JSONTableColumn{}, err
+	}
+
+	path, err := parseString(m[3])
+	if err != nil {
+		return plan.JSONTableColumn{}, err
+	}
+
+	return plan.JSONTableColumn{Name: unquoteIdentifier(m[1]), Type: typ, Path: path}, nil
+}
+
+// parseExpression parses a single expression.
+func parseExpression(s string) (sql.Expression, error) {
+	stmt, err := sqlparser.Parse("SELECT " + s)
+	if err != nil {
+		return nil, err
+	}
+
+	sel, ok := stmt.(*sqlparser.Select)
+	if !ok || len(sel.SelectExprs) != 1 {
+		return nil, fmt.Errorf("invalid expression %q", s)
+	}
+
+	return selectExprToExpression(sel.SelectExprs[0])
+}
+
+// parseString parses a quoted string literal.
+func parseString(s string) (string, error) {
+	e, err := parseExpression(s)
+	if err != nil {
+		return "", err
+	}
+
+	lit, ok := e.(*expression.Literal)
+	if !ok || !sql.IsText(lit.Type()) {
+		return "", fmt.Errorf("invalid string %s", s)
+	}
+
+	return lit.Eval(nil).(string), nil
+}
+
+// splitTopLevel splits a string by the commas that are not quoted or
+// enclosed in parentheses.
+func splitTopLevel(s string) []string {
+	var parts []string
+	var depth, start int
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '\'', '"', '`':
+			i = quotedEnd(s, i) - 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case ',':
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+
+	return append(parts, s[start:])
+}
+
+// closingParen returns the index of the parenthesis that closes the one at
+// the given index, or -1 if it's not closed.
+func closingParen(s string, open int) int {
+	depth := 0
+	for i := open; i < len(s); i++ {
+		switch s[i] {
+		case '\'', '"', '`':
+			i = quotedEnd(s, i) - 1
+		case '(':
+			depth++
+		case ')':
+			depth--
+			if depth == 0 {
+				return i
+			}
+		}
+	}
+
+	return -1
+}
+
+// quotedEnd returns the index after the closing quote of the quoted string
+// or identifier at the given index, or the length of s if it's not closed.
+// Quotes are escaped with backslashes or by doubling them.
+func quotedEnd(s string, start int) int {
+	q := s[start]
+	for i := start + 1; i < len(s); i++ {
+		switch {
+		case s[i] == '\\' && q != '`':
+			i++
+		case s[i] == q && i+1 < len(s) && s[i+1] == q:
+			i++
+		case s[i] == q:
+			return i + 1
+		}
+	}
+
+	return len(s)
+}
+
+func isIdentifierChar(c byte) bool {
+	return c == '_' || c == '$' || c == '.' ||
+		('0' <= c && c <= '9') || ('a' <= c && c <= 'z') || ('A' <= c && c <= 'Z')
+}
+
+func unquoteIdentifier(s string) string {
+	if len(s) > 1 && s[0] == '`' && s[len(s)-1] == '`' {
+		return strings.Replace(s[1:len(s)-1], "``", "`", -1)
+	}
+
+	return s
+}
@@ -7,13 +7,15 @@ import (
 	"strings"
 
 	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/decimal"
 	"github.com/src-d/go-mysql-server/sql/expression"
 	"github.com/src-d/go-mysql-server/sql/plan"
+	"github.com/src-d/go-vitess/sqltypes"
 	"github.com/src-d/go-vitess/vt/sqlparser"
 )
 
-const (
-	showTables = "SHOW TABLES"
+var showVariablesRegex = regexp.MustCompile(
+	`(?is)^show\s+(?:(global|session|local)\s+)?variables(?:\s+like\s+'([^']*)'|\s+like\s+"([^"]*)")?\s*$`,
 )
 
 func errUnsupported(n sqlparser.SQLNode) error {
@@ -29,14 +31,25 @@ func Parse(s string) (sql.Node, error) {
 		s = s[:len(s)-1]
 	}
 
+	n, err := parseShow(s)
+	if err != nil || n != nil {
+		return n, err
+	}
+
+	n, err = parseDescribe(s)
+	if err != nil || n != nil {
+		return n, err
+	}
+
 	// TODO implement it into the parser
-	if strings.ToUpper(s) == showTables {
-		return plan.NewShowTables(&sql.UnresolvedDatabase{}), nil
+	t := showVariablesRegex.FindStringSubmatch(s)
+	if len(t) == 4 {
+		return plan.NewShowVariables(scopeFromString(t[1]), t[2]+t[3]), nil
 	}
 
-	t := regexp.MustCompile(`^describe\s+table\s+(.*)`).FindStringSubmatch(strings.ToLower(s))
-	if len(t) == 2 && t[1] != "" {
-		return plan.NewDescribe(plan.NewUnresolvedTable(t[1])), nil
+	s, tables, err := extractJSONTables(s)
+	if err != nil {
+		return nil, err
 	}
 
 	stmt, err := sqlparser.Parse(s)
@@ -44,7 +57,12 @@ func Parse(s string) (sql.Node, error) {
 		return nil, err
 	}
 
-	return convert(stmt)
+	n, err = convert(stmt)
+	if err != nil || len(tables) == 0 {
+		return n, err
+	}
+
+	return replaceJSONTables(n, tables), nil
 }
 
 func convert(stmt sqlparser.Statement) (sql.Node, error) {
@@ -55,6 +73,144 @@ func convert(stmt sqlparser.Statement) (sql.Node, error) {
 		return convertSelect(n)
 	case *sqlparser.Insert:
 		return convertInsert(n)
+	case *sqlparser.Set:
+		return convertSet(n)
+	case *sqlparser.Show:
+		return convertShow(n)
+	case *sqlparser.Begin:
+		return plan.NewStartTransaction(), nil
+	case *sqlparser.Commit:
+		return plan.NewCommit(), nil
+	case *sqlparser.Rollback:
+		return plan.NewRollback(), nil
+	}
+}
+
+func convertSet(s *sqlparser.Set) (sql.Node, error) {
+	var vars []plan.SetVariable
+	for _, e := range s.Exprs {
+		name := e.Name.String()
+		scope := scopeFromString(s.Scope)
+
+		value, err := setValueToExpression(e.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		switch strings.ToLower(name) {
+		case "names":
+			for _, n := range []string{
+				"character_set_client",
+				"character_set_connection",
+				"character_set_results",
+			} {
+				vars = append(vars, plan.SetVariable{Name: n, Scope: scope, Value: value})
+			}
+			continue
+		case "charset":
+			for _, n := range []string{
+				"character_set_client",
+				"character_set_results",
+			} {
+				vars = append(vars, plan.SetVariable{Name: n, Scope: scope, Value: value})
+			}
+			continue
+		case "transaction":
+			level, ok := isolationLevel(value)
+			if !ok {
+				return nil, errUnsupported(e)
+			}
+
+			vars = append(vars, plan.SetVariable{
+				Name:  "transaction_isolation",
+				Scope: scope,
+				Value: expression.NewLiteral(level, sql.Text),
+			})
+			continue
+		}
+
+		if strings.HasPrefix(name, "@@") {
+			name, scope = systemVariableName(name)
+		} else if strings.HasPrefix(name, "@") {
+			vars = append(vars, plan.SetVariable{
+				Name:  strings.TrimPrefix(name, "@"),
+				User:  true,
+				Value: value,
+			})
+			continue
+		}
+
+		vars = append(vars, plan.SetVariable{
+			Name:  strings.ToLower(name),
+			Scope: scope,
+			Value: value,
+		})
+	}
+
+	return plan.NewSet(vars...), nil
+}
+
+// setValueToExpression returns the expression for the value assigned in a
+// SET statement, which is nil for DEFAULT.
+func setValueToExpression(e sqlparser.Expr) (sql.Expression, error) {
+	switch v := e.(type) {
+	case *sqlparser.Default:
+		return nil, nil
+	case *sqlparser.ColName:
+		// Keywords such as ON or SYSTEM can be used as values.
+		name := v.Name.String()
+		if !strings.HasPrefix(name, "@") && v.Qualifier.IsEmpty() {
+			return expression.NewLiteral(name, sql.Text), nil
+		}
+	}
+
+	return exprToExpression(e)
+}
+
+// isolationLevel returns the value of the transaction_isolation variable
+// for the given "ISOLATION LEVEL ..." expression.
+func isolationLevel(e sql.Expression) (string, bool) {
+	l, ok := e.(*expression.Literal)
+	if !ok {
+		return "", false
+	}
+
+	s, ok := l.Eval(nil).(string)
+	if !ok {
+		return "", false
+	}
+
+	const prefix = "isolation level "
+	s = strings.ToLower(s)
+	if !strings.HasPrefix(s, prefix) {
+		return "", false
+	}
+
+	return strings.ToUpper(strings.Replace(s[len(prefix):], " ", "-", -1)), true
+}
+
+// systemVariableName returns the name and scope of a system variable
+// reference such as @@session.autocommit.
+func systemVariableName(name string) (string, sql.SystemVariableScope) {
+	name = strings.ToLower(strings.TrimPrefix(name, "@@"))
+	parts := strings.SplitN(name, ".", 2)
+	if len(parts) == 2 {
+		if scope := scopeFromString(parts[0]); scope != sql.DefaultScope {
+			return parts[1], scope
+		}
+	}
+
+	return name, sql.DefaultScope
+}
+
+func scopeFromString(scope string) sql.SystemVariableScope {
+	switch strings.ToLower(scope) {
+	case "global":
+		return sql.GlobalScope
+	case "session", "local":
+		return sql.SessionScope
+	default:
+		return sql.DefaultScope
 	}
 }
 
@@ -66,10 +222,6 @@ func convertSelect(s *sqlparser.Select) (sql.Node, error) {
 		return nil, err
 	}
 
-	if s.Distinct != "" {
-		return nil, errUnsupportedFeature("DISTINCT")
-	}
-
 	if s.Having != nil {
 		return nil, errUnsupportedFeature("HAVING")
 	}
@@ -93,6 +245,10 @@ func convertSelect(s *sqlparser.Select) (sql.Node, error) {
 		return nil, err
 	}
 
+	if s.Distinct != "" {
+		node = plan.NewDistinct(node)
+	}
+
 	if s.Limit != nil {
 		//TODO: Add support for offset
 		node, err = limitToLimit(s.Limit.Rowcount, node)
@@ -198,13 +354,15 @@ func tableExprToTable(te sqlparser.TableExpr) (sql.Node, error) {
 		return nil, errUnsupported(te)
 	case *sqlparser.AliasedTableExpr:
 		//TODO: Add support for table alias.
-		//TODO: Add support for qualifier.
 		tn, ok := t.Expr.(sqlparser.TableName)
 		if !ok {
 			return nil, errUnsupportedFeature("non simple tables")
 		}
 
-		return plan.NewUnresolvedTable(tn.Name.String()), nil
+		return plan.NewQualifiedUnresolvedTable(
+			tn.Qualifier.String(),
+			tn.Name.String(),
+		), nil
 	}
 }
 
@@ -330,12 +488,30 @@ func exprToExpression(e sqlparser.Expr) (sql.Expression, error) {
 		case sqlparser.StrVal:
 			return expression.NewLiteral(string(v.Val), sql.Text), nil
 		case sqlparser.IntVal:
-			//TODO: Use smallest integer representation and widen later.
-			n, _ := strconv.ParseInt(string(v.Val), 10, 64)
-			return expression.NewLiteral(n, sql.Int64), nil
+			return integerLiteral(string(v.Val))
+		case sqlparser.FloatVal:
+			return floatLiteral(string(v.Val))
+		case sqlparser.BitVal:
+			n, err := strconv.ParseUint("0"+string(v.Val), 2, 64)
+			if err != nil {
+				return nil, fmt.Errorf("bit value b'%s' doesn't fit in %d bits", v.Val, sql.MaxBitLength)
+			}
+
+			// Leading zeros count in the length of the value, but they
+			// don't make it longer than the longest BIT type.
+			length := len(v.Val)
+			if length < 1 {
+				length = 1
+			} else if length > sql.MaxBitLength {
+				length = sql.MaxBitLength
+			}
+
+			return expression.NewLiteral(n, sql.Bit(length)), nil
 		case sqlparser.HexVal:
 			//TODO
 			return nil, errUnsupported(v)
+		case sqlparser.ValArg:
+			return expression.NewBindvar(strings.TrimPrefix(string(v.Val), ":")), nil
 		default:
 			//TODO
 			return nil, errUnsupported(v)
@@ -345,8 +521,46 @@ func exprToExpression(e sqlparser.Expr) (sql.Expression, error) {
 	case *sqlparser.NullVal:
 		return expression.NewLiteral(nil, sql.Null), nil
 	case *sqlparser.ColName:
+		name := v.Name.String()
+		if strings.HasPrefix(name, "@@") {
+			variable, scope := systemVariableName(name)
+			return expression.NewSystemVar(name, variable, scope), nil
+		}
+
+		if strings.HasPrefix(name, "@") {
+			return expression.NewUserVar(strings.ToLower(name[1:])), nil
+		}
+
 		//TODO: add handling of case sensitiveness.
 		return expression.NewUnresolvedColumn(v.Name.Lowered()), nil
+	case *sqlparser.CollateExpr:
+		e, err := exprToExpression(v.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		collation := strings.ToLower(v.Charset)
+		if _, err := sql.CollationCharacterSet(collation); err != nil {
+			return nil, err
+		}
+
+		return expression.NewCollate(e, collation), nil
+	case *sqlparser.ConvertExpr:
+		return convertExprToExpression(v)
+	case *sqlparser.ConvertUsingExpr:
+		e, err := exprToExpression(v.Expr)
+		if err != nil {
+			return nil, err
+		}
+
+		typ, err := textType(-1, v.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		return expression.NewCast(e, typ), nil
+	case *sqlparser.BinaryExpr:
+		return binaryExprToExpression(v)
 	case *sqlparser.FuncExpr:
 		exprs, err := selectExprsToExpressions(v.Exprs)
 		if err != nil {
@@ -358,6 +572,325 @@ func exprToExpression(e sqlparser.Expr) (sql.Expression, error) {
 	}
 }
 
+// binaryExprToExpression converts the JSON operators col->path and
+// col->>path, which are JSON_EXTRACT(col, path) and
+// JSON_UNQUOTE(JSON_EXTRACT(col, path)). Other operators are not supported.
+func binaryExprToExpression(b *sqlparser.BinaryExpr) (sql.Expression, error) {
+	switch b.Operator {
+	case sqlparser.JSONExtractOp, sqlparser.JSONUnquoteExtractOp:
+		left, err := exprToExpression(b.Left)
+		if err != nil {
+			return nil, err
+		}
+
+		right, err := exprToExpression(b.Right)
+		if err != nil {
+			return nil, err
+		}
+
+		var e sql.Expression = expression.NewJSONExtract(left, right)
+		if b.Operator == sqlparser.JSONUnquoteExtractOp {
+			e = expression.NewJSONUnquote(e)
+		}
+
+		return e, nil
+	default:
+		return nil, errUnsupported(b)
+	}
+}
+
+func convertExprToExpression(c *sqlparser.ConvertExpr) (sql.Expression, error) {
+	e, err := exprToExpression(c.Expr)
+	if err != nil {
+		return nil, err
+	}
+
+	typ, err := convertTypeToType(c.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	return expression.NewCast(e, typ), nil
+}
+
+// convertTypeToType returns the type of a CAST or CONVERT expression.
+func convertTypeToType(t *sqlparser.ConvertType) (sql.Type, error) {
+	length, err := convertTypeLength(t.Length)
+	if err != nil {
+		return nil, err
+	}
+
+	switch strings.ToLower(t.Type) {
+	case "signed":
+		return sql.Int64, nil
+	case "unsigned":
+		return sql.Uint64, nil
+	case "char":
+		return textType(length, t.Charset)
+	case "nchar":
+		return textType(length, "utf8")
+	case "binary":
+		return textType(length, sql.BinaryCharacterSet)
+	case "date":
+		return sql.Date, nil
+	case "datetime":
+		return datetimeType(length)
+	case "time":
+		return sql.Time, nil
+	case "decimal":
+		return decimalType(length, t.Scale)
+	case "json":
+		return sql.JSON, nil
+	default:
+		return nil, errUnsupported(t)
+	}
+}
+
+// integerLiteral returns the literal of an integer. Like in MySQL, it's a
+// BIGINT, or a BIGINT UNSIGNED if it doesn't fit, or a DECIMAL if it doesn't
+// fit in either.
+func integerLiteral(s string) (sql.Expression, error) {
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+		return expression.NewLiteral(n, sql.Int64), nil
+	}
+
+	if n, err := strconv.ParseUint(s, 10, 64); err == nil {
+		return expression.NewLiteral(n, sql.Uint64), nil
+	}
+
+	return decimalLiteral(s)
+}
+
+// floatLiteral returns the literal of a number with a decimal point or an
+// exponent. Like in MySQL, numbers with an exponent are DOUBLE values, and
+// the rest are exact DECIMAL values.
+func floatLiteral(s string) (sql.Expression, error) {
+	if strings.ContainsAny(s, "eE") {
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, err
+		}
+
+		return expression.NewLiteral(n, sql.Float64), nil
+	}
+
+	return decimalLiteral(s)
+}
+
+// decimalLiteral returns the literal of a DECIMAL number with the precision
+// and scale of its digits. It returns an error if there are too many digits.
+func decimalLiteral(s string) (sql.Expression, error) {
+	d, err := decimal.Parse(s)
+	if err != nil {
+		return nil, err
+	}
+
+	if d.Precision() > sql.MaxDecimalPrecision || d.Scale() > sql.MaxDecimalScale {
+		return nil, fmt.Errorf("number %s has too many digits", s)
+	}
+
+	return expression.NewLiteral(d, sql.Decimal(d.Precision(), d.Scale())), nil
+}
+
+// ColumnType parses the type of a column, as it's written in a column
+// definition, such as VARCHAR(10) or ENUM('a','b').
+func ColumnType(s string) (sql.Type, error) {
+	stmt, err := sqlparser.Parse("CREATE TABLE t (c " + s + ")")
+	if err != nil {
+		return nil, err
+	}
+
+	ddl, ok := stmt.(*sqlparser.DDL)
+	if !ok || ddl.TableSpec == nil || len(ddl.TableSpec.Columns) != 1 {
+		return nil, fmt.Errorf("invalid column type %q", s)
+	}
+
+	return columnTypeToType(&ddl.TableSpec.Columns[0].Type)
+}
+
+// columnTypeToType returns the type of a column definition.
+func columnTypeToType(t *sqlparser.ColumnType) (sql.Type, error) {
+	length, err := convertTypeLength(t.Length)
+	if err != nil {
+		return nil, err
+	}
+
+	unsigned := bool(t.Unsigned)
+	switch strings.ToLower(t.Type) {
+	case "bool", "boolean":
+		return sql.Boolean, nil
+	case "tinyint":
+		return integerType(unsigned, sql.Int8, sql.Uint8), nil
+	case "smallint":
+		return integerType(unsigned, sql.Int16, sql.Uint16), nil
+	case "mediumint":
+		return integerType(unsigned, sql.Int24, sql.Uint24), nil
+	case "int", "integer":
+		return integerType(unsigned, sql.Int32, sql.Uint32), nil
+	case "bigint":
+		return integerType(unsigned, sql.Int64, sql.Uint64), nil
+	case "float":
+		return sql.Float32, nil
+	case "double", "real":
+		return sql.Float64, nil
+	case "decimal", "numeric":
+		return decimalType(length, t.Scale)
+	case "bit":
+		if length < 0 {
+			length = 1
+		}
+
+		if length < 1 || length > sql.MaxBitLength {
+			return nil, fmt.Errorf("invalid BIT(%d) type", length)
+		}
+
+		return sql.Bit(int(length)), nil
+	case "char":
+		if length < 0 {
+			length = 1
+		}
+		return sql.CreateString(sqltypes.Char, length, t.Charset, t.Collate)
+	case "varchar":
+		if length < 0 {
+			return nil, fmt.Errorf("missing VARCHAR length")
+		}
+		return sql.CreateString(sqltypes.VarChar, length, t.Charset, t.Collate)
+	case "tinytext":
+		return sql.CreateString(sqltypes.Text, sql.TinyTextLength, t.Charset, t.Collate)
+	case "text":
+		return sql.CreateString(sqltypes.Text, sql.TextLength, t.Charset, t.Collate)
+	case "mediumtext":
+		return sql.CreateString(sqltypes.Text, sql.MediumTextLength, t.Charset, t.Collate)
+	case "longtext":
+		return sql.CreateString(sqltypes.Text, sql.LongTextLength, t.Charset, t.Collate)
+	case "binary":
+		if length < 0 {
+			length = 1
+		}
+		return sql.CreateBinary(sqltypes.Binary, length)
+	case "varbinary":
+		if length < 0 {
+			return nil, fmt.Errorf("missing VARBINARY length")
+		}
+		return sql.CreateBinary(sqltypes.VarBinary, length)
+	case "tinyblob":
+		return sql.TinyBlob, nil
+	case "blob":
+		return sql.Blob, nil
+	case "mediumblob":
+		return sql.MediumBlob, nil
+	case "longblob":
+		return sql.LongBlob, nil
+	case "enum", "set":
+		values := make([]string, len(t.EnumValues))
+		for i, v := range t.EnumValues {
+			s, err := parseString(v)
+			if err != nil {
+				return nil, err
+			}
+			values[i] = s
+		}
+
+		if strings.ToLower(t.Type) == "enum" {
+			return sql.CreateEnum(values)
+		}
+		return sql.CreateSet(values)
+	case "date":
+		return sql.Date, nil
+	case "datetime":
+		return datetimeType(length)
+	case "timestamp":
+		return sql.Timestamp, nil
+	case "time":
+		return sql.Time, nil
+	case "year":
+		return sql.Year, nil
+	case "json":
+		return sql.JSON, nil
+	default:
+		return nil, errUnsupportedFeature(fmt.Sprintf("column type %s", t.Type))
+	}
+}
+
+func integerType(unsigned bool, signed, unsignedType sql.Type) sql.Type {
+	if unsigned {
+		return unsignedType
+	}
+
+	return signed
+}
+
+// datetimeType returns the DATETIME type with the given precision, which is
+// 0 if it's negative.
+func datetimeType(length int64) (sql.Type, error) {
+	if length < 0 {
+		length = 0
+	}
+
+	if length > sql.MaxDatetimePrecision {
+		return nil, fmt.Errorf("invalid DATETIME precision %d", length)
+	}
+
+	return sql.Datetime(int(length)), nil
+}
+
+// decimalType returns the DECIMAL type with the given precision and scale.
+// Without precision, it's DECIMAL(10,0).
+func decimalType(length int64, s *sqlparser.SQLVal) (sql.Type, error) {
+	precision, scale := int64(10), int64(0)
+	if length >= 0 {
+		precision = length
+	}
+
+	if s != nil {
+		var err error
+		if scale, err = convertTypeLength(s); err != nil {
+			return nil, err
+		}
+	}
+
+	if precision < 1 || precision > sql.MaxDecimalPrecision ||
+		scale > sql.MaxDecimalScale || scale > precision {
+		return nil, fmt.Errorf("invalid DECIMAL(%d,%d) type", precision, scale)
+	}
+
+	return sql.Decimal(int(precision), int(scale)), nil
+}
+
+// convertTypeLength returns the length of the type of a CAST or CONVERT
+// expression, or -1 if it has no length.
+func convertTypeLength(v *sqlparser.SQLVal) (int64, error) {
+	if v == nil {
+		return -1, nil
+	}
+
+	n, err := strconv.ParseInt(string(v.Val), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid length %s", v.Val)
+	}
+
+	return n, nil
+}
+
+// textType returns the type of strings of the given character set that
+// strings are converted to. Without length, it's the longest string type.
+func textType(length int64, charset string) (sql.Type, error) {
+	charset = strings.ToLower(charset)
+	if charset == sql.BinaryCharacterSet {
+		if length < 0 {
+			return sql.LongBlob, nil
+		}
+
+		return sql.CreateBinary(sqltypes.Binary, length)
+	}
+
+	if length < 0 {
+		return sql.CreateString(sqltypes.Text, sql.LongTextLength, charset, "")
+	}
+
+	return sql.CreateString(sqltypes.VarChar, length, charset, "")
+}
+
 func isExprToExpression(c *sqlparser.IsExpr) (sql.Expression, error) {
 	e, err := exprToExpression(c.Expr)
 	if err != nil {
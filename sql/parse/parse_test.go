@@ -1,12 +1,15 @@
 package parse
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/decimal"
 	"github.com/src-d/go-mysql-server/sql/expression"
 	"github.com/src-d/go-mysql-server/sql/plan"
 
+	"github.com/src-d/go-vitess/sqltypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,6 +24,13 @@ var fixtures = map[string]sql.Node{
 		},
 		plan.NewUnresolvedTable("foo"),
 	),
+	`SELECT foo, bar FROM mydb.foo;`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("foo"),
+			expression.NewUnresolvedColumn("bar"),
+		},
+		plan.NewQualifiedUnresolvedTable("mydb", "foo"),
+	),
 	`SELECT foo IS NULL, bar IS NOT NULL FROM foo;`: plan.NewProject(
 		[]sql.Expression{
 			expression.NewIsNull(expression.NewUnresolvedColumn("foo")),
@@ -188,6 +198,273 @@ var fixtures = map[string]sql.Node{
 		}}),
 		[]string{"col1", "col2"},
 	),
+	`SET autocommit = ON, @@session.sql_mode = 'ANSI', @@GLOBAL.net_read_timeout = 10, @foo = 1`: plan.NewSet(
+		plan.SetVariable{
+			Name:  "autocommit",
+			Value: expression.NewLiteral("on", sql.Text),
+		},
+		plan.SetVariable{
+			Name:  "sql_mode",
+			Scope: sql.SessionScope,
+			Value: expression.NewLiteral("ANSI", sql.Text),
+		},
+		plan.SetVariable{
+			Name:  "net_read_timeout",
+			Scope: sql.GlobalScope,
+			Value: expression.NewLiteral(int64(10), sql.Int64),
+		},
+		plan.SetVariable{
+			Name:  "foo",
+			User:  true,
+			Value: expression.NewLiteral(int64(1), sql.Int64),
+		},
+	),
+	`SET GLOBAL wait_timeout = DEFAULT`: plan.NewSet(
+		plan.SetVariable{Name: "wait_timeout", Scope: sql.GlobalScope},
+	),
+	`SET NAMES utf8mb4`: plan.NewSet(
+		plan.SetVariable{
+			Name:  "character_set_client",
+			Value: expression.NewLiteral("utf8mb4", sql.Text),
+		},
+		plan.SetVariable{
+			Name:  "character_set_connection",
+			Value: expression.NewLiteral("utf8mb4", sql.Text),
+		},
+		plan.SetVariable{
+			Name:  "character_set_results",
+			Value: expression.NewLiteral("utf8mb4", sql.Text),
+		},
+	),
+	`SET SESSION TRANSACTION ISOLATION LEVEL READ COMMITTED`: plan.NewSet(
+		plan.SetVariable{
+			Name:  "transaction_isolation",
+			Scope: sql.SessionScope,
+			Value: expression.NewLiteral("READ-COMMITTED", sql.Text),
+		},
+	),
+	`SELECT @@max_allowed_packet, @@Session.autocommit, @foo`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewSystemVar("@@max_allowed_packet", "max_allowed_packet", sql.DefaultScope),
+			expression.NewSystemVar("@@Session.autocommit", "autocommit", sql.SessionScope),
+			expression.NewUserVar("foo"),
+		},
+		plan.NewUnresolvedTable("dual"),
+	),
+	`SELECT foo FROM t1 WHERE bar > 1.5`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("foo"),
+		},
+		plan.NewFilter(
+			expression.NewGreaterThan(
+				expression.NewUnresolvedColumn("bar"),
+				expression.NewLiteral(decimal.MustParse("1.5"), sql.Decimal(2, 1)),
+			),
+			plan.NewUnresolvedTable("t1"),
+		),
+	),
+	`SELECT foo FROM t1 WHERE bar > 1.5e0`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("foo"),
+		},
+		plan.NewFilter(
+			expression.NewGreaterThan(
+				expression.NewUnresolvedColumn("bar"),
+				expression.NewLiteral(float64(1.5), sql.Float64),
+			),
+			plan.NewUnresolvedTable("t1"),
+		),
+	),
+	`SELECT 18446744073709551615, 18446744073709551616, 1234567890123456789.01`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewLiteral(uint64(18446744073709551615), sql.Uint64),
+			expression.NewLiteral(decimal.MustParse("18446744073709551616"), sql.Decimal(20, 0)),
+			expression.NewLiteral(decimal.MustParse("1234567890123456789.01"), sql.Decimal(21, 2)),
+		},
+		plan.NewUnresolvedTable("dual"),
+	),
+	`SELECT foo FROM t1 WHERE bar = ?`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("foo"),
+		},
+		plan.NewFilter(
+			expression.NewEquals(
+				expression.NewUnresolvedColumn("bar"),
+				expression.NewBindvar("v1"),
+			),
+			plan.NewUnresolvedTable("t1"),
+		),
+	),
+	`SELECT foo FROM t1 WHERE :bar < bar`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("foo"),
+		},
+		plan.NewFilter(
+			expression.NewLessThan(
+				expression.NewBindvar("bar"),
+				expression.NewUnresolvedColumn("bar"),
+			),
+			plan.NewUnresolvedTable("t1"),
+		),
+	),
+	`INSERT INTO t1 (col1, col2) VALUES (?, ?)`: plan.NewInsertInto(
+		plan.NewUnresolvedTable("t1"),
+		plan.NewValues([][]sql.Expression{{
+			expression.NewBindvar("v1"),
+			expression.NewBindvar("v2"),
+		}}),
+		[]string{"col1", "col2"},
+	),
+	`BEGIN`:                             plan.NewStartTransaction(),
+	`START TRANSACTION`:                 plan.NewStartTransaction(),
+	`COMMIT`:                            plan.NewCommit(),
+	`ROLLBACK`:                          plan.NewRollback(),
+	`SHOW VARIABLES`:                    plan.NewShowVariables(sql.DefaultScope, ""),
+	`SHOW GLOBAL VARIABLES LIKE 'net%'`: plan.NewShowVariables(sql.GlobalScope, "net%"),
+	`show session variables like "sql_mode";`: plan.NewShowVariables(sql.SessionScope, "sql_mode"),
+	`SHOW TABLES`:    plan.NewShowTables(sql.NewUnresolvedDatabase(""), false, ""),
+	`show  tables ;`: plan.NewShowTables(sql.NewUnresolvedDatabase(""), false, ""),
+	`SHOW FULL TABLES FROM mydb LIKE 'foo%'`: plan.NewShowTables(
+		sql.NewUnresolvedDatabase("mydb"), true, "foo%",
+	),
+	`SHOW TABLES WHERE Table_type = 'VIEW'`: plan.NewFilter(
+		expression.NewEquals(
+			expression.NewUnresolvedColumn("table_type"),
+			expression.NewLiteral("VIEW", sql.Text),
+		),
+		plan.NewShowTables(sql.NewUnresolvedDatabase(""), false, ""),
+	),
+	`SHOW DATABASES`:              plan.NewShowDatabases(""),
+	`SHOW SCHEMAS LIKE 'my%'`:     plan.NewShowDatabases("my%"),
+	`SHOW TABLE STATUS IN mydb`:   plan.NewShowTableStatus(sql.NewUnresolvedDatabase("mydb"), ""),
+	`SHOW TABLE STATUS LIKE 'f%'`: plan.NewShowTableStatus(sql.NewUnresolvedDatabase(""), "f%"),
+	`SHOW COLUMNS FROM foo`: plan.NewShowColumns(
+		plan.NewUnresolvedTable("foo"), false, "",
+	),
+	`SHOW FULL FIELDS FROM foo FROM mydb LIKE 'a%'`: plan.NewShowColumns(
+		plan.NewQualifiedUnresolvedTable("mydb", "foo"), true, "a%",
+	),
+	`SHOW CREATE TABLE mydb.foo`: plan.NewShowCreateTable(
+		plan.NewQualifiedUnresolvedTable("mydb", "foo"),
+	),
+	`DESCRIBE foo`:       plan.NewDescribe(plan.NewUnresolvedTable("foo")),
+	`desc mydb.foo`:      plan.NewDescribe(plan.NewQualifiedUnresolvedTable("mydb", "foo")),
+	`describe table foo`: plan.NewDescribe(plan.NewUnresolvedTable("foo")),
+	`EXPLAIN foo`:        plan.NewDescribe(plan.NewUnresolvedTable("foo")),
+	`EXPLAIN SELECT foo FROM t`: plan.NewExplain(plan.NewProject(
+		[]sql.Expression{expression.NewUnresolvedColumn("foo")},
+		plan.NewUnresolvedTable("t"),
+	)),
+	`DESCRIBE SELECT foo FROM t`: plan.NewExplain(plan.NewProject(
+		[]sql.Expression{expression.NewUnresolvedColumn("foo")},
+		plan.NewUnresolvedTable("t"),
+	)),
+	`SHOW INDEX FROM foo`: plan.NewShowIndex(plan.NewUnresolvedTable("foo")),
+	`SHOW KEYS IN foo IN mydb WHERE Key_name = 'PRIMARY'`: plan.NewFilter(
+		expression.NewEquals(
+			expression.NewUnresolvedColumn("key_name"),
+			expression.NewLiteral("PRIMARY", sql.Text),
+		),
+		plan.NewShowIndex(plan.NewQualifiedUnresolvedTable("mydb", "foo")),
+	),
+	`SELECT foo FROM t WHERE flags = b'101'`: plan.NewProject(
+		[]sql.Expression{expression.NewUnresolvedColumn("foo")},
+		plan.NewFilter(
+			expression.NewEquals(
+				expression.NewUnresolvedColumn("flags"),
+				expression.NewLiteral(uint64(5), sql.Bit(3)),
+			),
+			plan.NewUnresolvedTable("t"),
+		),
+	),
+	`SELECT CAST(foo AS SIGNED), CONVERT(bar, CHAR(3)), CAST(baz AS DECIMAL(5,2)) FROM t`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewCast(expression.NewUnresolvedColumn("foo"), sql.Int64),
+			expression.NewCast(expression.NewUnresolvedColumn("bar"), sql.Varchar(3)),
+			expression.NewCast(expression.NewUnresolvedColumn("baz"), sql.Decimal(5, 2)),
+		},
+		plan.NewUnresolvedTable("t"),
+	),
+	`SELECT CAST(foo AS BINARY), CAST(bar AS DATETIME(3)), CONVERT(baz USING utf8mb4) FROM t`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewCast(expression.NewUnresolvedColumn("foo"), sql.LongBlob),
+			expression.NewCast(expression.NewUnresolvedColumn("bar"), sql.Datetime(3)),
+			expression.NewCast(
+				expression.NewUnresolvedColumn("baz"),
+				mustCreateString(sql.CreateString(sqltypes.Text, sql.LongTextLength, "utf8mb4", "")),
+			),
+		},
+		plan.NewUnresolvedTable("t"),
+	),
+	`SELECT DISTINCT foo FROM t WHERE bar = 'x' COLLATE utf8mb4_bin`: plan.NewDistinct(
+		plan.NewProject(
+			[]sql.Expression{expression.NewUnresolvedColumn("foo")},
+			plan.NewFilter(
+				expression.NewEquals(
+					expression.NewUnresolvedColumn("bar"),
+					expression.NewCollate(expression.NewLiteral("x", sql.Text), "utf8mb4_bin"),
+				),
+				plan.NewUnresolvedTable("t"),
+			),
+		),
+	),
+	`SELECT doc->'$.name', doc->>'$.phones[0]' FROM t WHERE JSON_CONTAINS(doc, '1', '$.ids')`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewJSONExtract(
+				expression.NewUnresolvedColumn("doc"),
+				expression.NewLiteral("$.name", sql.Text),
+			),
+			expression.NewJSONUnquote(expression.NewJSONExtract(
+				expression.NewUnresolvedColumn("doc"),
+				expression.NewLiteral("$.phones[0]", sql.Text),
+			)),
+		},
+		plan.NewFilter(
+			expression.NewUnresolvedFunction("json_contains", false,
+				expression.NewUnresolvedColumn("doc"),
+				expression.NewLiteral("1", sql.Text),
+				expression.NewLiteral("$.ids", sql.Text),
+			),
+			plan.NewUnresolvedTable("t"),
+		),
+	),
+	`SELECT n, name FROM JSON_TABLE('[{"name": "a"}]', '$[*]' COLUMNS (n FOR ORDINALITY, ` + "`name`" + ` VARCHAR(10) PATH '$.name', tags JSON PATH "$.tags")) AS t WHERE name <> 'json_table('`: plan.NewProject(
+		[]sql.Expression{
+			expression.NewUnresolvedColumn("n"),
+			expression.NewUnresolvedColumn("name"),
+		},
+		plan.NewFilter(
+			expression.NewNot(expression.NewEquals(
+				expression.NewUnresolvedColumn("name"),
+				expression.NewLiteral("json_table(", sql.Text),
+			)),
+			mustJSONTable(plan.NewJSONTable(
+				expression.NewLiteral(`[{"name": "a"}]`, sql.Text),
+				"$[*]",
+				[]plan.JSONTableColumn{
+					{Name: "n", Type: sql.Int64, Ordinality: true},
+					{Name: "name", Type: mustCreateString(sql.CreateString(sqltypes.VarChar, 10, "", "")), Path: "$.name"},
+					{Name: "tags", Type: sql.JSON, Path: "$.tags"},
+				},
+			)),
+		),
+	),
+}
+
+func mustJSONTable(t *plan.JSONTable, err error) *plan.JSONTable {
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+func mustCreateString(t sql.StringType, err error) sql.StringType {
+	if err != nil {
+		panic(err)
+	}
+
+	return t
 }
 
 func TestParse(t *testing.T) {
@@ -202,3 +479,30 @@ func TestParse(t *testing.T) {
 
 	}
 }
+
+func TestParseJSONTableErrors(t *testing.T) {
+	for _, query := range []string{
+		`SELECT * FROM JSON_TABLE('[]', '$[*]' COLUMNS (a INT PATH '$.a')`,
+		`SELECT * FROM JSON_TABLE('[]' COLUMNS (a INT PATH '$.a')) AS t`,
+		`SELECT * FROM JSON_TABLE('[]', '$[' COLUMNS (a INT PATH '$.a')) AS t`,
+		`SELECT * FROM JSON_TABLE('[]', '$[*]' COLUMNS (a INT EXISTS PATH '$.a')) AS t`,
+		`SELECT * FROM JSON_TABLE('[]', '$[*]' COLUMNS (a FOO PATH '$.a')) AS t`,
+	} {
+		t.Run(query, func(t *testing.T) {
+			_, err := Parse(query)
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestParseNumberErrors(t *testing.T) {
+	for _, query := range []string{
+		"SELECT 1" + strings.Repeat("0", 65),
+		"SELECT 0." + strings.Repeat("1", 31),
+	} {
+		t.Run(query, func(t *testing.T) {
+			_, err := Parse(query)
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,133 @@
+package parse
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/plan"
+	"github.com/src-d/go-vitess/vt/sqlparser"
+)
+
+// showRegex matches the SHOW statements whose options sqlparser skips. They
+// have the same options as SHOW TABLES or SHOW COLUMNS, so they are parsed
+// as one of them.
+var showRegex = regexp.MustCompile(
+	`(?is)^\s*show\s+(databases|schemas|table\s+status|index|indexes|keys)\b\s*(.*)$`,
+)
+
+var showTableRegex = regexp.MustCompile(`(?is)^(?:from|in)\s+(.*)$`)
+
+// parseShow parses the SHOW statements matched by showRegex. It returns a
+// nil node if the statement is not one of them.
+func parseShow(s string) (sql.Node, error) {
+	t := showRegex.FindStringSubmatch(s)
+	if len(t) != 3 {
+		return nil, nil
+	}
+
+	kind := strings.ToLower(strings.Join(strings.Fields(t[1]), " "))
+	rest := t[2]
+
+	var query string
+	switch kind {
+	case "index", "indexes", "keys":
+		m := showTableRegex.FindStringSubmatch(rest)
+		if len(m) != 2 {
+			return nil, errUnsupportedFeature("SHOW " + strings.ToUpper(kind) + " without table")
+		}
+		query = "SHOW COLUMNS FROM " + m[1]
+	default:
+		query = "SHOW TABLES " + rest
+	}
+
+	stmt, err := sqlparser.Parse(query)
+	if err != nil {
+		return nil, err
+	}
+
+	show, ok := stmt.(*sqlparser.Show)
+	if !ok || show.ShowTablesOpt == nil {
+		return nil, errUnsupported(stmt)
+	}
+
+	opt := show.ShowTablesOpt
+	switch kind {
+	case "databases", "schemas":
+		if opt.DbName != "" {
+			return nil, errUnsupported(show)
+		}
+
+		return showFilter(plan.NewShowDatabases(pattern(opt)), opt)
+	case "table status":
+		return showFilter(plan.NewShowTableStatus(
+			sql.NewUnresolvedDatabase(opt.DbName),
+			pattern(opt),
+		), opt)
+	default:
+		if pattern(opt) != "" {
+			return nil, errUnsupportedFeature("LIKE in SHOW " + strings.ToUpper(kind))
+		}
+
+		return showFilter(plan.NewShowIndex(showTable(show)), opt)
+	}
+}
+
+func convertShow(s *sqlparser.Show) (sql.Node, error) {
+	opt := s.ShowTablesOpt
+	switch strings.ToLower(s.Type) {
+	case "tables":
+		if opt == nil {
+			return nil, errUnsupported(s)
+		}
+
+		return showFilter(plan.NewShowTables(
+			sql.NewUnresolvedDatabase(opt.DbName),
+			opt.Full != "",
+			pattern(opt),
+		), opt)
+	case "columns", "fields":
+		return showFilter(plan.NewShowColumns(
+			showTable(s),
+			opt.Full != "",
+			pattern(opt),
+		), opt)
+	case "create table":
+		return plan.NewShowCreateTable(plan.NewQualifiedUnresolvedTable(
+			s.Table.Qualifier.String(),
+			s.Table.Name.String(),
+		)), nil
+	default:
+		return nil, errUnsupported(s)
+	}
+}
+
+// showTable returns the table of a SHOW COLUMNS statement, which may be in
+// the database given with a second FROM.
+func showTable(s *sqlparser.Show) sql.Node {
+	db := s.OnTable.Qualifier.String()
+	if s.ShowTablesOpt.DbName != "" {
+		db = s.ShowTablesOpt.DbName
+	}
+
+	return plan.NewQualifiedUnresolvedTable(db, s.OnTable.Name.String())
+}
+
+// pattern returns the LIKE pattern of a SHOW statement, if any.
+func pattern(opt *sqlparser.ShowTablesOpt) string {
+	if opt.Filter == nil {
+		return ""
+	}
+
+	return opt.Filter.Like
+}
+
+// showFilter returns the node of a SHOW statement filtered by its WHERE
+// clause, if any.
+func showFilter(n sql.Node, opt *sqlparser.ShowTablesOpt) (sql.Node, error) {
+	if opt.Filter == nil || opt.Filter.Filter == nil {
+		return n, nil
+	}
+
+	return whereToFilter(&sqlparser.Where{Expr: opt.Filter.Filter}, n)
+}
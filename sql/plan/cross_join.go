@@ -27,13 +27,13 @@ func (p *CrossJoin) Resolved() bool {
 	return p.Left.Resolved() && p.Right.Resolved()
 }
 
-func (p *CrossJoin) RowIter() (sql.RowIter, error) {
-	li, err := p.Left.RowIter()
+func (p *CrossJoin) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	li, err := p.Left.RowIter(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	ri, err := p.Right.RowIter()
+	ri, err := p.Right.RowIter(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -60,6 +60,13 @@ func (p *CrossJoin) TransformExpressionsUp(f func(sql.Expression) sql.Expression
 	return NewCrossJoin(ln, rn)
 }
 
+func (p *CrossJoin) String() string {
+	pr := sql.NewTreePrinter()
+	pr.WriteNode("CrossJoin")
+	pr.WriteChildren(nodeString(p.Left), nodeString(p.Right))
+	return pr.String()
+}
+
 type crossJoinIterator struct {
 	li sql.RowIter
 	ri sql.RowIter
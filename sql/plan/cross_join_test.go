@@ -26,6 +26,7 @@ var rSchema = sql.Schema{
 
 func TestCrossJoin(t *testing.T) {
 	assert := assert.New(t)
+	ctx := sql.NewEmptyContext()
 
 	resultSchema := sql.Schema{
 		{Name: "lcol1", Type: sql.Text},
@@ -47,7 +48,7 @@ func TestCrossJoin(t *testing.T) {
 
 	assert.Equal(resultSchema, j.Schema())
 
-	iter, err := j.RowIter()
+	iter, err := j.RowIter(ctx)
 	assert.Nil(err)
 	assert.NotNil(iter)
 
@@ -94,6 +95,7 @@ func TestCrossJoin(t *testing.T) {
 
 func TestCrossJoin_Empty(t *testing.T) {
 	assert := assert.New(t)
+	ctx := sql.NewEmptyContext()
 
 	ltable := mem.NewTable("left", lSchema)
 	rtable := mem.NewTable("right", rSchema)
@@ -101,7 +103,7 @@ func TestCrossJoin_Empty(t *testing.T) {
 
 	j := NewCrossJoin(ltable, rtable)
 
-	iter, err := j.RowIter()
+	iter, err := j.RowIter(ctx)
 	assert.Nil(err)
 	assert.NotNil(iter)
 
@@ -115,7 +117,7 @@ func TestCrossJoin_Empty(t *testing.T) {
 
 	j = NewCrossJoin(ltable, rtable)
 
-	iter, err = j.RowIter()
+	iter, err = j.RowIter(ctx)
 	assert.Nil(err)
 	assert.NotNil(iter)
 
@@ -125,8 +127,8 @@ func TestCrossJoin_Empty(t *testing.T) {
 }
 
 func insertData(assert *assert.Assertions, table *mem.Table) {
-	err := table.Insert(sql.NewRow("col1_1", "col2_1", int32(1111), int64(2222)))
+	err := table.Insert(sql.NewEmptyContext(), sql.NewRow("col1_1", "col2_1", int32(1111), int64(2222)))
 	assert.Nil(err)
-	err = table.Insert(sql.NewRow("col1_2", "col2_2", int32(3333), int64(4444)))
+	err = table.Insert(sql.NewEmptyContext(), sql.NewRow("col1_2", "col2_2", int32(3333), int64(4444)))
 	assert.Nil(err)
 }
@@ -1,11 +1,11 @@
 package plan
 
 import (
-	"io"
-
 	"github.com/src-d/go-mysql-server/sql"
 )
 
+// Describe is a node that describes the columns of the schema of its child
+// as MySQL does, with the same output as SHOW COLUMNS.
 type Describe struct {
 	UnaryNode
 }
@@ -15,17 +15,11 @@ func NewDescribe(child sql.Node) *Describe {
 }
 
 func (d *Describe) Schema() sql.Schema {
-	return sql.Schema{{
-		Name: "name",
-		Type: sql.Text,
-	}, {
-		Name: "type",
-		Type: sql.Text,
-	}}
+	return NewShowColumns(d.Child, false, "").Schema()
 }
 
-func (d *Describe) RowIter() (sql.RowIter, error) {
-	return &describeIter{schema: d.Child.Schema()}, nil
+func (d *Describe) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	return NewShowColumns(d.Child, false, "").RowIter(ctx)
 }
 
 func (d *Describe) TransformUp(f func(sql.Node) sql.Node) sql.Node {
@@ -42,21 +36,9 @@ func (d *Describe) TransformExpressionsUp(f func(sql.Expression) sql.Expression)
 	return n
 }
 
-type describeIter struct {
-	schema sql.Schema
-	i      int
-}
-
-func (i *describeIter) Next() (sql.Row, error) {
-	if i.i >= len(i.schema) {
-		return nil, io.EOF
-	}
-
-	f := i.schema[i.i]
-	i.i++
-	return sql.NewRow(f.Name, f.Type.Type().String()), nil
-}
-
-func (i *describeIter) Close() error {
-	return nil
+func (d *Describe) String() string {
+	pr := sql.NewTreePrinter()
+	pr.WriteNode("Describe")
+	pr.WriteChildren(nodeString(d.Child))
+	return pr.String()
 }
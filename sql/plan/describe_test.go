@@ -11,6 +11,7 @@ import (
 
 func TestDescribe(t *testing.T) {
 	assert := assert.New(t)
+	ctx := sql.NewEmptyContext()
 
 	table := mem.NewTable("test", sql.Schema{
 		{Name: "c1", Type: sql.Text},
@@ -18,17 +19,17 @@ func TestDescribe(t *testing.T) {
 	})
 
 	d := NewDescribe(table)
-	iter, err := d.RowIter()
+	iter, err := d.RowIter(ctx)
 	assert.Nil(err)
 	assert.NotNil(iter)
 
 	n, err := iter.Next()
 	assert.Nil(err)
-	assert.Equal(sql.NewRow("c1", "TEXT"), n)
+	assert.Equal(sql.NewRow("c1", "text", "NO", "", nil, ""), n)
 
 	n, err = iter.Next()
 	assert.Nil(err)
-	assert.Equal(sql.NewRow("c2", "INT32"), n)
+	assert.Equal(sql.NewRow("c2", "int", "NO", "", nil, ""), n)
 
 	n, err = iter.Next()
 	assert.Equal(io.EOF, err)
@@ -37,10 +38,11 @@ func TestDescribe(t *testing.T) {
 
 func TestDescribe_Empty(t *testing.T) {
 	assert := assert.New(t)
+	ctx := sql.NewEmptyContext()
 
 	d := NewDescribe(NewUnresolvedTable("test_table"))
 
-	iter, err := d.RowIter()
+	iter, err := d.RowIter(ctx)
 	assert.Nil(err)
 	assert.NotNil(iter)
 
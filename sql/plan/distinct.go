@@ -0,0 +1,88 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// Distinct is a node that returns the rows of its child without the ones
+// that duplicate a previous row. Rows are duplicated if the types of the
+// columns compare their values as equal, so strings that only differ in
+// case are duplicated with case-insensitive collations.
+type Distinct struct {
+	UnaryNode
+}
+
+// NewDistinct creates a new Distinct node.
+func NewDistinct(child sql.Node) *Distinct {
+	return &Distinct{UnaryNode{child}}
+}
+
+// RowIter implements the Node interface.
+func (d *Distinct) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	iter, err := d.Child.RowIter(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return &distinctIter{
+		schema: d.Child.Schema(),
+		iter:   iter,
+		seen:   make(map[string]struct{}),
+	}, nil
+}
+
+// TransformUp implements the Transformable interface.
+func (d *Distinct) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(NewDistinct(d.Child.TransformUp(f)))
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (d *Distinct) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return NewDistinct(d.Child.TransformExpressionsUp(f))
+}
+
+func (d *Distinct) String() string {
+	pr := sql.NewTreePrinter()
+	pr.WriteNode("Distinct")
+	pr.WriteChildren(nodeString(d.Child))
+	return pr.String()
+}
+
+type distinctIter struct {
+	schema sql.Schema
+	iter   sql.RowIter
+	seen   map[string]struct{}
+}
+
+func (i *distinctIter) Next() (sql.Row, error) {
+	for {
+		row, err := i.iter.Next()
+		if err != nil {
+			return nil, err
+		}
+
+		vals := make([]string, len(row))
+		for j, v := range row {
+			var typ sql.Type = sql.Null
+			if j < len(i.schema) {
+				typ = i.schema[j].Type
+			}
+			vals[j] = fmt.Sprintf("%#v", groupingValue(typ, v))
+		}
+
+		key := strings.Join(vals, ",")
+		if _, ok := i.seen[key]; ok {
+			continue
+		}
+
+		i.seen[key] = struct{}{}
+		return row, nil
+	}
+}
+
+func (i *distinctIter) Close() error {
+	return i.iter.Close()
+}
@@ -0,0 +1,47 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/mem"
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/src-d/go-vitess/sqltypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDistinct(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	bin, err := sql.CreateString(sqltypes.VarChar, 10, "", "utf8_bin")
+	require.NoError(err)
+
+	child := mem.NewTable("test", sql.Schema{
+		{Name: "name", Type: sql.Text, Nullable: true},
+		{Name: "code", Type: bin},
+	})
+	for _, row := range []sql.Row{
+		sql.NewRow("john", "a"),
+		sql.NewRow("John", "a"),
+		sql.NewRow("john", "A"),
+		sql.NewRow("café", "a"),
+		sql.NewRow("CAFE", "a"),
+		sql.NewRow(nil, "a"),
+		sql.NewRow(nil, "a"),
+	} {
+		require.NoError(child.Insert(ctx, row))
+	}
+
+	d := NewDistinct(child)
+	require.Equal(child.Schema(), d.Schema())
+
+	rows, err := sql.NodeToRows(ctx, d)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{"john", "a"},
+		{"john", "A"},
+		{"café", "a"},
+		{nil, "a"},
+	}, rows)
+}
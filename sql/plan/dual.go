@@ -0,0 +1,51 @@
+package plan
+
+import "github.com/src-d/go-mysql-server/sql"
+
+// Dual is the table of queries without a FROM clause, such as
+// SELECT @@autocommit. It has a single row.
+type Dual struct{}
+
+// NewDual creates a new Dual node.
+func NewDual() *Dual {
+	return &Dual{}
+}
+
+// Name implements the Nameable interface.
+func (*Dual) Name() string {
+	return "dual"
+}
+
+// Resolved implements the Resolvable interface.
+func (*Dual) Resolved() bool {
+	return true
+}
+
+// Children implements the Node interface.
+func (*Dual) Children() []sql.Node {
+	return nil
+}
+
+// Schema implements the Node interface.
+func (*Dual) Schema() sql.Schema {
+	return sql.Schema{{Name: "dummy", Type: sql.Text, Nullable: false}}
+}
+
+// RowIter implements the Node interface.
+func (*Dual) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	return sql.RowsToRowIter(sql.NewRow("x")), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (d *Dual) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(NewDual())
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (d *Dual) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return d
+}
+
+func (*Dual) String() string {
+	return "Dual"
+}
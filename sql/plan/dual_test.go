@@ -0,0 +1,20 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDual(t *testing.T) {
+	require := require.New(t)
+
+	n := NewDual()
+	require.True(n.Resolved())
+	require.Equal("dual", n.Name())
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), n)
+	require.NoError(err)
+	require.Equal([]sql.Row{{"x"}}, rows)
+}
@@ -0,0 +1,77 @@
+package plan
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// Explain is a node that shows the plan of a query as a tree, with a row for
+// every line. The query is analyzed but it's not executed.
+type Explain struct {
+	UnaryNode
+}
+
+// NewExplain creates a new Explain node that shows the plan of the given
+// query.
+func NewExplain(child sql.Node) *Explain {
+	return &Explain{UnaryNode{child}}
+}
+
+// Schema implements the Node interface.
+func (*Explain) Schema() sql.Schema {
+	return sql.Schema{{Name: "plan", Type: sql.Text}}
+}
+
+// RowIter implements the Node interface.
+func (p *Explain) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	var rows []sql.Row
+	for _, line := range strings.Split(nodeString(p.Child), "\n") {
+		rows = append(rows, sql.NewRow(line))
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (p *Explain) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(NewExplain(p.Child.TransformUp(f)))
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (p *Explain) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return NewExplain(p.Child.TransformExpressionsUp(f))
+}
+
+func (p *Explain) String() string {
+	pr := sql.NewTreePrinter()
+	pr.WriteNode("Explain")
+	pr.WriteChildren(nodeString(p.Child))
+	return pr.String()
+}
+
+// nodeString returns the tree of a node. Nodes that don't implement
+// fmt.Stringer are shown as tables if they have a name, or with the name of
+// their type otherwise.
+func nodeString(n sql.Node) string {
+	switch n := n.(type) {
+	case fmt.Stringer:
+		return n.String()
+	case sql.Table:
+		return fmt.Sprintf("Table(%s)", n.Name())
+	default:
+		return reflect.TypeOf(n).Elem().Name()
+	}
+}
+
+// exprsString returns the expressions separated by commas.
+func exprsString(exprs []sql.Expression) string {
+	strs := make([]string, len(exprs))
+	for i, e := range exprs {
+		strs[i] = fmt.Sprint(e)
+	}
+
+	return strings.Join(strs, ", ")
+}
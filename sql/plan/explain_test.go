@@ -0,0 +1,41 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/mem"
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/expression"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExplain(t *testing.T) {
+	require := require.New(t)
+
+	table := mem.NewTable("foo", sql.Schema{
+		{Name: "a", Type: sql.Int64},
+		{Name: "b", Type: sql.Text},
+	})
+
+	n := NewExplain(NewLimit(10, NewProject(
+		[]sql.Expression{expression.NewGetField(1, sql.Text, "b", false)},
+		NewFilter(
+			expression.NewEquals(
+				expression.NewGetField(0, sql.Int64, "a", false),
+				expression.NewLiteral(int64(1), sql.Int64),
+			),
+			NewCrossJoin(table, NewUnresolvedTable("bar")),
+		),
+	)))
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), n)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{"Limit(10)"},
+		{" └─ Project(b)"},
+		{"     └─ Filter(a = 1)"},
+		{"         └─ CrossJoin"},
+		{"             ├─ Table(foo)"},
+		{"             └─ UnresolvedTable(bar)"},
+	}, rows)
+}
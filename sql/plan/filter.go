@@ -14,12 +14,17 @@ func NewFilter(expression sql.Expression, child sql.Node) *Filter {
 	}
 }
 
+// Expression returns the condition of the filter.
+func (p *Filter) Expression() sql.Expression {
+	return p.expression
+}
+
 func (p *Filter) Resolved() bool {
 	return p.UnaryNode.Child.Resolved() && p.expression.Resolved()
 }
 
-func (p *Filter) RowIter() (sql.RowIter, error) {
-	i, err := p.Child.RowIter()
+func (p *Filter) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	i, err := p.Child.RowIter(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -41,6 +46,13 @@ func (p *Filter) TransformExpressionsUp(f func(sql.Expression) sql.Expression) s
 	return n
 }
 
+func (p *Filter) String() string {
+	pr := sql.NewTreePrinter()
+	pr.WriteNode("Filter(%s)", p.expression)
+	pr.WriteChildren(nodeString(p.Child))
+	return pr.String()
+}
+
 type filterIter struct {
 	f         *Filter
 	childIter sql.RowIter
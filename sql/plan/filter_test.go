@@ -12,6 +12,7 @@ import (
 
 func TestFilter(t *testing.T) {
 	assert := assert.New(t)
+	ctx := sql.NewEmptyContext()
 	childSchema := sql.Schema{
 		{Name: "col1", Type: sql.Text, Nullable: true},
 		{Name: "col2", Type: sql.Text, Nullable: true},
@@ -19,11 +20,11 @@ func TestFilter(t *testing.T) {
 		{Name: "col4", Type: sql.Int64, Nullable: true},
 	}
 	child := mem.NewTable("test", childSchema)
-	err := child.Insert(sql.NewRow("col1_1", "col2_1", int32(1111), int64(2222)))
+	err := child.Insert(sql.NewEmptyContext(), sql.NewRow("col1_1", "col2_1", int32(1111), int64(2222)))
 	assert.Nil(err)
-	err = child.Insert(sql.NewRow("col1_2", "col2_2", int32(3333), int64(4444)))
+	err = child.Insert(sql.NewEmptyContext(), sql.NewRow("col1_2", "col2_2", int32(3333), int64(4444)))
 	assert.Nil(err)
-	err = child.Insert(sql.NewRow("col1_3", "col2_3", nil, int64(4444)))
+	err = child.Insert(sql.NewEmptyContext(), sql.NewRow("col1_3", "col2_3", nil, int64(4444)))
 	assert.Nil(err)
 
 	f := NewFilter(
@@ -34,7 +35,7 @@ func TestFilter(t *testing.T) {
 
 	assert.Equal(1, len(f.Children()))
 
-	iter, err := f.RowIter()
+	iter, err := f.RowIter(ctx)
 	assert.Nil(err)
 	assert.NotNil(iter)
 
@@ -54,7 +55,7 @@ func TestFilter(t *testing.T) {
 		expression.NewLiteral(int32(1111),
 			sql.Int32)), child)
 
-	iter, err = f.RowIter()
+	iter, err = f.RowIter(ctx)
 	assert.Nil(err)
 	assert.NotNil(iter)
 
@@ -70,7 +71,7 @@ func TestFilter(t *testing.T) {
 		expression.NewLiteral(int64(4444), sql.Int64)),
 		child)
 
-	iter, err = f.RowIter()
+	iter, err = f.RowIter(ctx)
 	assert.Nil(err)
 	assert.NotNil(iter)
 
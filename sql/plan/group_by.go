@@ -44,8 +44,8 @@ func (p *GroupBy) Schema() sql.Schema {
 	return s
 }
 
-func (p *GroupBy) RowIter() (sql.RowIter, error) {
-	i, err := p.Child.RowIter()
+func (p *GroupBy) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	i, err := p.Child.RowIter(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -68,6 +68,13 @@ func (p *GroupBy) TransformExpressionsUp(f func(sql.Expression) sql.Expression)
 	return n
 }
 
+func (p *GroupBy) String() string {
+	pr := sql.NewTreePrinter()
+	pr.WriteNode("GroupBy(%s; %s)", exprsString(p.aggregate), exprsString(p.grouping))
+	pr.WriteChildren(nodeString(p.Child))
+	return pr.String()
+}
+
 type groupByIter struct {
 	p         *GroupBy
 	childIter sql.RowIter
@@ -153,12 +160,24 @@ func groupingKey(exprs []sql.Expression, row sql.Row) interface{} {
 	//TODO: use a more robust/efficient way of calculating grouping keys.
 	vals := make([]string, 0, len(exprs))
 	for _, expr := range exprs {
-		vals = append(vals, fmt.Sprintf("%#v", expr.Eval(row)))
+		v := groupingValue(expr.Type(), expr.Eval(row))
+		vals = append(vals, fmt.Sprintf("%#v", v))
 	}
 
 	return strings.Join(vals, ",")
 }
 
+// groupingValue returns a value that is the same for the values a type
+// compares as equal. Character strings are replaced by their key in the
+// collation of their type.
+func groupingValue(t sql.Type, v interface{}) interface{} {
+	if s, ok := v.(string); ok && sql.IsText(t) {
+		return sql.CollationKey(t.(sql.StringType).Collation(), s)
+	}
+
+	return v
+}
+
 func aggregate(exprs []sql.Expression, rows []sql.Row) sql.Row {
 	aggs := exprsToAggregateExprs(exprs)
 
@@ -8,6 +8,7 @@ import (
 	"github.com/src-d/go-mysql-server/sql/expression"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestGroupBy_Schema(t *testing.T) {
@@ -44,16 +45,17 @@ func TestGroupBy_Resolved(t *testing.T) {
 
 func TestGroupBy_RowIter(t *testing.T) {
 	assert := assert.New(t)
+	ctx := sql.NewEmptyContext()
 	childSchema := sql.Schema{
 		{Name: "col1", Type: sql.Text},
 		{Name: "col2", Type: sql.Int64},
 	}
 	child := mem.NewTable("test", childSchema)
-	child.Insert(sql.NewRow("col1_1", int64(1111)))
-	child.Insert(sql.NewRow("col1_1", int64(1111)))
-	child.Insert(sql.NewRow("col1_2", int64(4444)))
-	child.Insert(sql.NewRow("col1_1", int64(1111)))
-	child.Insert(sql.NewRow("col1_2", int64(4444)))
+	child.Insert(sql.NewEmptyContext(), sql.NewRow("col1_1", int64(1111)))
+	child.Insert(sql.NewEmptyContext(), sql.NewRow("col1_1", int64(1111)))
+	child.Insert(sql.NewEmptyContext(), sql.NewRow("col1_2", int64(4444)))
+	child.Insert(sql.NewEmptyContext(), sql.NewRow("col1_1", int64(1111)))
+	child.Insert(sql.NewEmptyContext(), sql.NewRow("col1_2", int64(4444)))
 
 	p := NewSort(
 		[]SortField{
@@ -79,10 +81,36 @@ func TestGroupBy_RowIter(t *testing.T) {
 
 	assert.Equal(1, len(p.Children()))
 
-	rows, err := sql.NodeToRows(p)
+	rows, err := sql.NodeToRows(ctx, p)
 	assert.NoError(err)
 	assert.Len(rows, 2)
 
 	assert.Equal(sql.NewRow("col1_1", int64(1111)), rows[0])
 	assert.Equal(sql.NewRow("col1_2", int64(4444)), rows[1])
 }
+
+func TestGroupBy_Collation(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	child := mem.NewTable("test", sql.Schema{{Name: "name", Type: sql.Text}})
+	for _, name := range []string{"john", "John", "JOHN ", "jane"} {
+		require.NoError(child.Insert(ctx, sql.NewRow(name)))
+	}
+
+	name := expression.NewGetField(0, sql.Text, "name", false)
+	p := NewSort(
+		[]SortField{{Column: name, Order: Ascending}},
+		NewGroupBy(
+			[]sql.Expression{name, expression.NewCount(expression.NewStar())},
+			[]sql.Expression{name},
+			child,
+		),
+	)
+
+	rows, err := sql.NodeToRows(ctx, p)
+	require.NoError(err)
+	require.Len(rows, 2)
+	require.Equal(sql.NewRow("jane", int32(1)), rows[0])
+	require.Equal(int32(3), rows[1][1])
+}
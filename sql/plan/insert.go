@@ -2,7 +2,11 @@ package plan
 
 import (
 	"errors"
+	"fmt"
 	"io"
+	"math"
+	"strings"
+	"time"
 
 	"github.com/src-d/go-mysql-server/sql"
 	"github.com/src-d/go-mysql-server/sql/expression"
@@ -20,21 +24,31 @@ func NewInsertInto(dst, src sql.Node, cols []string) *InsertInto {
 	}
 }
 
+// Schema implements the Node interface. An insert returns a single row with
+// an sql.OkResult holding the number of inserted rows.
 func (p *InsertInto) Schema() sql.Schema {
-	return sql.Schema{{
-		Name:     "updated",
-		Type:     sql.Int64,
-		Default:  int64(0),
-		Nullable: false,
-	}}
+	return sql.OkResultSchema
 }
 
-func (p *InsertInto) Execute() (int, error) {
+func (p *InsertInto) Execute(ctx *sql.Context) (int, error) {
+	result, err := p.insert(ctx)
+	return int(result.RowsAffected), err
+}
+
+func (p *InsertInto) insert(ctx *sql.Context) (sql.OkResult, error) {
+	var result sql.OkResult
 	insertable, ok := p.Left.(sql.Inserter)
 	if !ok {
-		return 0, errors.New("destination table does not support INSERT TO")
+		return result, errors.New("destination table does not support INSERT TO")
+	}
+
+	loc, err := sql.TimeZone(ctx)
+	if err != nil {
+		return result, err
 	}
 
+	strict := sql.StrictMode(ctx)
+
 	dstSchema := p.Left.Schema()
 	projExprs := make([]sql.Expression, len(dstSchema))
 	for i, f := range dstSchema {
@@ -48,19 +62,28 @@ func (p *InsertInto) Execute() (int, error) {
 		}
 
 		if !found {
-			def, _ := f.Type.Convert(nil)
+			def, err := defaultValue(f, strict)
+			if err != nil {
+				return result, err
+			}
+
 			projExprs[i] = expression.NewLiteral(def, f.Type)
 		}
 	}
 
+	conv := &rowConverter{
+		schema: dstSchema,
+		loc:    loc,
+		strict: strict,
+	}
+
 	proj := NewProject(projExprs, p.Right)
 
-	iter, err := proj.RowIter()
+	iter, err := proj.RowIter(ctx)
 	if err != nil {
-		return 0, err
+		return result, err
 	}
 
-	i := 0
 	for {
 		row, err := iter.Next()
 		if err == io.EOF {
@@ -69,27 +92,119 @@ func (p *InsertInto) Execute() (int, error) {
 
 		if err != nil {
 			_ = iter.Close()
-			return i, err
+			return result, err
 		}
 
-		if err := insertable.Insert(row); err != nil {
+		row, err = conv.convert(row, result.RowsAffected+1)
+		if err != nil {
 			_ = iter.Close()
-			return i, err
+			return result, err
+		}
+
+		if err := insertable.Insert(ctx, row); err != nil {
+			_ = iter.Close()
+			return result, err
+		}
+
+		result.RowsAffected++
+		result.Warnings = conv.warnings
+	}
+
+	return result, nil
+}
+
+// defaultValue returns the value of a column left out of an INSERT: its
+// default value, or NULL if it's nullable. Like in MySQL, NOT NULL columns
+// without a default value are an error in strict mode, and otherwise they
+// get the implicit default of their type.
+func defaultValue(col *sql.Column, strict bool) (interface{}, error) {
+	if col.Default != nil {
+		return col.Default, nil
+	}
+
+	if col.Nullable {
+		return nil, nil
+	}
+
+	if strict {
+		return nil, fmt.Errorf("field %s doesn't have a default value", col.Name)
+	}
+
+	return implicitDefault(col.Type)
+}
+
+// implicitDefault returns the value MySQL gives to NOT NULL columns without
+// a default value: the first member of an ENUM, the empty string or set,
+// JSON null, or zero.
+func implicitDefault(t sql.Type) (interface{}, error) {
+	switch t.(type) {
+	case sql.EnumType:
+		return uint16(1), nil
+	case sql.StringType, sql.SetType:
+		return t.Convert("")
+	}
+
+	if t == sql.JSON {
+		return sql.JSONDocument{}, nil
+	}
+
+	return t.Convert(int64(0))
+}
+
+// rowConverter converts the values of rows to the types of the columns of
+// a schema, so tables store values of the types of their columns. Strings
+// inserted in JSON columns are read as JSON text.
+type rowConverter struct {
+	schema sql.Schema
+	// loc is the time zone of the session, in which strings and numbers
+	// are read as TIMESTAMP values.
+	loc *time.Location
+	// strict is true if strings too long for their column are an error
+	// instead of being truncated with a warning.
+	strict   bool
+	warnings uint16
+}
+
+// convert converts the values of the n-th row.
+func (c *rowConverter) convert(row sql.Row, n uint64) (sql.Row, error) {
+	converted := make(sql.Row, len(row))
+	for i, v := range row {
+		if v == nil || i >= len(c.schema) {
+			converted[i] = v
+			continue
+		}
+
+		col := c.schema[i]
+		cv, err := sql.ConvertIn(col.Type, v, c.loc)
+		if err != nil {
+			return nil, fmt.Errorf("can't insert value %v in column %s: %s", v, col.Name, err)
 		}
 
-		i++
+		if st, ok := col.Type.(sql.StringType); ok {
+			var truncated bool
+			cv, truncated = sql.TruncateString(st, cv)
+			if truncated && c.strict {
+				return nil, fmt.Errorf("data too long for column %s at row %d", col.Name, n)
+			}
+
+			if truncated && c.warnings < math.MaxUint16 {
+				c.warnings++
+			}
+		}
+
+		converted[i] = cv
 	}
 
-	return i, nil
+	return converted, nil
 }
 
-func (p *InsertInto) RowIter() (sql.RowIter, error) {
-	n, err := p.Execute()
+func (p *InsertInto) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	result, err := p.insert(ctx)
 	if err != nil {
 		return nil, err
 	}
 
-	return sql.RowsToRowIter(sql.NewRow(int64(n))), nil
+	return sql.RowsToRowIter(sql.NewRow(result)), nil
 }
 
 func (p *InsertInto) TransformUp(f func(sql.Node) sql.Node) sql.Node {
@@ -106,3 +221,10 @@ func (p *InsertInto) TransformExpressionsUp(f func(sql.Expression) sql.Expressio
 
 	return NewInsertInto(ln, rn, p.Columns)
 }
+
+func (p *InsertInto) String() string {
+	pr := sql.NewTreePrinter()
+	pr.WriteNode("Insert(%s)", strings.Join(p.Columns, ", "))
+	pr.WriteChildren(nodeString(p.Left), nodeString(p.Right))
+	return pr.String()
+}
@@ -0,0 +1,156 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// JSONTableColumn is a column of a JSON_TABLE.
+type JSONTableColumn struct {
+	// Name is the name of the column.
+	Name string
+	// Type is the type of the values of the column.
+	Type sql.Type
+	// Path is the path of the value of the column in the value of each row.
+	// Rows without a value at the path are NULL in the column.
+	Path string
+	// Ordinality is true if the column is the number of the row, starting
+	// at 1, as the FOR ORDINALITY columns of MySQL. The path is ignored.
+	Ordinality bool
+}
+
+// JSONTable is the JSON_TABLE table function, which returns a row for each
+// value matched by a path in a JSON document. The values of the columns of
+// a row are the values at the paths of the columns in the value of the row,
+// converted to the types of the columns, or NULL if they can't be. The
+// document is evaluated once, so it can't refer to columns of other tables.
+type JSONTable struct {
+	doc     sql.Expression
+	path    sql.JSONPath
+	columns []JSONTableColumn
+	paths   []sql.JSONPath
+}
+
+// NewJSONTable creates a new JSONTable node with the rows matched by path
+// in the value of doc. It returns an error if a path is not valid.
+func NewJSONTable(doc sql.Expression, path string, columns []JSONTableColumn) (*JSONTable, error) {
+	p, err := sql.ParseJSONPath(path)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]sql.JSONPath, len(columns))
+	for i, c := range columns {
+		if c.Ordinality {
+			continue
+		}
+
+		if paths[i], err = sql.ParseJSONPath(c.Path); err != nil {
+			return nil, err
+		}
+	}
+
+	return &JSONTable{doc, p, columns, paths}, nil
+}
+
+// Schema implements the Node interface.
+func (t *JSONTable) Schema() sql.Schema {
+	schema := make(sql.Schema, len(t.columns))
+	for i, c := range t.columns {
+		schema[i] = &sql.Column{Name: c.Name, Type: c.Type, Nullable: !c.Ordinality}
+	}
+
+	return schema
+}
+
+// Children implements the Node interface.
+func (t *JSONTable) Children() []sql.Node {
+	return nil
+}
+
+// Resolved implements the Resolvable interface.
+func (t *JSONTable) Resolved() bool {
+	return t.doc.Resolved()
+}
+
+// RowIter implements the Node interface.
+func (t *JSONTable) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	v := t.doc.Eval(nil)
+	if v == nil {
+		return sql.RowsToRowIter(), nil
+	}
+
+	doc, err := sql.JSON.Convert(v)
+	if err != nil {
+		return nil, err
+	}
+
+	var rows []sql.Row
+	for n, value := range t.path.Extract(doc.(sql.JSONDocument).Val) {
+		row := make(sql.Row, len(t.columns))
+		for i, c := range t.columns {
+			if c.Ordinality {
+				row[i], _ = c.Type.Convert(int64(n + 1))
+				continue
+			}
+
+			if values := t.paths[i].Extract(value); len(values) == 1 {
+				row[i] = jsonColumnValue(c.Type, values[0])
+			}
+		}
+
+		rows = append(rows, row)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// jsonColumnValue returns a JSON value converted to the type of a column,
+// or nil if it can't be converted. Values of other types than JSON are
+// converted from their unquoted text.
+func jsonColumnValue(t sql.Type, v interface{}) interface{} {
+	if t == sql.JSON {
+		return sql.JSONDocument{Val: v}
+	}
+
+	if v == nil {
+		return nil
+	}
+
+	text, ok := v.(string)
+	if !ok {
+		text = sql.JSON.SQL(v).ToString()
+	}
+
+	converted, err := t.Convert(text)
+	if err != nil {
+		return nil
+	}
+
+	return converted
+}
+
+// TransformUp implements the Transformable interface.
+func (t *JSONTable) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(t)
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (t *JSONTable) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return &JSONTable{t.doc.TransformUp(f), t.path, t.columns, t.paths}
+}
+
+func (t *JSONTable) String() string {
+	columns := make([]string, len(t.columns))
+	for i, c := range t.columns {
+		if c.Ordinality {
+			columns[i] = fmt.Sprintf("%s FOR ORDINALITY", c.Name)
+		} else {
+			columns[i] = fmt.Sprintf("%s %s PATH '%s'", c.Name, sql.MySQLTypeName(c.Type), c.Path)
+		}
+	}
+
+	return fmt.Sprintf("JSONTable(%s, '%s' COLUMNS(%s))", t.doc, t.path, strings.Join(columns, ", "))
+}
@@ -0,0 +1,47 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/expression"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONTable(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	doc := expression.NewLiteral(
+		`{"people": [{"name": "John", "age": 30, "tags": [1]}, {"name": "Jane"}, {"age": "x"}]}`,
+		sql.Text,
+	)
+	table, err := NewJSONTable(doc, "$.people[*]", []JSONTableColumn{
+		{Name: "n", Type: sql.Int64, Ordinality: true},
+		{Name: "name", Type: sql.Text, Path: "$.name"},
+		{Name: "age", Type: sql.Int64, Path: "$.age"},
+		{Name: "tags", Type: sql.JSON, Path: "$.tags"},
+	})
+	require.NoError(err)
+	require.True(table.Resolved())
+	require.Equal(sql.Schema{
+		{Name: "n", Type: sql.Int64},
+		{Name: "name", Type: sql.Text, Nullable: true},
+		{Name: "age", Type: sql.Int64, Nullable: true},
+		{Name: "tags", Type: sql.JSON, Nullable: true},
+	}, table.Schema())
+
+	rows, err := sql.NodeToRows(ctx, table)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{int64(1), "John", int64(30), sql.JSONDocument{Val: []interface{}{1.0}}},
+		{int64(2), "Jane", nil, nil},
+		{int64(3), nil, nil, nil},
+	}, rows)
+
+	_, err = NewJSONTable(doc, "$.people[", nil)
+	require.Error(err)
+	_, err = NewJSONTable(doc, "$", []JSONTableColumn{{Name: "a", Type: sql.Text, Path: "a"}})
+	require.Error(err)
+}
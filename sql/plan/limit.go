@@ -22,8 +22,8 @@ func (p *Limit) Resolved() bool {
 	return p.UnaryNode.Child.Resolved()
 }
 
-func (l *Limit) RowIter() (sql.RowIter, error) {
-	li, err := l.Child.RowIter()
+func (l *Limit) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	li, err := l.Child.RowIter(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -44,6 +44,13 @@ func (l *Limit) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sq
 	return n
 }
 
+func (l *Limit) String() string {
+	pr := sql.NewTreePrinter()
+	pr.WriteNode("Limit(%d)", l.size)
+	pr.WriteChildren(nodeString(l.Child))
+	return pr.String()
+}
+
 type limitIter struct {
 	l          *Limit
 	currentPos int64
@@ -90,18 +90,19 @@ func getTestingTable() (*mem.Table, int) {
 		{Name: "col1", Type: sql.Text},
 	}
 	testingTable = mem.NewTable("test", childSchema)
-	testingTable.Insert(sql.NewRow("11a"))
-	testingTable.Insert(sql.NewRow("22a"))
-	testingTable.Insert(sql.NewRow("33a"))
+	testingTable.Insert(sql.NewEmptyContext(), sql.NewRow("11a"))
+	testingTable.Insert(sql.NewEmptyContext(), sql.NewRow("22a"))
+	testingTable.Insert(sql.NewEmptyContext(), sql.NewRow("33a"))
 	testingTableSize = 3
 
 	return testingTable, testingTableSize
 }
 
 func getLimitedIterator(limitSize int64) (sql.RowIter, error) {
+	ctx := sql.NewEmptyContext()
 	table, _ := getTestingTable()
 	limitPlan := NewLimit(limitSize, table)
-	return limitPlan.RowIter()
+	return limitPlan.RowIter(ctx)
 }
 
 func receivesNode(n sql.Node) bool {
@@ -34,8 +34,8 @@ func (p *Project) Resolved() bool {
 		expressionsResolved(p.Expressions...)
 }
 
-func (p *Project) RowIter() (sql.RowIter, error) {
-	i, err := p.Child.RowIter()
+func (p *Project) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	i, err := p.Child.RowIter(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -57,6 +57,13 @@ func (p *Project) TransformExpressionsUp(f func(sql.Expression) sql.Expression)
 	return n
 }
 
+func (p *Project) String() string {
+	pr := sql.NewTreePrinter()
+	pr.WriteNode("Project(%s)", exprsString(p.Expressions))
+	pr.WriteChildren(nodeString(p.Child))
+	return pr.String()
+}
+
 type iter struct {
 	p         *Project
 	childIter sql.RowIter
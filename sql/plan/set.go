@@ -0,0 +1,112 @@
+package plan
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// SetVariable is a single assignment of a SET statement.
+type SetVariable struct {
+	// Name of the variable, without the @ or @@ prefix and the scope.
+	Name string
+	// Scope of the variable. It is ignored for user-defined variables.
+	Scope sql.SystemVariableScope
+	// User is true for user-defined variables and false for system
+	// variables.
+	User bool
+	// Value to assign to the variable. If it is nil, the variable is set to
+	// its default value.
+	Value sql.Expression
+}
+
+// Set is a node that sets the value of one or more variables.
+type Set struct {
+	Variables []SetVariable
+}
+
+// NewSet creates a new Set node.
+func NewSet(vars ...SetVariable) *Set {
+	return &Set{vars}
+}
+
+// Resolved implements the Node interface.
+func (p *Set) Resolved() bool {
+	for _, v := range p.Variables {
+		if v.Value != nil && !v.Value.Resolved() {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Children implements the Node interface.
+func (p *Set) Children() []sql.Node {
+	return nil
+}
+
+// Schema implements the Node interface.
+func (p *Set) Schema() sql.Schema {
+	return sql.Schema{}
+}
+
+// RowIter implements the Node interface.
+func (p *Set) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	for _, v := range p.Variables {
+		var value interface{}
+		if v.Value != nil {
+			value = v.Value.Eval(nil)
+		}
+
+		if v.User {
+			typ := sql.Type(sql.Null)
+			if v.Value != nil {
+				typ = v.Value.Type()
+			}
+
+			ctx.SetUserVariable(v.Name, typ, value)
+			continue
+		}
+
+		if err := ctx.SetSystemVariable(v.Scope, v.Name, value); err != nil {
+			return nil, err
+		}
+	}
+
+	return sql.RowsToRowIter(), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (p *Set) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(NewSet(p.Variables...))
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (p *Set) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	vars := make([]SetVariable, len(p.Variables))
+	for i, v := range p.Variables {
+		if v.Value != nil {
+			v.Value = v.Value.TransformUp(f)
+		}
+
+		vars[i] = v
+	}
+
+	return NewSet(vars...)
+}
+
+func (p *Set) String() string {
+	vars := make([]string, len(p.Variables))
+	for i, v := range p.Variables {
+		name := v.Name
+		if v.User {
+			name = "@" + name
+		}
+
+		vars[i] = fmt.Sprintf("%s = %s", name, v.Value)
+	}
+
+	return fmt.Sprintf("Set(%s)", strings.Join(vars, ", "))
+}
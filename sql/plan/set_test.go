@@ -0,0 +1,65 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/src-d/go-mysql-server/sql/expression"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSet(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	s := NewSet(
+		SetVariable{
+			Name:  "autocommit",
+			Value: expression.NewLiteral(int64(0), sql.Int64),
+		},
+		SetVariable{
+			Name:  "sql_mode",
+			Scope: sql.GlobalScope,
+			Value: expression.NewLiteral("ANSI", sql.Text),
+		},
+		SetVariable{
+			Name:  "foo",
+			User:  true,
+			Value: expression.NewLiteral("bar", sql.Text),
+		},
+	)
+	require.True(s.Resolved())
+	require.Equal(sql.Schema{}, s.Schema())
+
+	rows, err := sql.NodeToRows(ctx, s)
+	require.NoError(err)
+	require.Len(rows, 0)
+
+	_, v, err := ctx.SystemVariable(sql.DefaultScope, "autocommit")
+	require.NoError(err)
+	require.Equal(int64(0), v)
+
+	_, v, err = ctx.SystemVariable(sql.GlobalScope, "sql_mode")
+	require.NoError(err)
+	require.Equal("ANSI", v)
+
+	typ, v := ctx.UserVariable("foo")
+	require.Equal(sql.Text, typ)
+	require.Equal("bar", v)
+
+	s = NewSet(SetVariable{Name: "autocommit"})
+	_, err = sql.NodeToRows(ctx, s)
+	require.NoError(err)
+
+	_, v, err = ctx.SystemVariable(sql.DefaultScope, "autocommit")
+	require.NoError(err)
+	require.Equal(int64(1), v)
+
+	s = NewSet(SetVariable{
+		Name:  "unknown",
+		Value: expression.NewLiteral(int64(1), sql.Int64),
+	})
+	_, err = sql.NodeToRows(ctx, s)
+	require.Error(err)
+}
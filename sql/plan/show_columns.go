@@ -0,0 +1,113 @@
+package plan
+
+import (
+	"regexp"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// ShowColumns is a node that shows the columns of a table.
+type ShowColumns struct {
+	UnaryNode
+	// Full is true if the collation, privileges and comment of the columns
+	// are also shown.
+	Full bool
+	// Pattern is the LIKE pattern the names of the columns must match, or
+	// empty to show all of them.
+	Pattern string
+}
+
+// NewShowColumns creates a new ShowColumns node that shows the columns of
+// the given table whose name matches the given LIKE pattern.
+func NewShowColumns(table sql.Node, full bool, pattern string) *ShowColumns {
+	return &ShowColumns{UnaryNode{table}, full, pattern}
+}
+
+// Schema implements the Node interface.
+func (p *ShowColumns) Schema() sql.Schema {
+	if p.Full {
+		return sql.Schema{
+			{Name: "Field", Type: sql.Text},
+			{Name: "Type", Type: sql.Text},
+			{Name: "Collation", Type: sql.Text, Nullable: true},
+			{Name: "Null", Type: sql.Text},
+			{Name: "Key", Type: sql.Text},
+			{Name: "Default", Type: sql.Text, Nullable: true},
+			{Name: "Extra", Type: sql.Text},
+			{Name: "Privileges", Type: sql.Text},
+			{Name: "Comment", Type: sql.Text},
+		}
+	}
+
+	return sql.Schema{
+		{Name: "Field", Type: sql.Text},
+		{Name: "Type", Type: sql.Text},
+		{Name: "Null", Type: sql.Text},
+		{Name: "Key", Type: sql.Text},
+		{Name: "Default", Type: sql.Text, Nullable: true},
+		{Name: "Extra", Type: sql.Text},
+	}
+}
+
+// RowIter implements the Node interface.
+func (p *ShowColumns) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	var like *regexp.Regexp
+	if p.Pattern != "" {
+		like = likeToRegexp(p.Pattern)
+	}
+
+	var rows []sql.Row
+	for _, c := range p.Child.Schema() {
+		if like != nil && !like.MatchString(c.Name) {
+			continue
+		}
+
+		null := "NO"
+		if c.Nullable {
+			null = "YES"
+		}
+
+		var def interface{}
+		if c.Default != nil {
+			def = c.Type.SQL(c.Default).ToString()
+		}
+
+		typ := sql.MySQLTypeName(c.Type)
+		if p.Full {
+			var collation interface{}
+			switch t := c.Type.(type) {
+			case sql.StringType:
+				if sql.IsText(t) {
+					collation = t.Collation()
+				}
+			case sql.EnumType, sql.SetType:
+				collation = sql.DefaultCollation
+			}
+
+			rows = append(rows, sql.NewRow(
+				c.Name, typ, collation, null, "", def, "", "select", "",
+			))
+		} else {
+			rows = append(rows, sql.NewRow(c.Name, typ, null, "", def, ""))
+		}
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (p *ShowColumns) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(NewShowColumns(p.Child.TransformUp(f), p.Full, p.Pattern))
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (p *ShowColumns) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return NewShowColumns(p.Child.TransformExpressionsUp(f), p.Full, p.Pattern)
+}
+
+func (p *ShowColumns) String() string {
+	pr := sql.NewTreePrinter()
+	pr.WriteNode("ShowColumns")
+	pr.WriteChildren(nodeString(p.Child))
+	return pr.String()
+}
@@ -0,0 +1,45 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/mem"
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/stretchr/testify/require"
+)
+
+var showColumnsTable = mem.NewTable("test", sql.Schema{
+	{Name: "id", Type: sql.Int64},
+	{Name: "name", Type: sql.Text, Nullable: true, Default: "foo"},
+	{Name: "admin", Type: sql.Boolean, Default: false},
+})
+
+func TestShowColumns(t *testing.T) {
+	require := require.New(t)
+
+	require.False(NewShowColumns(NewUnresolvedTable("test"), false, "").Resolved())
+
+	n := NewShowColumns(showColumnsTable, false, "")
+	require.True(n.Resolved())
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), n)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{"id", "bigint", "NO", "", nil, ""},
+		{"name", "text", "YES", "", "foo", ""},
+		{"admin", "tinyint(1)", "NO", "", "0", ""},
+	}, rows)
+}
+
+func TestShowColumns_Full(t *testing.T) {
+	require := require.New(t)
+
+	n := NewShowColumns(showColumnsTable, true, "N%")
+	require.Len(n.Schema(), 9)
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), n)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{"name", "text", "utf8_general_ci", "YES", "", "foo", "", "select", ""},
+	}, rows)
+}
@@ -0,0 +1,94 @@
+package plan
+
+import (
+	"bytes"
+	"strings"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// ShowCreateTable is a node that shows the CREATE TABLE statement of a
+// table.
+type ShowCreateTable struct {
+	UnaryNode
+}
+
+// NewShowCreateTable creates a new ShowCreateTable node that shows the
+// statement of the given table.
+func NewShowCreateTable(table sql.Node) *ShowCreateTable {
+	return &ShowCreateTable{UnaryNode{table}}
+}
+
+// Schema implements the Node interface.
+func (*ShowCreateTable) Schema() sql.Schema {
+	return sql.Schema{
+		{Name: "Table", Type: sql.Text},
+		{Name: "Create Table", Type: sql.Text},
+	}
+}
+
+// RowIter implements the Node interface.
+func (p *ShowCreateTable) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	var name string
+	if n, ok := p.Child.(sql.Nameable); ok {
+		name = n.Name()
+	}
+
+	return sql.RowsToRowIter(sql.NewRow(name, createTable(name, p.Child.Schema()))), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (p *ShowCreateTable) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(NewShowCreateTable(p.Child.TransformUp(f)))
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (p *ShowCreateTable) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return NewShowCreateTable(p.Child.TransformExpressionsUp(f))
+}
+
+func (p *ShowCreateTable) String() string {
+	pr := sql.NewTreePrinter()
+	pr.WriteNode("ShowCreateTable")
+	pr.WriteChildren(nodeString(p.Child))
+	return pr.String()
+}
+
+// createTable returns the CREATE TABLE statement of a table with the given
+// name and schema.
+func createTable(name string, schema sql.Schema) string {
+	var buf bytes.Buffer
+	buf.WriteString("CREATE TABLE " + quoteIdentifier(name) + " (")
+	for i, c := range schema {
+		if i > 0 {
+			buf.WriteString(",")
+		}
+
+		buf.WriteString("\n  " + quoteIdentifier(c.Name) + " " + sql.MySQLTypeName(c.Type))
+		if st, ok := c.Type.(sql.StringType); ok && sql.IsText(st) {
+			if st.CharacterSet() != sql.DefaultCharacterSet {
+				buf.WriteString(" CHARACTER SET " + st.CharacterSet())
+			}
+
+			if c, _ := sql.CharacterSetCollation(st.CharacterSet()); st.Collation() != c {
+				buf.WriteString(" COLLATE " + st.Collation())
+			}
+		}
+
+		if !c.Nullable {
+			buf.WriteString(" NOT NULL")
+		}
+
+		if c.Default != nil {
+			buf.WriteString(" DEFAULT ")
+			c.Type.SQL(c.Default).EncodeSQL(&buf)
+		}
+	}
+
+	buf.WriteString("\n) DEFAULT CHARSET=utf8")
+	return buf.String()
+}
+
+func quoteIdentifier(name string) string {
+	return "`" + strings.Replace(name, "`", "``", -1) + "`"
+}
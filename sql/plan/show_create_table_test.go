@@ -0,0 +1,23 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowCreateTable(t *testing.T) {
+	require := require.New(t)
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), NewShowCreateTable(showColumnsTable))
+	require.NoError(err)
+	require.Equal([]sql.Row{{
+		"test",
+		"CREATE TABLE `test` (\n" +
+			"  `id` bigint NOT NULL,\n" +
+			"  `name` text DEFAULT 'foo',\n" +
+			"  `admin` tinyint(1) NOT NULL DEFAULT 0\n" +
+			") DEFAULT CHARSET=utf8",
+	}}, rows)
+}
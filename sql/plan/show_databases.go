@@ -0,0 +1,77 @@
+package plan
+
+import (
+	"regexp"
+	"sort"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// ShowDatabases is a node that shows the databases of a catalog.
+type ShowDatabases struct {
+	// Catalog is the catalog whose databases are shown, or nil if it has
+	// not been resolved yet.
+	Catalog *sql.Catalog
+	// Pattern is the LIKE pattern the names of the databases must match, or
+	// empty to show all of them.
+	Pattern string
+}
+
+// NewShowDatabases creates a new ShowDatabases node that shows the databases
+// whose name matches the given LIKE pattern.
+func NewShowDatabases(pattern string) *ShowDatabases {
+	return &ShowDatabases{Pattern: pattern}
+}
+
+// Resolved implements the Resolvable interface.
+func (p *ShowDatabases) Resolved() bool {
+	return p.Catalog != nil
+}
+
+// Children implements the Node interface.
+func (*ShowDatabases) Children() []sql.Node {
+	return nil
+}
+
+// Schema implements the Node interface.
+func (*ShowDatabases) Schema() sql.Schema {
+	return sql.Schema{{Name: "Database", Type: sql.Text, Nullable: false}}
+}
+
+// RowIter implements the Node interface.
+func (p *ShowDatabases) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	var like *regexp.Regexp
+	if p.Pattern != "" {
+		like = likeToRegexp(p.Pattern)
+	}
+
+	var names []string
+	for _, db := range p.Catalog.Databases {
+		if like == nil || like.MatchString(db.Name()) {
+			names = append(names, db.Name())
+		}
+	}
+
+	sort.Strings(names)
+
+	rows := make([]sql.Row, len(names))
+	for i, name := range names {
+		rows[i] = sql.NewRow(name)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (p *ShowDatabases) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(&ShowDatabases{Catalog: p.Catalog, Pattern: p.Pattern})
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (p *ShowDatabases) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return p
+}
+
+func (*ShowDatabases) String() string {
+	return "ShowDatabases"
+}
@@ -0,0 +1,31 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/mem"
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowDatabases(t *testing.T) {
+	require := require.New(t)
+
+	n := NewShowDatabases("")
+	require.False(n.Resolved())
+
+	c := sql.NewCatalog()
+	c.Databases = append(c.Databases, mem.NewDatabase("foo"), mem.NewDatabase("bar"))
+
+	n.Catalog = c
+	require.True(n.Resolved())
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), n)
+	require.NoError(err)
+	require.Equal([]sql.Row{{"bar"}, {"foo"}}, rows)
+
+	n = &ShowDatabases{Catalog: c, Pattern: "F%"}
+	rows, err = sql.NodeToRows(sql.NewEmptyContext(), n)
+	require.NoError(err)
+	require.Equal([]sql.Row{{"foo"}}, rows)
+}
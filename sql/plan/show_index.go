@@ -0,0 +1,58 @@
+package plan
+
+import (
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// ShowIndex is a node that shows the indexes of a table. Tables have no
+// indexes, so it never returns rows.
+type ShowIndex struct {
+	UnaryNode
+}
+
+// NewShowIndex creates a new ShowIndex node that shows the indexes of the
+// given table.
+func NewShowIndex(table sql.Node) *ShowIndex {
+	return &ShowIndex{UnaryNode{table}}
+}
+
+// Schema implements the Node interface.
+func (*ShowIndex) Schema() sql.Schema {
+	return sql.Schema{
+		{Name: "Table", Type: sql.Text},
+		{Name: "Non_unique", Type: sql.Int32},
+		{Name: "Key_name", Type: sql.Text},
+		{Name: "Seq_in_index", Type: sql.Uint32},
+		{Name: "Column_name", Type: sql.Text, Nullable: true},
+		{Name: "Collation", Type: sql.Text, Nullable: true},
+		{Name: "Cardinality", Type: sql.Int64, Nullable: true},
+		{Name: "Sub_part", Type: sql.Int64, Nullable: true},
+		{Name: "Packed", Type: sql.Text, Nullable: true},
+		{Name: "Null", Type: sql.Text},
+		{Name: "Index_type", Type: sql.Text},
+		{Name: "Comment", Type: sql.Text},
+		{Name: "Index_comment", Type: sql.Text},
+	}
+}
+
+// RowIter implements the Node interface.
+func (*ShowIndex) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	return sql.RowsToRowIter(), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (p *ShowIndex) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(NewShowIndex(p.Child.TransformUp(f)))
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (p *ShowIndex) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return NewShowIndex(p.Child.TransformExpressionsUp(f))
+}
+
+func (p *ShowIndex) String() string {
+	pr := sql.NewTreePrinter()
+	pr.WriteNode("ShowIndex")
+	pr.WriteChildren(nodeString(p.Child))
+	return pr.String()
+}
@@ -0,0 +1,19 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowIndex(t *testing.T) {
+	require := require.New(t)
+
+	n := NewShowIndex(showColumnsTable)
+	require.True(n.Resolved())
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), n)
+	require.NoError(err)
+	require.Empty(rows)
+}
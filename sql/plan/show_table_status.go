@@ -0,0 +1,100 @@
+package plan
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// ShowTableStatus is a node that shows information about the tables of a
+// database. Only their names are known, so most of the information is NULL.
+type ShowTableStatus struct {
+	Database sql.Database
+	// Pattern is the LIKE pattern the names of the tables must match, or
+	// empty to show all of them.
+	Pattern string
+}
+
+// NewShowTableStatus creates a new ShowTableStatus node that shows the
+// tables of the given database whose name matches the given LIKE pattern.
+func NewShowTableStatus(database sql.Database, pattern string) *ShowTableStatus {
+	return &ShowTableStatus{Database: database, Pattern: pattern}
+}
+
+// Resolved implements the Resolvable interface.
+func (p *ShowTableStatus) Resolved() bool {
+	_, ok := p.Database.(*sql.UnresolvedDatabase)
+	return !ok
+}
+
+// Children implements the Node interface.
+func (*ShowTableStatus) Children() []sql.Node {
+	return nil
+}
+
+// Schema implements the Node interface.
+func (*ShowTableStatus) Schema() sql.Schema {
+	return sql.Schema{
+		{Name: "Name", Type: sql.Text},
+		{Name: "Engine", Type: sql.Text, Nullable: true},
+		{Name: "Version", Type: sql.Uint64, Nullable: true},
+		{Name: "Row_format", Type: sql.Text, Nullable: true},
+		{Name: "Rows", Type: sql.Uint64, Nullable: true},
+		{Name: "Avg_row_length", Type: sql.Uint64, Nullable: true},
+		{Name: "Data_length", Type: sql.Uint64, Nullable: true},
+		{Name: "Max_data_length", Type: sql.Uint64, Nullable: true},
+		{Name: "Index_length", Type: sql.Uint64, Nullable: true},
+		{Name: "Data_free", Type: sql.Uint64, Nullable: true},
+		{Name: "Auto_increment", Type: sql.Uint64, Nullable: true},
+		{Name: "Create_time", Type: sql.Timestamp, Nullable: true},
+		{Name: "Update_time", Type: sql.Timestamp, Nullable: true},
+		{Name: "Check_time", Type: sql.Timestamp, Nullable: true},
+		{Name: "Collation", Type: sql.Text, Nullable: true},
+		{Name: "Checksum", Type: sql.Uint64, Nullable: true},
+		{Name: "Create_options", Type: sql.Text, Nullable: true},
+		{Name: "Comment", Type: sql.Text, Nullable: true},
+	}
+}
+
+// RowIter implements the Node interface.
+func (p *ShowTableStatus) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	var like *regexp.Regexp
+	if p.Pattern != "" {
+		like = likeToRegexp(p.Pattern)
+	}
+
+	var names []string
+	for name := range p.Database.Tables() {
+		if like == nil || like.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	rows := make([]sql.Row, len(names))
+	for i, name := range names {
+		rows[i] = sql.NewRow(
+			name, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil,
+			nil, nil, nil, sql.DefaultCollation, nil, "", "",
+		)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (p *ShowTableStatus) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(NewShowTableStatus(p.Database, p.Pattern))
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (p *ShowTableStatus) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return p
+}
+
+func (p *ShowTableStatus) String() string {
+	return fmt.Sprintf("ShowTableStatus(%s)", p.Database.Name())
+}
@@ -0,0 +1,28 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/mem"
+	"github.com/src-d/go-mysql-server/sql"
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowTableStatus(t *testing.T) {
+	require := require.New(t)
+
+	require.False(NewShowTableStatus(sql.NewUnresolvedDatabase(""), "").Resolved())
+
+	db := mem.NewDatabase("test")
+	db.AddTable("foo", mem.NewTable("foo", nil))
+	db.AddTable("bar", mem.NewTable("bar", nil))
+
+	n := NewShowTableStatus(db, "b%")
+	require.True(n.Resolved())
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), n)
+	require.NoError(err)
+	require.Len(rows, 1)
+	require.Len(rows[0], len(n.Schema()))
+	require.Equal("bar", rows[0][0])
+}
@@ -1,24 +1,36 @@
 package plan
 
 import (
+	"fmt"
 	"io"
+	"regexp"
 	"sort"
 
 	"github.com/src-d/go-mysql-server/sql"
 )
 
+// ShowTables is a node that shows the tables of a database.
 type ShowTables struct {
-	database sql.Database
+	Database sql.Database
+	// Full is true if the type of the tables is also shown.
+	Full bool
+	// Pattern is the LIKE pattern the names of the tables must match, or
+	// empty to show all of them.
+	Pattern string
 }
 
-func NewShowTables(database sql.Database) *ShowTables {
+// NewShowTables creates a new ShowTables node that shows the tables of the
+// given database whose name matches the given LIKE pattern.
+func NewShowTables(database sql.Database, full bool, pattern string) *ShowTables {
 	return &ShowTables{
-		database: database,
+		Database: database,
+		Full:     full,
+		Pattern:  pattern,
 	}
 }
 
 func (p *ShowTables) Resolved() bool {
-	_, ok := p.database.(*sql.UnresolvedDatabase)
+	_, ok := p.Database.(*sql.UnresolvedDatabase)
 	return !ok
 }
 
@@ -26,35 +38,59 @@ func (*ShowTables) Children() []sql.Node {
 	return nil
 }
 
-func (*ShowTables) Schema() sql.Schema {
-	return sql.Schema{{
-		Name:     "table",
+// Schema implements the Node interface. As in MySQL, the name of the column
+// with the names of the tables contains the name of the database.
+func (p *ShowTables) Schema() sql.Schema {
+	schema := sql.Schema{{
+		Name:     "Tables_in_" + p.Database.Name(),
 		Type:     sql.Text,
 		Nullable: false,
 	}}
+
+	if p.Full {
+		schema = append(schema, &sql.Column{
+			Name:     "Table_type",
+			Type:     sql.Text,
+			Nullable: false,
+		})
+	}
+
+	return schema
 }
 
-func (p *ShowTables) RowIter() (sql.RowIter, error) {
+func (p *ShowTables) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	var like *regexp.Regexp
+	if p.Pattern != "" {
+		like = likeToRegexp(p.Pattern)
+	}
+
 	tableNames := []string{}
-	for key := range p.database.Tables() {
-		tableNames = append(tableNames, key)
+	for key := range p.Database.Tables() {
+		if like == nil || like.MatchString(key) {
+			tableNames = append(tableNames, key)
+		}
 	}
 
 	sort.Strings(tableNames)
 
-	return &showTablesIter{tableNames: tableNames}, nil
+	return &showTablesIter{tableNames: tableNames, full: p.Full}, nil
 }
 
 func (p *ShowTables) TransformUp(f func(sql.Node) sql.Node) sql.Node {
-	return f(NewShowTables(p.database))
+	return f(NewShowTables(p.Database, p.Full, p.Pattern))
 }
 
 func (p *ShowTables) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
 	return p
 }
 
+func (p *ShowTables) String() string {
+	return fmt.Sprintf("ShowTables(%s)", p.Database.Name())
+}
+
 type showTablesIter struct {
 	tableNames []string
+	full       bool
 	idx        int
 }
 
@@ -62,7 +98,11 @@ func (i *showTablesIter) Next() (sql.Row, error) {
 	if i.idx >= len(i.tableNames) {
 		return nil, io.EOF
 	}
+
 	row := sql.NewRow(i.tableNames[i.idx])
+	if i.full {
+		row = append(row, "BASE TABLE")
+	}
 	i.idx++
 
 	return row, nil
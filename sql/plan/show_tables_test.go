@@ -5,14 +5,16 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"github.com/src-d/go-mysql-server/mem"
 	"github.com/src-d/go-mysql-server/sql"
 )
 
 func TestShowTables(t *testing.T) {
 	assert := assert.New(t)
+	ctx := sql.NewEmptyContext()
 
-	unresolvedShowTables := NewShowTables(&sql.UnresolvedDatabase{})
+	unresolvedShowTables := NewShowTables(sql.NewUnresolvedDatabase(""), false, "")
 
 	assert.False(unresolvedShowTables.Resolved())
 	assert.Nil(unresolvedShowTables.Children())
@@ -22,11 +24,11 @@ func TestShowTables(t *testing.T) {
 	db.AddTable("test2", mem.NewTable("test2", nil))
 	db.AddTable("test3", mem.NewTable("test3", nil))
 
-	resolvedShowTables := NewShowTables(db)
+	resolvedShowTables := NewShowTables(db, false, "")
 	assert.True(resolvedShowTables.Resolved())
 	assert.Nil(resolvedShowTables.Children())
 
-	iter, err := resolvedShowTables.RowIter()
+	iter, err := resolvedShowTables.RowIter(ctx)
 	assert.Nil(err)
 
 	res, err := iter.Next()
@@ -44,3 +46,25 @@ func TestShowTables(t *testing.T) {
 	_, err = iter.Next()
 	assert.Equal(io.EOF, err)
 }
+
+func TestShowTables_Full(t *testing.T) {
+	require := require.New(t)
+
+	db := mem.NewDatabase("test")
+	db.AddTable("test1", mem.NewTable("test1", nil))
+	db.AddTable("test2", mem.NewTable("test2", nil))
+	db.AddTable("other", mem.NewTable("other", nil))
+
+	n := NewShowTables(db, true, "TEST%")
+	require.Equal(sql.Schema{
+		{Name: "Tables_in_test", Type: sql.Text},
+		{Name: "Table_type", Type: sql.Text},
+	}, n.Schema())
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), n)
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		{"test1", "BASE TABLE"},
+		{"test2", "BASE TABLE"},
+	}, rows)
+}
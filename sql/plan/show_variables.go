@@ -0,0 +1,110 @@
+package plan
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// ShowVariables is a node that shows the values of the system variables.
+type ShowVariables struct {
+	scope   sql.SystemVariableScope
+	pattern string
+}
+
+// NewShowVariables creates a new ShowVariables node that shows the
+// variables in the given scope whose name matches the given LIKE pattern. An
+// empty pattern matches all the variables.
+func NewShowVariables(scope sql.SystemVariableScope, pattern string) *ShowVariables {
+	return &ShowVariables{scope, pattern}
+}
+
+// Resolved implements the Node interface.
+func (*ShowVariables) Resolved() bool {
+	return true
+}
+
+// Children implements the Node interface.
+func (*ShowVariables) Children() []sql.Node {
+	return nil
+}
+
+// Schema implements the Node interface.
+func (*ShowVariables) Schema() sql.Schema {
+	return sql.Schema{
+		{Name: "Variable_name", Type: sql.Text, Nullable: false},
+		{Name: "Value", Type: sql.Text, Nullable: true},
+	}
+}
+
+// RowIter implements the Node interface.
+func (p *ShowVariables) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	var like *regexp.Regexp
+	if p.pattern != "" {
+		like = likeToRegexp(p.pattern)
+	}
+
+	vars := ctx.SystemVariables(p.scope)
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		if like == nil || like.MatchString(name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	rows := make([]sql.Row, len(names))
+	for i, name := range names {
+		var value interface{}
+		if v := vars[name]; v != nil {
+			value = sql.MustConvert(sql.Text, v)
+		}
+
+		rows[i] = sql.NewRow(name, value)
+	}
+
+	return sql.RowsToRowIter(rows...), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (p *ShowVariables) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(NewShowVariables(p.scope, p.pattern))
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (p *ShowVariables) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return p
+}
+
+func (p *ShowVariables) String() string {
+	return fmt.Sprintf("ShowVariables(%s)", p.scope)
+}
+
+// likeToRegexp converts a case insensitive LIKE pattern into a regular
+// expression matching the whole string.
+func likeToRegexp(pattern string) *regexp.Regexp {
+	var buf = []string{"(?is)^"}
+	var escaped bool
+	for _, r := range pattern {
+		s := string(r)
+		switch {
+		case escaped:
+			buf = append(buf, regexp.QuoteMeta(s))
+			escaped = false
+		case r == '\\':
+			escaped = true
+		case r == '%':
+			buf = append(buf, ".*")
+		case r == '_':
+			buf = append(buf, ".")
+		default:
+			buf = append(buf, regexp.QuoteMeta(s))
+		}
+	}
+
+	return regexp.MustCompile(strings.Join(append(buf, "$"), ""))
+}
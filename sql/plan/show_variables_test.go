@@ -0,0 +1,53 @@
+package plan
+
+import (
+	"testing"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestShowVariables(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+	require.NoError(ctx.SetSystemVariable(sql.SessionScope, "net_read_timeout", 5))
+
+	rows, err := sql.NodeToRows(ctx, NewShowVariables(sql.SessionScope, "net\\_%"))
+	require.NoError(err)
+	require.Equal([]sql.Row{
+		sql.NewRow("net_buffer_length", "16384"),
+		sql.NewRow("net_read_timeout", "5"),
+		sql.NewRow("net_write_timeout", "60"),
+	}, rows)
+
+	rows, err = sql.NodeToRows(ctx, NewShowVariables(sql.GlobalScope, "NET_READ_TIMEOUT"))
+	require.NoError(err)
+	require.Equal([]sql.Row{sql.NewRow("net_read_timeout", "30")}, rows)
+
+	rows, err = sql.NodeToRows(ctx, NewShowVariables(sql.DefaultScope, ""))
+	require.NoError(err)
+	require.Len(rows, len(ctx.SystemVariables(sql.SessionScope)))
+}
+
+func TestLikeToRegexp(t *testing.T) {
+	testCases := []struct {
+		pattern string
+		value   string
+		matches bool
+	}{
+		{"abc", "ABC", true},
+		{"a%", "abc", true},
+		{"a_c", "abc", true},
+		{"a_c", "abbc", false},
+		{"a\\_c", "abc", false},
+		{"a\\_c", "a_c", true},
+		{"a.c", "abc", false},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.pattern+" "+tt.value, func(t *testing.T) {
+			require.Equal(t, tt.matches, likeToRegexp(tt.pattern).MatchString(tt.value))
+		})
+	}
+}
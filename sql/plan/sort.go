@@ -1,8 +1,10 @@
 package plan
 
 import (
+	"fmt"
 	"io"
 	"sort"
+	"strings"
 
 	"github.com/src-d/go-mysql-server/sql"
 )
@@ -52,9 +54,9 @@ func (p *Sort) expressionsResolved() bool {
 	return true
 }
 
-func (s *Sort) RowIter() (sql.RowIter, error) {
+func (s *Sort) RowIter(ctx *sql.Context) (sql.RowIter, error) {
 
-	i, err := s.UnaryNode.Child.RowIter()
+	i, err := s.UnaryNode.Child.RowIter(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +81,23 @@ func (s *Sort) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql
 	return n
 }
 
+func (s *Sort) String() string {
+	fields := make([]string, len(s.SortFields))
+	for i, f := range s.SortFields {
+		order := "ASC"
+		if f.Order == Descending {
+			order = "DESC"
+		}
+
+		fields[i] = fmt.Sprintf("%s %s", f.Column, order)
+	}
+
+	pr := sql.NewTreePrinter()
+	pr.WriteNode("Sort(%s)", strings.Join(fields, ", "))
+	pr.WriteChildren(nodeString(s.Child))
+	return pr.String()
+}
+
 type sortIter struct {
 	s          *Sort
 	childIter  sql.RowIter
@@ -12,6 +12,7 @@ import (
 
 func TestSort(t *testing.T) {
 	require := require.New(t)
+	ctx := sql.NewEmptyContext()
 
 	data := []sql.Row{
 		sql.NewRow("c", nil),
@@ -28,7 +29,7 @@ func TestSort(t *testing.T) {
 
 	child := mem.NewTable("test", schema)
 	for _, row := range data {
-		require.NoError(child.Insert(row))
+		require.NoError(child.Insert(sql.NewEmptyContext(), row))
 	}
 
 	sf := []SortField{
@@ -46,13 +47,14 @@ func TestSort(t *testing.T) {
 		sql.NewRow("a", int32(3)),
 	}
 
-	actual, err := sql.NodeToRows(s)
+	actual, err := sql.NodeToRows(ctx, s)
 	require.NoError(err)
 	require.Equal(expected, actual)
 }
 
 func TestSortAscending(t *testing.T) {
 	require := require.New(t)
+	ctx := sql.NewEmptyContext()
 
 	data := []sql.Row{
 		sql.NewRow("c"),
@@ -68,7 +70,7 @@ func TestSortAscending(t *testing.T) {
 
 	child := mem.NewTable("test", schema)
 	for _, row := range data {
-		require.NoError(child.Insert(row))
+		require.NoError(child.Insert(sql.NewEmptyContext(), row))
 	}
 
 	sf := []SortField{
@@ -85,13 +87,14 @@ func TestSortAscending(t *testing.T) {
 		sql.NewRow("d"),
 	}
 
-	actual, err := sql.NodeToRows(s)
+	actual, err := sql.NodeToRows(ctx, s)
 	require.NoError(err)
 	require.Equal(expected, actual)
 }
 
 func TestSortDescending(t *testing.T) {
 	require := require.New(t)
+	ctx := sql.NewEmptyContext()
 
 	data := []sql.Row{
 		sql.NewRow("c"),
@@ -107,7 +110,7 @@ func TestSortDescending(t *testing.T) {
 
 	child := mem.NewTable("test", schema)
 	for _, row := range data {
-		require.NoError(child.Insert(row))
+		require.NoError(child.Insert(sql.NewEmptyContext(), row))
 	}
 
 	sf := []SortField{
@@ -124,7 +127,7 @@ func TestSortDescending(t *testing.T) {
 		sql.NewRow("a"),
 	}
 
-	actual, err := sql.NodeToRows(s)
+	actual, err := sql.NodeToRows(ctx, s)
 	require.NoError(err)
 	require.Equal(expected, actual)
 }
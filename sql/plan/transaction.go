@@ -0,0 +1,142 @@
+package plan
+
+import "github.com/src-d/go-mysql-server/sql"
+
+// StartTransaction is a node that starts an explicit transaction, committing
+// the current one if there is any.
+type StartTransaction struct{}
+
+// NewStartTransaction creates a new StartTransaction node.
+func NewStartTransaction() *StartTransaction {
+	return &StartTransaction{}
+}
+
+// Resolved implements the Node interface.
+func (*StartTransaction) Resolved() bool {
+	return true
+}
+
+// Children implements the Node interface.
+func (*StartTransaction) Children() []sql.Node {
+	return nil
+}
+
+// Schema implements the Node interface.
+func (*StartTransaction) Schema() sql.Schema {
+	return sql.Schema{}
+}
+
+// RowIter implements the Node interface.
+func (*StartTransaction) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	if err := ctx.BeginTransaction(); err != nil {
+		return nil, err
+	}
+
+	return sql.RowsToRowIter(), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (p *StartTransaction) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(p)
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (p *StartTransaction) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return p
+}
+
+func (*StartTransaction) String() string {
+	return "StartTransaction"
+}
+
+// Commit is a node that commits the current transaction.
+type Commit struct{}
+
+// NewCommit creates a new Commit node.
+func NewCommit() *Commit {
+	return &Commit{}
+}
+
+// Resolved implements the Node interface.
+func (*Commit) Resolved() bool {
+	return true
+}
+
+// Children implements the Node interface.
+func (*Commit) Children() []sql.Node {
+	return nil
+}
+
+// Schema implements the Node interface.
+func (*Commit) Schema() sql.Schema {
+	return sql.Schema{}
+}
+
+// RowIter implements the Node interface.
+func (*Commit) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	if err := ctx.CommitTransaction(); err != nil {
+		return nil, err
+	}
+
+	return sql.RowsToRowIter(), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (p *Commit) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(p)
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (p *Commit) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return p
+}
+
+func (*Commit) String() string {
+	return "Commit"
+}
+
+// Rollback is a node that discards the changes of the current transaction.
+type Rollback struct{}
+
+// NewRollback creates a new Rollback node.
+func NewRollback() *Rollback {
+	return &Rollback{}
+}
+
+// Resolved implements the Node interface.
+func (*Rollback) Resolved() bool {
+	return true
+}
+
+// Children implements the Node interface.
+func (*Rollback) Children() []sql.Node {
+	return nil
+}
+
+// Schema implements the Node interface.
+func (*Rollback) Schema() sql.Schema {
+	return sql.Schema{}
+}
+
+// RowIter implements the Node interface.
+func (*Rollback) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	if err := ctx.RollbackTransaction(); err != nil {
+		return nil, err
+	}
+
+	return sql.RowsToRowIter(), nil
+}
+
+// TransformUp implements the Transformable interface.
+func (p *Rollback) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(p)
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (p *Rollback) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return p
+}
+
+func (*Rollback) String() string {
+	return "Rollback"
+}
@@ -8,10 +8,19 @@ import (
 
 type UnresolvedTable struct {
 	Name string
+	// Database is the name of the database of the table, or empty if it's
+	// in the current database.
+	Database string
 }
 
 func NewUnresolvedTable(name string) *UnresolvedTable {
-	return &UnresolvedTable{name}
+	return &UnresolvedTable{Name: name}
+}
+
+// NewQualifiedUnresolvedTable creates an unresolved table in the database
+// with the given name.
+func NewQualifiedUnresolvedTable(database, name string) *UnresolvedTable {
+	return &UnresolvedTable{Name: name, Database: database}
 }
 
 func (*UnresolvedTable) Resolved() bool {
@@ -26,14 +35,22 @@ func (*UnresolvedTable) Schema() sql.Schema {
 	return sql.Schema{}
 }
 
-func (*UnresolvedTable) RowIter() (sql.RowIter, error) {
+func (*UnresolvedTable) RowIter(ctx *sql.Context) (sql.RowIter, error) {
 	return nil, fmt.Errorf("unresolved table")
 }
 
 func (p *UnresolvedTable) TransformUp(f func(sql.Node) sql.Node) sql.Node {
-	return f(NewUnresolvedTable(p.Name))
+	return f(NewQualifiedUnresolvedTable(p.Database, p.Name))
 }
 
 func (p *UnresolvedTable) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
 	return p
 }
+
+func (p *UnresolvedTable) String() string {
+	if p.Database != "" {
+		return fmt.Sprintf("UnresolvedTable(%s.%s)", p.Database, p.Name)
+	}
+
+	return fmt.Sprintf("UnresolvedTable(%s)", p.Name)
+}
@@ -1,6 +1,9 @@
 package plan
 
 import (
+	"fmt"
+	"strings"
+
 	"github.com/src-d/go-mysql-server/sql"
 )
 
@@ -44,7 +47,7 @@ func (p *Values) Resolved() bool {
 	return true
 }
 
-func (p *Values) RowIter() (sql.RowIter, error) {
+func (p *Values) RowIter(ctx *sql.Context) (sql.RowIter, error) {
 	rows := make([]sql.Row, len(p.ExpressionTuples))
 	for i, et := range p.ExpressionTuples {
 		vals := make([]interface{}, len(et))
@@ -70,3 +73,12 @@ func (p *Values) TransformExpressionsUp(f func(sql.Expression) sql.Expression) s
 
 	return NewValues(ets)
 }
+
+func (p *Values) String() string {
+	tuples := make([]string, len(p.ExpressionTuples))
+	for i, t := range p.ExpressionTuples {
+		tuples[i] = "(" + exprsString(t) + ")"
+	}
+
+	return fmt.Sprintf("Values(%s)", strings.Join(tuples, ", "))
+}
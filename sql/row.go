@@ -39,8 +39,8 @@ func RowIterToRows(i RowIter) ([]Row, error) {
 	return rows, i.Close()
 }
 
-func NodeToRows(n Node) ([]Row, error) {
-	i, err := n.RowIter()
+func NodeToRows(ctx *Context, n Node) ([]Row, error) {
+	i, err := n.RowIter(ctx)
 	if err != nil {
 		return nil, err
 	}
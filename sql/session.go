@@ -0,0 +1,380 @@
+package sql
+
+import (
+	"context"
+	"sort"
+	"sync"
+)
+
+// Session holds the state of a client connection: the values of the system
+// variables in session scope and the user-defined variables.
+type Session interface {
+	// ID returns the unique identifier of the session.
+	ID() uint32
+	// SystemVariable returns the type and value of the system variable with
+	// the given name in the given scope.
+	SystemVariable(scope SystemVariableScope, name string) (Type, interface{}, error)
+	// SetSystemVariable sets the value of a system variable in the given
+	// scope.
+	SetSystemVariable(scope SystemVariableScope, name string, value interface{}) error
+	// SystemVariables returns the values of all the system variables visible
+	// in the given scope.
+	SystemVariables(scope SystemVariableScope) map[string]interface{}
+	// UserVariable returns the type and value of a user-defined variable. If
+	// the variable has not been set, it returns a NULL value.
+	UserVariable(name string) (Type, interface{})
+	// SetUserVariable sets the value of a user-defined variable.
+	SetUserVariable(name string, typ Type, value interface{})
+	// BeginTransaction starts an explicit transaction, committing the
+	// current one if there is any.
+	BeginTransaction() error
+	// CommitTransaction commits the changes of the current transaction and
+	// ends it.
+	CommitTransaction() error
+	// RollbackTransaction discards the changes of the current transaction
+	// and ends it.
+	RollbackTransaction() error
+	// InTransaction returns whether the session is inside a transaction,
+	// either because one was started explicitly or because autocommit is
+	// disabled.
+	InTransaction() bool
+	// Transaction returns the transaction of the session in the given
+	// database, starting it the first time the database is used in the
+	// current transaction. It returns nil if the session is not inside a
+	// transaction.
+	Transaction(db TransactionalDatabase) (Transaction, error)
+	// BeginStatement marks the start of a statement in the transactions of
+	// the session.
+	BeginStatement()
+	// RollbackStatement discards the changes made by the current statement
+	// in the transactions of the session, which stay open.
+	RollbackStatement() error
+	// Warn records a warning found while executing the current statement.
+	Warn(w Warning)
+	// Warnings returns the warnings recorded since they were last cleared.
+	Warnings() []Warning
+	// ClearWarnings discards the recorded warnings. It's called before a
+	// statement is executed.
+	ClearWarnings()
+}
+
+// Warning is a problem found while executing a statement that doesn't stop
+// its execution, such as a value changed by a conversion.
+type Warning struct {
+	// Code is the MySQL error code of the warning.
+	Code int
+	// Message describes the warning.
+	Message string
+}
+
+// ErrTruncatedWrongValue is the code of the warning of a value changed when
+// converted to a type.
+const ErrTruncatedWrongValue = 1292
+
+type typedValue struct {
+	typ   Type
+	value interface{}
+}
+
+// BaseSession is the basic Session implementation. It is safe for concurrent
+// use.
+type BaseSession struct {
+	id      uint32
+	globals *SystemVariables
+
+	mu       sync.RWMutex
+	session  map[string]interface{}
+	userVars map[string]typedValue
+	// explicit is true if a transaction was started with BEGIN.
+	explicit bool
+	// txs holds the transactions of the session by database name.
+	txs      map[string]Transaction
+	warnings []Warning
+}
+
+// NewSession creates a new session with the given id. Session variables
+// take their initial value from the current global values in the given
+// registry. If globals is nil, a registry with the default values is used.
+func NewSession(id uint32, globals *SystemVariables) *BaseSession {
+	if globals == nil {
+		globals = NewSystemVariables()
+	}
+
+	return &BaseSession{
+		id:       id,
+		globals:  globals,
+		session:  globals.sessionDefaults(),
+		userVars: make(map[string]typedValue),
+		txs:      make(map[string]Transaction),
+	}
+}
+
+// NewBaseSession creates a new session with id 0 and the default values for
+// all system variables.
+func NewBaseSession() *BaseSession {
+	return NewSession(0, nil)
+}
+
+// ID implements the Session interface.
+func (s *BaseSession) ID() uint32 {
+	return s.id
+}
+
+// SystemVariable implements the Session interface.
+func (s *BaseSession) SystemVariable(
+	scope SystemVariableScope,
+	name string,
+) (Type, interface{}, error) {
+	def, err := s.globals.definition(name)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if scope == DefaultScope {
+		scope = def.defaultScope()
+	}
+
+	switch scope {
+	case SessionScope:
+		if !def.Scope.has(SessionScope) {
+			return nil, nil, errGlobalOnlyVariable(def.Name)
+		}
+
+		s.mu.RLock()
+		v := s.session[def.Name]
+		s.mu.RUnlock()
+		return def.Type, v, nil
+	default:
+		v, err := s.globals.Get(def.Name)
+		return def.Type, v, err
+	}
+}
+
+// SetSystemVariable implements the Session interface.
+func (s *BaseSession) SetSystemVariable(
+	scope SystemVariableScope,
+	name string,
+	value interface{},
+) error {
+	if scope != GlobalScope {
+		def, err := s.globals.definition(name)
+		if err != nil {
+			return err
+		}
+
+		if def.ReadOnly {
+			return errReadOnlyVariable(def.Name)
+		}
+
+		if !def.Scope.has(SessionScope) {
+			return errGlobalOnlyVariable(def.Name)
+		}
+
+		v, err := def.convert(value)
+		if err != nil {
+			return err
+		}
+
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		// Like in MySQL, turning autocommit back on commits the open
+		// transaction.
+		if def.Name == "autocommit" && s.session[def.Name] == int64(0) && v == int64(1) {
+			err = s.endTransaction(Transaction.Commit)
+		}
+
+		s.session[def.Name] = v
+		return err
+	}
+
+	return s.globals.Set(name, value)
+}
+
+// SystemVariables implements the Session interface.
+func (s *BaseSession) SystemVariables(scope SystemVariableScope) map[string]interface{} {
+	vars := s.globals.globals()
+	if scope == GlobalScope {
+		return vars
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for k, v := range s.session {
+		vars[k] = v
+	}
+
+	return vars
+}
+
+// UserVariable implements the Session interface.
+func (s *BaseSession) UserVariable(name string) (Type, interface{}) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	v, ok := s.userVars[normalizeVariableName(name)]
+	if !ok {
+		return Null, nil
+	}
+
+	return v.typ, v.value
+}
+
+// SetUserVariable implements the Session interface.
+func (s *BaseSession) SetUserVariable(name string, typ Type, value interface{}) {
+	s.mu.Lock()
+	s.userVars[normalizeVariableName(name)] = typedValue{typ, value}
+	s.mu.Unlock()
+}
+
+// BeginTransaction implements the Session interface.
+func (s *BaseSession) BeginTransaction() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if err := s.endTransaction(Transaction.Commit); err != nil {
+		return err
+	}
+
+	s.explicit = true
+	return nil
+}
+
+// CommitTransaction implements the Session interface. Transactions in
+// different databases are committed one after another, so a failure may
+// leave some of them committed.
+func (s *BaseSession) CommitTransaction() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.endTransaction(Transaction.Commit)
+}
+
+// RollbackTransaction implements the Session interface.
+func (s *BaseSession) RollbackTransaction() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.endTransaction(Transaction.Rollback)
+}
+
+// endTransaction commits or rolls back the transactions in all databases and
+// ends the current transaction. The first error found is returned. It must
+// be called with the lock held.
+func (s *BaseSession) endTransaction(end func(Transaction) error) error {
+	names := make([]string, 0, len(s.txs))
+	for name := range s.txs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var err error
+	for _, name := range names {
+		if e := end(s.txs[name]); e != nil && err == nil {
+			err = e
+		}
+	}
+
+	s.txs = make(map[string]Transaction)
+	s.explicit = false
+	return err
+}
+
+// InTransaction implements the Session interface.
+func (s *BaseSession) InTransaction() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.inTransaction()
+}
+
+func (s *BaseSession) inTransaction() bool {
+	return s.explicit || s.session["autocommit"] == int64(0)
+}
+
+// Transaction implements the Session interface.
+func (s *BaseSession) Transaction(db TransactionalDatabase) (Transaction, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.inTransaction() {
+		return nil, nil
+	}
+
+	if tx, ok := s.txs[db.Name()]; ok {
+		return tx, nil
+	}
+
+	tx, err := db.BeginTransaction()
+	if err != nil {
+		return nil, err
+	}
+
+	s.txs[db.Name()] = tx
+	return tx, nil
+}
+
+// BeginStatement implements the Session interface.
+func (s *BaseSession) BeginStatement() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, tx := range s.txs {
+		if tx, ok := tx.(StatementTransaction); ok {
+			tx.BeginStatement()
+		}
+	}
+}
+
+// RollbackStatement implements the Session interface. Transactions that
+// can't discard the changes of a statement keep them.
+func (s *BaseSession) RollbackStatement() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var err error
+	for _, tx := range s.txs {
+		if tx, ok := tx.(StatementTransaction); ok {
+			if e := tx.RollbackStatement(); e != nil && err == nil {
+				err = e
+			}
+		}
+	}
+
+	return err
+}
+
+// Warn implements the Session interface.
+func (s *BaseSession) Warn(w Warning) {
+	s.mu.Lock()
+	s.warnings = append(s.warnings, w)
+	s.mu.Unlock()
+}
+
+// Warnings implements the Session interface.
+func (s *BaseSession) Warnings() []Warning {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return append([]Warning(nil), s.warnings...)
+}
+
+// ClearWarnings implements the Session interface.
+func (s *BaseSession) ClearWarnings() {
+	s.mu.Lock()
+	s.warnings = nil
+	s.mu.Unlock()
+}
+
+// Context of the query execution.
+type Context struct {
+	context.Context
+	Session
+}
+
+// NewContext creates a new query context from the given context and session.
+func NewContext(ctx context.Context, session Session) *Context {
+	return &Context{ctx, session}
+}
+
+// NewEmptyContext returns a context with a new base session and no
+// cancellation.
+func NewEmptyContext() *Context {
+	return NewContext(context.TODO(), NewBaseSession())
+}
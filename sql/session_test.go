@@ -0,0 +1,105 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionSystemVariables(t *testing.T) {
+	require := require.New(t)
+
+	globals := NewSystemVariables()
+	s := NewSession(1, globals)
+
+	typ, v, err := s.SystemVariable(DefaultScope, "autocommit")
+	require.NoError(err)
+	require.Equal(Int64, typ)
+	require.Equal(int64(1), v)
+
+	require.NoError(s.SetSystemVariable(DefaultScope, "AUTOCOMMIT", "OFF"))
+	_, v, err = s.SystemVariable(SessionScope, "autocommit")
+	require.NoError(err)
+	require.Equal(int64(0), v)
+
+	_, v, err = s.SystemVariable(GlobalScope, "autocommit")
+	require.NoError(err)
+	require.Equal(int64(1), v)
+
+	require.NoError(s.SetSystemVariable(GlobalScope, "max_allowed_packet", 1024))
+	_, v, err = s.SystemVariable(DefaultScope, "max_allowed_packet")
+	require.NoError(err)
+	require.Equal(int64(4194304), v)
+
+	s2 := NewSession(2, globals)
+	_, v, err = s2.SystemVariable(DefaultScope, "max_allowed_packet")
+	require.NoError(err)
+	require.Equal(int64(1024), v)
+
+	_, v, err = s.SystemVariable(DefaultScope, "version_comment")
+	require.NoError(err)
+	require.Equal("go-mysql-server", v)
+
+	_, _, err = s.SystemVariable(SessionScope, "version_comment")
+	require.Error(err)
+
+	_, _, err = s.SystemVariable(DefaultScope, "foo")
+	require.EqualError(err, "unknown system variable: foo")
+
+	require.Error(s.SetSystemVariable(DefaultScope, "foo", 1))
+	require.Error(s.SetSystemVariable(GlobalScope, "version", "1"))
+	require.Error(s.SetSystemVariable(DefaultScope, "autocommit", "bar"))
+
+	require.NoError(s.SetSystemVariable(DefaultScope, "autocommit", nil))
+	_, v, err = s.SystemVariable(DefaultScope, "autocommit")
+	require.NoError(err)
+	require.Equal(int64(1), v)
+}
+
+func TestSessionSystemVariablesByScope(t *testing.T) {
+	require := require.New(t)
+
+	s := NewBaseSession()
+	require.NoError(s.SetSystemVariable(SessionScope, "sql_mode", "ANSI"))
+
+	vars := s.SystemVariables(SessionScope)
+	require.Equal("ANSI", vars["sql_mode"])
+	require.Equal("5.7.9", vars["version"])
+
+	vars = s.SystemVariables(GlobalScope)
+	require.Equal("STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION", vars["sql_mode"])
+}
+
+func TestSessionUserVariables(t *testing.T) {
+	require := require.New(t)
+
+	s := NewBaseSession()
+	typ, v := s.UserVariable("foo")
+	require.Equal(Null, typ)
+	require.Nil(v)
+
+	s.SetUserVariable("Foo", Text, "bar")
+	typ, v = s.UserVariable("foo")
+	require.Equal(Text, typ)
+	require.Equal("bar", v)
+}
+
+func TestSystemVariablesRegister(t *testing.T) {
+	require := require.New(t)
+
+	vars := NewSystemVariables()
+	vars.Register(SystemVariable{Name: "My_Var", Type: Text, Default: "foo"})
+
+	def, ok := vars.Definition("my_var")
+	require.True(ok)
+	require.Equal(BothScopes, def.Scope)
+
+	v, err := vars.Get("MY_VAR")
+	require.NoError(err)
+	require.Equal("foo", v)
+
+	require.NoError(vars.Set("my_var", "bar"))
+	v, err = vars.Get("my_var")
+	require.NoError(err)
+	require.Equal("bar", v)
+}
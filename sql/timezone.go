@@ -0,0 +1,71 @@
+package sql
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var offsetRegexp = regexp.MustCompile(`^([+-])(\d\d?):(\d\d)$`)
+
+// ParseTimeZone returns the location of a time zone given as an offset from
+// UTC such as +02:00, or as the name of a time zone such as UTC or
+// Europe/Madrid.
+func ParseTimeZone(name string) (*time.Location, error) {
+	if m := offsetRegexp.FindStringSubmatch(name); m != nil {
+		hours, _ := strconv.Atoi(m[2])
+		minutes, _ := strconv.Atoi(m[3])
+		offset := hours*60 + minutes
+		if minutes > 59 || offset > 14*60 || (m[1] == "-" && offset > 13*60+59) {
+			return nil, fmt.Errorf("unknown or incorrect time zone: %q", name)
+		}
+
+		if m[1] == "-" {
+			offset = -offset
+		}
+
+		return time.FixedZone(name, offset*60), nil
+	}
+
+	loc, err := time.LoadLocation(name)
+	if err != nil || name == "" || strings.EqualFold(name, "local") {
+		return nil, fmt.Errorf("unknown or incorrect time zone: %q", name)
+	}
+
+	return loc, nil
+}
+
+// TimeZone returns the location of the time zone of a session, which is the
+// value of its time_zone system variable. SYSTEM is the time zone of the
+// server, given by the system_time_zone variable.
+func TimeZone(s Session) (*time.Location, error) {
+	_, v, err := s.SystemVariable(DefaultScope, "time_zone")
+	if err != nil {
+		return nil, err
+	}
+
+	name, _ := v.(string)
+	if strings.EqualFold(name, "SYSTEM") {
+		_, v, err = s.SystemVariable(GlobalScope, "system_time_zone")
+		if err != nil {
+			return nil, err
+		}
+		name, _ = v.(string)
+	}
+
+	return ParseTimeZone(name)
+}
+
+// ConvertIn converts a value to a type like its Convert method does, but
+// strings and numbers converted to TIMESTAMP values are read as times in
+// the given time zone instead of UTC, as MySQL reads them in the time zone
+// of the session.
+func ConvertIn(t Type, v interface{}, loc *time.Location) (interface{}, error) {
+	if t != Timestamp || v == nil {
+		return t.Convert(v)
+	}
+
+	return toTimeIn(v, loc)
+}
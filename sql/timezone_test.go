@@ -0,0 +1,47 @@
+package sql
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseTimeZone(t *testing.T) {
+	require := require.New(t)
+
+	loc, err := ParseTimeZone("+05:30")
+	require.NoError(err)
+	_, offset := time.Date(2018, 1, 2, 0, 0, 0, 0, loc).Zone()
+	require.Equal(5*3600+30*60, offset)
+
+	loc, err = ParseTimeZone("-8:00")
+	require.NoError(err)
+	_, offset = time.Date(2018, 1, 2, 0, 0, 0, 0, loc).Zone()
+	require.Equal(-8*3600, offset)
+
+	loc, err = ParseTimeZone("UTC")
+	require.NoError(err)
+	require.Equal(time.UTC, loc)
+
+	for _, name := range []string{"", "Local", "+15:00", "-14:00", "+01:60", "Nowhere/Never"} {
+		_, err := ParseTimeZone(name)
+		require.Error(err, name)
+	}
+}
+
+func TestTimeZone(t *testing.T) {
+	require := require.New(t)
+	s := NewBaseSession()
+
+	loc, err := TimeZone(s)
+	require.NoError(err)
+	require.Equal(time.UTC, loc)
+
+	require.NoError(s.SetSystemVariable(SessionScope, "time_zone", "+01:00"))
+	loc, err = TimeZone(s)
+	require.NoError(err)
+	require.Equal("+01:00", loc.String())
+
+	require.Error(s.SetSystemVariable(SessionScope, "time_zone", "foo"))
+}
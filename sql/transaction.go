@@ -0,0 +1,37 @@
+package sql
+
+import "errors"
+
+// ErrTransactionClosed is returned when a transaction is used after it has
+// been committed or rolled back.
+var ErrTransactionClosed = errors.New("transaction has already been committed or rolled back")
+
+// Transaction is a set of changes to a database that are applied atomically
+// when it's committed. The changes of a transaction are not visible to other
+// transactions until then.
+type Transaction interface {
+	// Commit applies the changes made in the transaction.
+	Commit() error
+	// Rollback discards the changes made in the transaction.
+	Rollback() error
+}
+
+// StatementTransaction is a transaction that can discard the changes of its
+// current statement, so a statement that fails inside a transaction doesn't
+// leave part of its changes in it.
+type StatementTransaction interface {
+	Transaction
+	// BeginStatement marks the start of a statement.
+	BeginStatement()
+	// RollbackStatement discards the changes made since the current
+	// statement started, or since the transaction started if it started
+	// during the statement.
+	RollbackStatement() error
+}
+
+// TransactionalDatabase is a Database that supports transactions.
+type TransactionalDatabase interface {
+	Database
+	// BeginTransaction starts a new transaction in the database.
+	BeginTransaction() (Transaction, error)
+}
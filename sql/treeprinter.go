@@ -0,0 +1,57 @@
+package sql
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+)
+
+// TreePrinter prints a node and its children as a tree, with a line for
+// the node followed by the lines of its children:
+//
+//	Project(a)
+//	 └─ Filter(a = 1)
+//	     └─ Table(foo)
+type TreePrinter struct {
+	buf bytes.Buffer
+}
+
+// NewTreePrinter creates a new TreePrinter.
+func NewTreePrinter() *TreePrinter {
+	return new(TreePrinter)
+}
+
+// WriteNode writes the line of the node. It must be called before the
+// children are written.
+func (p *TreePrinter) WriteNode(format string, args ...interface{}) {
+	fmt.Fprintf(&p.buf, format, args...)
+	p.buf.WriteString("\n")
+}
+
+// WriteChildren writes the trees of the children of the node, which are
+// the strings of their own printers.
+func (p *TreePrinter) WriteChildren(children ...string) {
+	for i, child := range children {
+		last := i == len(children)-1
+		for j, line := range strings.Split(child, "\n") {
+			switch {
+			case j == 0 && last:
+				p.buf.WriteString(" └─ ")
+			case j == 0:
+				p.buf.WriteString(" ├─ ")
+			case last:
+				p.buf.WriteString("    ")
+			default:
+				p.buf.WriteString(" │  ")
+			}
+
+			p.buf.WriteString(line)
+			p.buf.WriteString("\n")
+		}
+	}
+}
+
+// String returns the printed tree.
+func (p *TreePrinter) String() string {
+	return strings.TrimSuffix(p.buf.String(), "\n")
+}
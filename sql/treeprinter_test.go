@@ -0,0 +1,22 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestTreePrinter(t *testing.T) {
+	child := NewTreePrinter()
+	child.WriteNode("Filter(%s)", "a = 1")
+	child.WriteChildren("Table(foo)")
+
+	p := NewTreePrinter()
+	p.WriteNode("CrossJoin")
+	p.WriteChildren(child.String(), "Table(bar)")
+
+	require.Equal(t, "CrossJoin\n"+
+		" ├─ Filter(a = 1)\n"+
+		" │   └─ Table(foo)\n"+
+		" └─ Table(bar)", p.String())
+}
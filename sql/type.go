@@ -2,14 +2,19 @@ package sql
 
 import (
 	"bytes"
-	"encoding/json"
+	"encoding/binary"
+	"errors"
 	"fmt"
+	"math"
 	"reflect"
+	"regexp"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/spf13/cast"
+	"github.com/src-d/go-mysql-server/sql/decimal"
 	"github.com/src-d/go-vitess/sqltypes"
 	"github.com/src-d/go-vitess/vt/proto/query"
 )
@@ -105,13 +110,34 @@ func (t nullT) Compare(a interface{}, b interface{}) int {
 	return 0
 }
 
-var Int32 = numberT{t: sqltypes.Int32}
-var Int64 = numberT{t: sqltypes.Int64}
-var Uint32 = numberT{t: sqltypes.Uint32}
-var Uint64 = numberT{t: sqltypes.Uint64}
+// Integer types. The values of Int24 are int32 and the values of Uint24 are
+// uint32, and the rest of types have values of the Go type of their size.
+var (
+	Int8   = numberT{t: sqltypes.Int8}
+	Int16  = numberT{t: sqltypes.Int16}
+	Int24  = numberT{t: sqltypes.Int24}
+	Int32  = numberT{t: sqltypes.Int32}
+	Int64  = numberT{t: sqltypes.Int64}
+	Uint8  = numberT{t: sqltypes.Uint8}
+	Uint16 = numberT{t: sqltypes.Uint16}
+	Uint24 = numberT{t: sqltypes.Uint24}
+	Uint32 = numberT{t: sqltypes.Uint32}
+	Uint64 = numberT{t: sqltypes.Uint64}
+)
+
 var Float32 = numberT{t: sqltypes.Float32}
 var Float64 = numberT{t: sqltypes.Float64}
 
+const (
+	minInt24  = -1 << 23
+	maxInt24  = 1<<23 - 1
+	maxUint24 = 1<<24 - 1
+)
+
+// errOutOfRange is returned by the integer conversions when a value doesn't
+// fit in the integer type.
+var errOutOfRange = errors.New("value out of range")
+
 type numberT struct {
 	t query.Type
 }
@@ -121,29 +147,281 @@ func (t numberT) Type() query.Type {
 	return t.t
 }
 
-// SQL implements Type interface.
+// SQL implements Type interface. Floats are written with the fewest digits
+// that are parsed back to the same value.
 func (t numberT) SQL(v interface{}) sqltypes.Value {
-	return sqltypes.MakeTrusted(t.t, strconv.AppendInt(nil, cast.ToInt64(v), 10))
+	var b []byte
+	switch {
+	case t.t == sqltypes.Float32:
+		b = strconv.AppendFloat(nil, float64(cast.ToFloat32(v)), 'g', -1, 32)
+	case t.t == sqltypes.Float64:
+		b = strconv.AppendFloat(nil, cast.ToFloat64(v), 'g', -1, 64)
+	case sqltypes.IsUnsigned(t.t):
+		n, _ := toUint64(v)
+		b = strconv.AppendUint(nil, n, 10)
+	default:
+		n, _ := toInt64(v)
+		b = strconv.AppendInt(nil, n, 10)
+	}
+
+	return sqltypes.MakeTrusted(t.t, b)
 }
 
-// Convert implements Type interface.
+// Convert implements Type interface. Values that don't fit in an integer
+// type can't be converted, and fractional values are rounded to the nearest
+// integer.
 func (t numberT) Convert(v interface{}) (interface{}, error) {
+	if d, ok := v.(decimal.Decimal); ok && (t.t == sqltypes.Float32 || t.t == sqltypes.Float64) {
+		v = d.Float64()
+	}
+
 	switch t.t {
-	case sqltypes.Int32:
-		return cast.ToInt32E(v)
-	case sqltypes.Int64:
-		return cast.ToInt64E(v)
-	case sqltypes.Uint32:
-		return cast.ToUint32E(v)
-	case sqltypes.Uint64:
-		return cast.ToUint64E(v)
 	case sqltypes.Float32:
 		return cast.ToFloat32E(v)
 	case sqltypes.Float64:
 		return cast.ToFloat64E(v)
 	}
 
-	return nil, ErrInvalidType
+	var r interface{}
+	var err error
+	if sqltypes.IsUnsigned(t.t) {
+		r, err = t.convertUint(v)
+	} else {
+		r, err = t.convertInt(v)
+	}
+
+	if err == errOutOfRange {
+		err = fmt.Errorf("value %v is out of range for %s", v, MySQLTypeName(t))
+	}
+
+	return r, err
+}
+
+// convertInt converts a value to a signed integer type. The value is 0 if it
+// can't be converted.
+func (t numberT) convertInt(v interface{}) (interface{}, error) {
+	var min, max int64 = math.MinInt64, math.MaxInt64
+	switch t.t {
+	case sqltypes.Int8:
+		min, max = math.MinInt8, math.MaxInt8
+	case sqltypes.Int16:
+		min, max = math.MinInt16, math.MaxInt16
+	case sqltypes.Int24:
+		min, max = minInt24, maxInt24
+	case sqltypes.Int32:
+		min, max = math.MinInt32, math.MaxInt32
+	}
+
+	n, err := toInt64(v)
+	if err == nil && (n < min || n > max) {
+		err = errOutOfRange
+	}
+
+	if err != nil {
+		n = 0
+	}
+
+	switch t.t {
+	case sqltypes.Int8:
+		return int8(n), err
+	case sqltypes.Int16:
+		return int16(n), err
+	case sqltypes.Int24, sqltypes.Int32:
+		return int32(n), err
+	default:
+		return n, err
+	}
+}
+
+// convertUint converts a value to an unsigned integer type. The value is 0
+// if it can't be converted.
+func (t numberT) convertUint(v interface{}) (interface{}, error) {
+	var max uint64 = math.MaxUint64
+	switch t.t {
+	case sqltypes.Uint8:
+		max = math.MaxUint8
+	case sqltypes.Uint16:
+		max = math.MaxUint16
+	case sqltypes.Uint24:
+		max = maxUint24
+	case sqltypes.Uint32:
+		max = math.MaxUint32
+	}
+
+	n, err := toUint64(v)
+	if err == nil && n > max {
+		err = errOutOfRange
+	}
+
+	if err != nil {
+		n = 0
+	}
+
+	switch t.t {
+	case sqltypes.Uint8:
+		return uint8(n), err
+	case sqltypes.Uint16:
+		return uint16(n), err
+	case sqltypes.Uint24, sqltypes.Uint32:
+		return uint32(n), err
+	default:
+		return n, err
+	}
+}
+
+// toInt64 converts a value to an int64, rounding fractional values to the
+// nearest integer. It returns errOutOfRange if the value doesn't fit.
+func toInt64(v interface{}) (int64, error) {
+	switch v := v.(type) {
+	case int:
+		return int64(v), nil
+	case int8:
+		return int64(v), nil
+	case int16:
+		return int64(v), nil
+	case int32:
+		return int64(v), nil
+	case int64:
+		return v, nil
+	case uint, uint8, uint16, uint32, uint64:
+		n, err := toUint64(v)
+		if err != nil {
+			return 0, err
+		}
+		if n > math.MaxInt64 {
+			return 0, errOutOfRange
+		}
+		return int64(n), nil
+	case float32:
+		return floatToInt64(float64(v))
+	case float64:
+		return floatToInt64(v)
+	case decimal.Decimal:
+		n, err := strconv.ParseInt(v.Round(0, decimal.HalfUp).String(), 10, 64)
+		if err != nil {
+			return 0, errOutOfRange
+		}
+		return n, nil
+	case bool:
+		if v {
+			return 1, nil
+		}
+		return 0, nil
+	case []byte:
+		return parseInt64(string(v))
+	case string:
+		return parseInt64(v)
+	default:
+		return cast.ToInt64E(v)
+	}
+}
+
+func floatToInt64(f float64) (int64, error) {
+	f = roundHalfAway(f)
+	if math.IsNaN(f) || f < math.MinInt64 || f >= math.MaxInt64 {
+		return 0, errOutOfRange
+	}
+
+	return int64(f), nil
+}
+
+func parseInt64(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	n, err := strconv.ParseInt(s, 10, 64)
+	if err == nil {
+		return n, nil
+	}
+
+	if err.(*strconv.NumError).Err == strconv.ErrRange {
+		return 0, errOutOfRange
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid integer value %q", s)
+	}
+
+	return floatToInt64(f)
+}
+
+// toUint64 converts a value to a uint64, rounding fractional values to the
+// nearest integer. It returns errOutOfRange if the value doesn't fit, which
+// includes all negative values.
+func toUint64(v interface{}) (uint64, error) {
+	switch v := v.(type) {
+	case uint:
+		return uint64(v), nil
+	case uint8:
+		return uint64(v), nil
+	case uint16:
+		return uint64(v), nil
+	case uint32:
+		return uint64(v), nil
+	case uint64:
+		return v, nil
+	case float32:
+		return floatToUint64(float64(v))
+	case float64:
+		return floatToUint64(v)
+	case decimal.Decimal:
+		n, err := strconv.ParseUint(v.Round(0, decimal.HalfUp).String(), 10, 64)
+		if err != nil {
+			return 0, errOutOfRange
+		}
+		return n, nil
+	case []byte:
+		return parseUint64(string(v))
+	case string:
+		return parseUint64(v)
+	case int, int8, int16, int32, int64, bool:
+		n, err := toInt64(v)
+		if err != nil {
+			return 0, err
+		}
+		if n < 0 {
+			return 0, errOutOfRange
+		}
+		return uint64(n), nil
+	default:
+		return cast.ToUint64E(v)
+	}
+}
+
+func floatToUint64(f float64) (uint64, error) {
+	f = roundHalfAway(f)
+	if math.IsNaN(f) || f < 0 || f >= math.MaxUint64 {
+		return 0, errOutOfRange
+	}
+
+	return uint64(f), nil
+}
+
+func parseUint64(s string) (uint64, error) {
+	s = strings.TrimSpace(s)
+	n, err := strconv.ParseUint(s, 10, 64)
+	if err == nil {
+		return n, nil
+	}
+
+	f, ferr := strconv.ParseFloat(s, 64)
+	if ferr != nil {
+		if err.(*strconv.NumError).Err == strconv.ErrRange {
+			return 0, errOutOfRange
+		}
+		return 0, fmt.Errorf("invalid integer value %q", s)
+	}
+
+	return floatToUint64(f)
+}
+
+// roundHalfAway rounds a float to the nearest integer, and halves away from
+// zero, like MySQL does when it stores them in integer columns.
+func roundHalfAway(f float64) float64 {
+	if f < 0 {
+		return math.Ceil(f - 0.5)
+	}
+
+	return math.Floor(f + 0.5)
 }
 
 // Compare implements Type interface.
@@ -153,7 +431,15 @@ func (t numberT) Compare(a interface{}, b interface{}) int {
 	}
 
 	switch t.t {
-	case sqltypes.Int32:
+	case sqltypes.Int8:
+		if a.(int8) < b.(int8) {
+			return -1
+		}
+	case sqltypes.Int16:
+		if a.(int16) < b.(int16) {
+			return -1
+		}
+	case sqltypes.Int24, sqltypes.Int32:
 		if a.(int32) < b.(int32) {
 			return -1
 		}
@@ -161,7 +447,15 @@ func (t numberT) Compare(a interface{}, b interface{}) int {
 		if a.(int64) < b.(int64) {
 			return -1
 		}
-	case sqltypes.Uint32:
+	case sqltypes.Uint8:
+		if a.(uint8) < b.(uint8) {
+			return -1
+		}
+	case sqltypes.Uint16:
+		if a.(uint16) < b.(uint16) {
+			return -1
+		}
+	case sqltypes.Uint24, sqltypes.Uint32:
 		if a.(uint32) < b.(uint32) {
 			return -1
 		}
@@ -186,175 +480,1332 @@ func (t numberT) Compare(a interface{}, b interface{}) int {
 	return +1
 }
 
-var Timestamp = timestampT{}
+// MaxBitLength is the maximum number of bits of a BIT type.
+const MaxBitLength = 64
 
-type timestampT struct{}
+// BitType is the type of bit-field values, whose values are uint64.
+type BitType interface {
+	Type
+	// Length returns the number of bits of the values.
+	Length() int
+}
 
-// Type implements Type interface.
-func (t timestampT) Type() query.Type {
-	return sqltypes.Timestamp
+// Bit returns a BIT type with the given number of bits. Strings and byte
+// slices are converted as big-endian binary values, like MySQL does, and
+// values that need more bits than the length can't be converted. It panics
+// if the length is not between 1 and MaxBitLength.
+func Bit(length int) BitType {
+	if length < 1 || length > MaxBitLength {
+		panic(fmt.Sprintf("invalid BIT(%d) type", length))
+	}
+
+	return bitT{length: length}
 }
 
-const TimestampLayout = "2006-01-02 15:04:05"
+type bitT struct {
+	length int
+}
 
-// SQL implements Type interface.
-func (t timestampT) SQL(v interface{}) sqltypes.Value {
-	time := MustConvert(t, v).(time.Time)
-	return sqltypes.MakeTrusted(
-		sqltypes.Timestamp,
-		[]byte(time.Format(TimestampLayout)),
-	)
+// Length implements the BitType interface.
+func (t bitT) Length() int {
+	return t.length
+}
+
+// Type implements Type interface.
+func (t bitT) Type() query.Type {
+	return sqltypes.Bit
+}
+
+// SQL implements Type interface. Bit values are sent as big-endian bytes.
+func (t bitT) SQL(v interface{}) sqltypes.Value {
+	var buf [8]byte
+	binary.BigEndian.PutUint64(buf[:], MustConvert(t, v).(uint64))
+	return sqltypes.MakeTrusted(sqltypes.Bit, buf[8-(t.length+7)/8:])
 }
 
 // Convert implements Type interface.
-func (t timestampT) Convert(v interface{}) (interface{}, error) {
-	switch value := v.(type) {
-	case time.Time:
-		return value, nil
+func (t bitT) Convert(v interface{}) (interface{}, error) {
+	var n uint64
+	var err error
+	switch v := v.(type) {
+	case []byte:
+		n, err = bytesToUint64(v)
 	case string:
-		t, err := time.Parse(TimestampLayout, value)
-		if err != nil {
-			return nil, fmt.Errorf("value %q can't be converted to time.Time", v)
-		}
-		return t, nil
+		n, err = bytesToUint64([]byte(v))
 	default:
-		ts, err := Int64.Convert(v)
-		if err != nil {
-			return nil, ErrInvalidType
-		}
+		n, err = toUint64(v)
+	}
+
+	if err == nil && t.length < 64 && n >= 1<<uint(t.length) {
+		err = errOutOfRange
+	}
+
+	if err == errOutOfRange {
+		return nil, fmt.Errorf("value %v is out of range for %s", v, MySQLTypeName(t))
+	}
+
+	if err != nil {
+		return nil, err
+	}
 
-		return time.Unix(ts.(int64), 0), nil
+	return n, nil
+}
+
+func bytesToUint64(b []byte) (uint64, error) {
+	if len(b) > 8 {
+		return 0, errOutOfRange
+	}
+
+	var n uint64
+	for _, c := range b {
+		n = n<<8 | uint64(c)
 	}
+
+	return n, nil
 }
 
 // Compare implements Type interface.
-func (t timestampT) Compare(a interface{}, b interface{}) int {
-	av := a.(time.Time)
-	bv := b.(time.Time)
-	if av.Before(bv) {
+func (t bitT) Compare(a interface{}, b interface{}) int {
+	x, y := a.(uint64), b.(uint64)
+	switch {
+	case x < y:
 		return -1
-	} else if av.After(bv) {
-		return 1
+	case x > y:
+		return +1
+	default:
+		return 0
 	}
-	return 0
 }
 
-var Text = textT{}
+const (
+	// MaxDecimalPrecision is the maximum precision of a DECIMAL type.
+	MaxDecimalPrecision = decimal.MaxPrecision
+	// MaxDecimalScale is the maximum scale of a DECIMAL type.
+	MaxDecimalScale = 30
+)
+
+// DecimalType is the type of exact fixed-point numbers, whose values are
+// decimal.Decimal.
+type DecimalType interface {
+	Type
+	// Precision returns the maximum number of digits of the values.
+	Precision() int
+	// Scale returns the number of digits after the decimal point.
+	Scale() int
+}
+
+// Decimal returns a DECIMAL type with the given precision and scale. Values
+// are rounded half away from zero to the scale, and values with more digits
+// than the precision can't be converted. It panics if the precision is not
+// between 1 and MaxDecimalPrecision, or the scale is not between 0 and
+// MaxDecimalScale and the precision.
+func Decimal(precision, scale int) DecimalType {
+	if precision < 1 || precision > MaxDecimalPrecision ||
+		scale < 0 || scale > MaxDecimalScale || scale > precision {
+		panic(fmt.Sprintf("invalid DECIMAL(%d,%d) type", precision, scale))
+	}
+
+	return decimalT{precision: precision, scale: scale}
+}
+
+// IsDecimal returns whether the type is a DECIMAL type.
+func IsDecimal(t Type) bool {
+	_, ok := t.(DecimalType)
+	return ok
+}
 
-type textT struct{}
+type decimalT struct {
+	precision int
+	scale     int
+}
 
 // Type implements Type interface.
-func (t textT) Type() query.Type {
-	return sqltypes.Text
+func (t decimalT) Type() query.Type {
+	return sqltypes.Decimal
+}
+
+// Precision implements DecimalType interface.
+func (t decimalT) Precision() int {
+	return t.precision
+}
+
+// Scale implements DecimalType interface.
+func (t decimalT) Scale() int {
+	return t.scale
 }
 
 // SQL implements Type interface.
-func (t textT) SQL(v interface{}) sqltypes.Value {
-	return sqltypes.MakeTrusted(sqltypes.Text, []byte(MustConvert(t, v).(string)))
+func (t decimalT) SQL(v interface{}) sqltypes.Value {
+	d := MustConvert(t, v).(decimal.Decimal)
+	return sqltypes.MakeTrusted(sqltypes.Decimal, []byte(d.String()))
 }
 
 // Convert implements Type interface.
-func (t textT) Convert(v interface{}) (interface{}, error) {
-	return cast.ToStringE(v)
+func (t decimalT) Convert(v interface{}) (interface{}, error) {
+	var d decimal.Decimal
+	var err error
+	switch value := v.(type) {
+	case decimal.Decimal:
+		d = value
+	case int, int8, int16, int32, int64:
+		d = decimal.NewFromInt(cast.ToInt64(value))
+	case uint, uint8, uint16, uint32, uint64:
+		d = decimal.NewFromUint(cast.ToUint64(value))
+	case float32:
+		d, err = decimal.NewFromFloat(float64(value), 32)
+	case float64:
+		d, err = decimal.NewFromFloat(value, 64)
+	case bool:
+		if value {
+			d = decimal.NewFromInt(1)
+		}
+	case string:
+		d, err = decimal.Parse(value)
+	case []byte:
+		d, err = decimal.Parse(string(value))
+	default:
+		return nil, ErrInvalidType
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	d = d.Round(t.scale, decimal.HalfUp)
+	if d.Precision() > t.precision {
+		return nil, fmt.Errorf("value %s is out of range for %s", d, MySQLTypeName(t))
+	}
+
+	return d, nil
 }
 
 // Compare implements Type interface.
-func (t textT) Compare(a interface{}, b interface{}) int {
-	return strings.Compare(a.(string), b.(string))
+func (t decimalT) Compare(a interface{}, b interface{}) int {
+	return a.(decimal.Decimal).Cmp(b.(decimal.Decimal))
 }
 
-var Boolean Type = booleanT{}
+var Timestamp = timestampT{}
 
-type booleanT struct{}
+type timestampT struct{}
 
 // Type implements Type interface.
-func (t booleanT) Type() query.Type {
-	return sqltypes.Bit
+func (t timestampT) Type() query.Type {
+	return sqltypes.Timestamp
 }
 
-// SQL implements Type interface.
-func (t booleanT) SQL(v interface{}) sqltypes.Value {
-	b := []byte{'0'}
-	if cast.ToBool(v) {
-		b[0] = '1'
-	}
+const TimestampLayout = "2006-01-02 15:04:05"
 
-	return sqltypes.MakeTrusted(sqltypes.Bit, b)
+// DateLayout is the layout of DATE values.
+const DateLayout = "2006-01-02"
+
+// SQL implements Type interface.
+func (t timestampT) SQL(v interface{}) sqltypes.Value {
+	time := MustConvert(t, v).(time.Time)
+	return sqltypes.MakeTrusted(
+		sqltypes.Timestamp,
+		[]byte(formatTime(time, TimestampLayout)),
+	)
 }
 
-// Convert implements Type interface.
-func (t booleanT) Convert(v interface{}) (interface{}, error) {
-	return cast.ToBoolE(v)
+// Convert implements Type interface. Values are converted like toTime does.
+func (t timestampT) Convert(v interface{}) (interface{}, error) {
+	tm, err := toTime(v)
+	if err != nil {
+		return nil, err
+	}
+
+	return tm, nil
 }
 
 // Compare implements Type interface.
-func (t booleanT) Compare(a interface{}, b interface{}) int {
-	if a == b {
-		return 0
-	}
+func (t timestampT) Compare(a interface{}, b interface{}) int {
+	return compareTimes(a.(time.Time), b.(time.Time))
+}
 
-	if a.(bool) == false {
+func compareTimes(a, b time.Time) int {
+	if a.Before(b) {
 		return -1
+	} else if a.After(b) {
+		return 1
 	}
+	return 0
+}
 
-	return +1
+// zeroDate is the date MySQL uses as a dummy date, which is represented by
+// the zero time.Time in DATE, DATETIME and TIMESTAMP values.
+const zeroDate = "0000-00-00"
+
+// timeLayouts are the layouts of the strings that can be converted to
+// DATE, DATETIME and TIMESTAMP values. Fractional seconds are accepted
+// after the seconds of any of them.
+var timeLayouts = []string{
+	TimestampLayout,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04",
+	DateLayout,
+	"20060102150405",
+	"20060102",
+	time.RFC3339Nano,
 }
 
-var Blob = blobT{}
+// ParseTime parses a date or a date and time such as 2018-01-02 or
+// 2018-01-02 15:04:05.123. Times without a time zone are read as times in
+// the given location. The zero date 0000-00-00 is parsed as the zero
+// time.Time.
+func ParseTime(s string, loc *time.Location) (time.Time, error) {
+	str := strings.TrimSpace(s)
+	if strings.HasPrefix(str, zeroDate) {
+		return time.Time{}, nil
+	}
 
-type blobT struct{}
+	for _, layout := range timeLayouts {
+		if t, err := time.ParseInLocation(layout, str, loc); err == nil {
+			return t, nil
+		}
+	}
 
-// Type implements Type interface.
-func (t blobT) Type() query.Type {
-	return sqltypes.Blob
+	return time.Time{}, fmt.Errorf("value %q can't be converted to time.Time", s)
 }
 
-// SQL implements Type interface.
-func (t blobT) SQL(v interface{}) sqltypes.Value {
-	return sqltypes.MakeTrusted(sqltypes.Blob, MustConvert(t, v).([]byte))
+// formatTime formats a time with the given layout, writing the zero time
+// as the zero date.
+func formatTime(t time.Time, layout string) string {
+	s := t.Format(layout)
+	if t.IsZero() {
+		s = zeroDate + s[len(DateLayout):]
+	}
+
+	return s
 }
 
-// Convert implements Type interface.
-func (t blobT) Convert(v interface{}) (interface{}, error) {
+// toTime converts a value to a time.Time. Strings are read as UTC times,
+// and numbers are read as dates and times such as 20180102 or
+// 20180102150405, like MySQL does.
+func toTime(v interface{}) (time.Time, error) {
+	return toTimeIn(v, time.UTC)
+}
+
+// toTimeIn converts a value to a time.Time like toTime does, reading
+// strings and numbers as times in the given location.
+func toTimeIn(v interface{}, loc *time.Location) (time.Time, error) {
 	switch value := v.(type) {
-	case []byte:
+	case time.Time:
 		return value, nil
 	case string:
-		return []byte(value), nil
-	case fmt.Stringer:
-		return []byte(value.String()), nil
+		return ParseTime(value, loc)
+	case []byte:
+		return ParseTime(string(value), loc)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		s := cast.ToString(value)
+		if s == "0" {
+			return time.Time{}, nil
+		}
+		return ParseTime(s, loc)
 	default:
-		return nil, ErrInvalidType
+		return time.Time{}, ErrInvalidType
 	}
 }
 
-// Compare implements Type interface.
-func (t blobT) Compare(a interface{}, b interface{}) int {
-	return bytes.Compare(a.([]byte), b.([]byte))
-}
-
-var JSON = jsonT{}
+// Date is the type of dates without time. Values are time.Time at
+// midnight UTC, and the zero time.Time is the zero date.
+var Date = dateT{}
 
-type jsonT struct{}
+type dateT struct{}
 
 // Type implements Type interface.
-func (t jsonT) Type() query.Type {
-	return sqltypes.TypeJSON
+func (t dateT) Type() query.Type {
+	return sqltypes.Date
 }
 
 // SQL implements Type interface.
-func (t jsonT) SQL(v interface{}) sqltypes.Value {
-	return sqltypes.MakeTrusted(sqltypes.TypeJSON, MustConvert(t, v).([]byte))
-}
-
-// Convert implements Type interface.
-func (t jsonT) Convert(v interface{}) (interface{}, error) {
-	return json.Marshal(v)
+func (t dateT) SQL(v interface{}) sqltypes.Value {
+	d := MustConvert(t, v).(time.Time)
+	return sqltypes.MakeTrusted(sqltypes.Date, []byte(formatTime(d, DateLayout)))
+}
+
+// Convert implements Type interface. The time of times is discarded.
+func (t dateT) Convert(v interface{}) (interface{}, error) {
+	tm, err := toTime(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if tm.IsZero() {
+		return tm, nil
+	}
+
+	y, m, d := tm.Date()
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC), nil
+}
+
+// Compare implements Type interface.
+func (t dateT) Compare(a interface{}, b interface{}) int {
+	return compareTimes(a.(time.Time), b.(time.Time))
+}
+
+// MaxDatetimePrecision is the maximum number of digits of the fractional
+// seconds of DATETIME values.
+const MaxDatetimePrecision = 6
+
+// DatetimeType is the type of dates with time, whose values are time.Time.
+type DatetimeType interface {
+	Type
+	// Precision returns the number of digits of the fractional seconds.
+	Precision() int
+}
+
+// Datetime returns a DATETIME type whose values have the given number of
+// digits of fractional seconds. Unlike TIMESTAMP values, DATETIME values
+// have no time zone: they are time.Time in UTC with the date and time they
+// were given, and the zero time.Time is the zero date. It panics if the
+// precision is not between 0 and MaxDatetimePrecision.
+func Datetime(precision int) DatetimeType {
+	if precision < 0 || precision > MaxDatetimePrecision {
+		panic(fmt.Sprintf("invalid DATETIME(%d) type", precision))
+	}
+
+	return datetimeT{precision: precision}
+}
+
+type datetimeT struct {
+	precision int
+}
+
+// Type implements Type interface.
+func (t datetimeT) Type() query.Type {
+	return sqltypes.Datetime
+}
+
+// Precision implements DatetimeType interface.
+func (t datetimeT) Precision() int {
+	return t.precision
+}
+
+func (t datetimeT) layout() string {
+	if t.precision == 0 {
+		return TimestampLayout
+	}
+
+	return TimestampLayout + "." + strings.Repeat("0", t.precision)
+}
+
+// SQL implements Type interface.
+func (t datetimeT) SQL(v interface{}) sqltypes.Value {
+	d := MustConvert(t, v).(time.Time)
+	return sqltypes.MakeTrusted(sqltypes.Datetime, []byte(formatTime(d, t.layout())))
+}
+
+// Convert implements Type interface. Times keep their date and time, and
+// fractional seconds are rounded to the precision of the type.
+func (t datetimeT) Convert(v interface{}) (interface{}, error) {
+	tm, err := toTime(v)
+	if err != nil {
+		return nil, err
+	}
+
+	if tm.IsZero() {
+		return tm, nil
+	}
+
+	y, mo, d := tm.Date()
+	h, mi, s := tm.Clock()
+	tm = time.Date(y, mo, d, h, mi, s, tm.Nanosecond(), time.UTC)
+
+	unit := time.Second
+	for i := 0; i < t.precision; i++ {
+		unit /= 10
+	}
+
+	return tm.Round(unit), nil
+}
+
+// Compare implements Type interface.
+func (t datetimeT) Compare(a interface{}, b interface{}) int {
+	return compareTimes(a.(time.Time), b.(time.Time))
+}
+
+// MaxTime is the maximum absolute value of TIME values.
+const MaxTime = 838*time.Hour + 59*time.Minute + 59*time.Second
+
+// Time is the type of times of the day and time intervals, which may be
+// negative or longer than a day. Values are time.Duration of whole seconds
+// between -MaxTime and MaxTime.
+var Time = timeT{}
+
+type timeT struct{}
+
+// Type implements Type interface.
+func (t timeT) Type() query.Type {
+	return sqltypes.Time
+}
+
+// SQL implements Type interface.
+func (t timeT) SQL(v interface{}) sqltypes.Value {
+	d := MustConvert(t, v).(time.Duration)
+
+	sign := ""
+	if d < 0 {
+		sign, d = "-", -d
+	}
+
+	s := fmt.Sprintf("%s%02d:%02d:%02d", sign,
+		d/time.Hour, d%time.Hour/time.Minute, d%time.Minute/time.Second)
+	return sqltypes.MakeTrusted(sqltypes.Time, []byte(s))
+}
+
+// Convert implements Type interface. Times are converted to their time of
+// the day, and strings and numbers are read as times such as 12:30:00,
+// -1 10:00:00 or 123000, like MySQL does.
+func (t timeT) Convert(v interface{}) (interface{}, error) {
+	var d time.Duration
+	var err error
+	switch value := v.(type) {
+	case time.Duration:
+		d = value
+	case time.Time:
+		h, m, s := value.Clock()
+		d = time.Duration(h)*time.Hour + time.Duration(m)*time.Minute +
+			time.Duration(s)*time.Second + time.Duration(value.Nanosecond())
+	case string:
+		d, err = parseDuration(value)
+	case []byte:
+		d, err = parseDuration(string(value))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		d, err = parseDuration(cast.ToString(value))
+	default:
+		return nil, ErrInvalidType
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	d = d.Round(time.Second)
+	if d > MaxTime || d < -MaxTime {
+		return nil, fmt.Errorf("value %v is out of range for time", v)
+	}
+
+	return d, nil
+}
+
+// Compare implements Type interface.
+func (t timeT) Compare(a interface{}, b interface{}) int {
+	av, bv := a.(time.Duration), b.(time.Duration)
+	if av < bv {
+		return -1
+	} else if av > bv {
+		return 1
+	}
+	return 0
+}
+
+var (
+	durationRegexp        = regexp.MustCompile(`^(-)?(?:(\d+) )?(\d+):(\d\d?)(?::(\d\d?))?(\.\d+)?$`)
+	numericDurationRegexp = regexp.MustCompile(`^(-)?(\d+)(\.\d+)?$`)
+)
+
+// parseDuration parses a time written as [-][D ]H:MM[:SS][.fraction] or
+// as the number [-]HHMMSS[.fraction].
+func parseDuration(s string) (time.Duration, error) {
+	str := strings.TrimSpace(s)
+	var neg bool
+	var days, hours, minutes, seconds, fraction string
+	if m := durationRegexp.FindStringSubmatch(str); m != nil {
+		neg = m[1] != ""
+		days, hours, minutes, seconds, fraction = m[2], m[3], m[4], m[5], m[6]
+	} else if m := numericDurationRegexp.FindStringSubmatch(str); m != nil {
+		neg = m[1] != ""
+		digits := fmt.Sprintf("%06s", m[2])
+		hours, minutes, seconds = digits[:len(digits)-4], digits[len(digits)-4:len(digits)-2], digits[len(digits)-2:]
+		fraction = m[3]
+	} else {
+		return 0, fmt.Errorf("value %q can't be converted to time", s)
+	}
+
+	n := func(s string) int64 {
+		n, _ := strconv.ParseInt(s, 10, 64)
+		return n
+	}
+
+	if n(minutes) > 59 || n(seconds) > 59 {
+		return 0, fmt.Errorf("value %q can't be converted to time", s)
+	}
+
+	d := time.Duration(n(days)*24+n(hours))*time.Hour +
+		time.Duration(n(minutes))*time.Minute +
+		time.Duration(n(seconds))*time.Second
+	if fraction != "" {
+		ns := (fraction[1:] + "000000000")[:9]
+		d += time.Duration(n(ns))
+	}
+
+	if neg {
+		d = -d
+	}
+
+	return d, nil
+}
+
+// Year is the type of years, whose values are int16 between 1901 and 2155,
+// or 0.
+var Year = yearT{}
+
+type yearT struct{}
+
+// Type implements Type interface.
+func (t yearT) Type() query.Type {
+	return sqltypes.Year
+}
+
+// SQL implements Type interface.
+func (t yearT) SQL(v interface{}) sqltypes.Value {
+	y := MustConvert(t, v).(int16)
+	return sqltypes.MakeTrusted(sqltypes.Year, []byte(fmt.Sprintf("%04d", y)))
+}
+
+// Convert implements Type interface. As in MySQL, two-digit years from 70
+// to 99 are years of the 20th century and the rest are years of the 21st,
+// but the number 0 is the year 0 and the string '0' is 2000.
+func (t yearT) Convert(v interface{}) (interface{}, error) {
+	var y int64
+	switch value := v.(type) {
+	case time.Time:
+		y = int64(value.Year())
+		if value.IsZero() {
+			y = 0
+		}
+	case string, []byte:
+		s := strings.TrimSpace(cast.ToString(value))
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("value %q can't be converted to year", s)
+		}
+
+		y = n
+		if len(s) <= 2 {
+			y = twoDigitYear(n)
+		}
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		y = cast.ToInt64(value)
+		if y != 0 {
+			y = twoDigitYear(y)
+		}
+	default:
+		return nil, ErrInvalidType
+	}
+
+	if y != 0 && (y < 1901 || y > 2155) {
+		return nil, fmt.Errorf("value %v is out of range for year", v)
+	}
+
+	return int16(y), nil
+}
+
+func twoDigitYear(y int64) int64 {
+	switch {
+	case y >= 0 && y < 70:
+		return 2000 + y
+	case y >= 70 && y < 100:
+		return 1900 + y
+	default:
+		return y
+	}
 }
 
 // Compare implements Type interface.
+func (t yearT) Compare(a interface{}, b interface{}) int {
+	av, bv := a.(int16), b.(int16)
+	if av < bv {
+		return -1
+	} else if av > bv {
+		return 1
+	}
+	return 0
+}
+
+const (
+	// DefaultCharacterSet is the character set of the character strings
+	// whose type doesn't have another one.
+	DefaultCharacterSet = "utf8"
+	// DefaultCollation is the collation of DefaultCharacterSet. Like in
+	// MySQL, strings are compared without case by default.
+	DefaultCollation = "utf8_general_ci"
+	// BinaryCharacterSet is the character set and collation of binary
+	// strings.
+	BinaryCharacterSet = "binary"
+)
+
+// Maximum lengths of the string types. The lengths of CHAR and VARCHAR are
+// in characters, and the rest in bytes.
+const (
+	MaxCharLength      = 255
+	MaxVarcharLength   = 65535
+	TinyTextLength     = 255
+	TextLength         = 65535
+	MediumTextLength   = 16777215
+	LongTextLength     = 4294967295
+	MaxBinaryLength    = MaxCharLength
+	MaxVarbinaryLength = MaxVarcharLength
+)
+
+// StringType is the type of character and binary strings with a maximum
+// length. Values of character strings are string, and values of binary
+// strings are []byte.
+type StringType interface {
+	Type
+	// MaxLength returns the maximum length of the values, in characters for
+	// CHAR and VARCHAR and in bytes for the rest.
+	MaxLength() int64
+	// CharacterSet returns the character set of the values, which is
+	// BinaryCharacterSet for binary strings.
+	CharacterSet() string
+	// Collation returns the collation of the values, which is
+	// BinaryCharacterSet for binary strings.
+	Collation() string
+}
+
+// IsText returns whether the type is a type of character strings.
+func IsText(t Type) bool {
+	st, ok := t.(StringType)
+	return ok && !sqltypes.IsBinary(st.Type())
+}
+
+// IsBinary returns whether the type is a type of binary strings.
+func IsBinary(t Type) bool {
+	st, ok := t.(StringType)
+	return ok && sqltypes.IsBinary(st.Type())
+}
+
+var (
+	TinyText   = stringT{sqltypes.Text, TinyTextLength, DefaultCharacterSet, DefaultCollation}
+	Text       = stringT{sqltypes.Text, TextLength, DefaultCharacterSet, DefaultCollation}
+	MediumText = stringT{sqltypes.Text, MediumTextLength, DefaultCharacterSet, DefaultCollation}
+	LongText   = stringT{sqltypes.Text, LongTextLength, DefaultCharacterSet, DefaultCollation}
+
+	TinyBlob   = stringT{sqltypes.Blob, TinyTextLength, BinaryCharacterSet, BinaryCharacterSet}
+	Blob       = stringT{sqltypes.Blob, TextLength, BinaryCharacterSet, BinaryCharacterSet}
+	MediumBlob = stringT{sqltypes.Blob, MediumTextLength, BinaryCharacterSet, BinaryCharacterSet}
+	LongBlob   = stringT{sqltypes.Blob, LongTextLength, BinaryCharacterSet, BinaryCharacterSet}
+)
+
+// CreateString returns a type of character strings with the given maximum
+// length, character set and collation. The query.Type must be Char, VarChar
+// or Text, and TEXT types are the smallest of TINYTEXT, TEXT, MEDIUMTEXT and
+// LONGTEXT that can hold values of the given length, like in MySQL. An
+// empty character set is DefaultCharacterSet, and an empty collation is the
+// default collation of the character set, such as utf8_general_ci.
+func CreateString(typ query.Type, length int64, charset, collation string) (StringType, error) {
+	charset, collation = strings.ToLower(charset), strings.ToLower(collation)
+	if charset == "" {
+		charset = DefaultCharacterSet
+	}
+
+	if collation == "" {
+		var err error
+		if collation, err = CharacterSetCollation(charset); err != nil {
+			return nil, err
+		}
+	}
+
+	cs, err := CollationCharacterSet(collation)
+	if err != nil {
+		return nil, err
+	}
+
+	if cs != charset {
+		return nil, fmt.Errorf("collation %s is not valid for character set %s", collation, charset)
+	}
+
+	return createString(typ, length, charset, collation)
+}
+
+// CreateBinary returns a type of binary strings with the given maximum
+// length. The query.Type must be Binary, VarBinary or Blob, and BLOB types
+// are chosen like TEXT types in CreateString.
+func CreateBinary(typ query.Type, length int64) (StringType, error) {
+	return createString(typ, length, BinaryCharacterSet, BinaryCharacterSet)
+}
+
+func createString(typ query.Type, length int64, charset, collation string) (StringType, error) {
+	var max int64
+	switch typ {
+	case sqltypes.Char, sqltypes.Binary:
+		max = MaxCharLength
+	case sqltypes.VarChar, sqltypes.VarBinary:
+		max = MaxVarcharLength
+	case sqltypes.Text, sqltypes.Blob:
+		max = LongTextLength
+		switch {
+		case length <= TinyTextLength:
+			length = TinyTextLength
+		case length <= TextLength:
+			length = TextLength
+		case length <= MediumTextLength:
+			length = MediumTextLength
+		default:
+			length = LongTextLength
+		}
+	default:
+		return nil, fmt.Errorf("%s is not a string type", typ)
+	}
+
+	if (charset == BinaryCharacterSet) != sqltypes.IsBinary(typ) {
+		return nil, fmt.Errorf("%s is not a %s string type", typ, charset)
+	}
+
+	if length < 0 || length > max {
+		return nil, fmt.Errorf("invalid length %d for type %s", length, typ)
+	}
+
+	return stringT{t: typ, length: length, charset: charset, collation: collation}, nil
+}
+
+func mustCreateString(t StringType, err error) StringType {
+	if err != nil {
+		panic(err)
+	}
+
+	return t
+}
+
+// Char returns a CHAR type of the given length with the default character
+// set. It panics if the length is greater than MaxCharLength.
+func Char(length int64) StringType {
+	return mustCreateString(CreateString(sqltypes.Char, length, "", ""))
+}
+
+// Varchar returns a VARCHAR type of the given length with the default
+// character set. It panics if the length is greater than MaxVarcharLength.
+func Varchar(length int64) StringType {
+	return mustCreateString(CreateString(sqltypes.VarChar, length, "", ""))
+}
+
+// Binary returns a BINARY type of the given length. It panics if the length
+// is greater than MaxBinaryLength.
+func Binary(length int64) StringType {
+	return mustCreateString(CreateBinary(sqltypes.Binary, length))
+}
+
+// Varbinary returns a VARBINARY type of the given length. It panics if the
+// length is greater than MaxVarbinaryLength.
+func Varbinary(length int64) StringType {
+	return mustCreateString(CreateBinary(sqltypes.VarBinary, length))
+}
+
+type stringT struct {
+	t         query.Type
+	length    int64
+	charset   string
+	collation string
+}
+
+// Type implements Type interface.
+func (t stringT) Type() query.Type {
+	return t.t
+}
+
+// MaxLength implements StringType interface.
+func (t stringT) MaxLength() int64 {
+	return t.length
+}
+
+// CharacterSet implements StringType interface.
+func (t stringT) CharacterSet() string {
+	return t.charset
+}
+
+// Collation implements StringType interface.
+func (t stringT) Collation() string {
+	return t.collation
+}
+
+// SQL implements Type interface.
+func (t stringT) SQL(v interface{}) sqltypes.Value {
+	switch v := MustConvert(t, v).(type) {
+	case []byte:
+		return sqltypes.MakeTrusted(t.t, v)
+	default:
+		return sqltypes.MakeTrusted(t.t, []byte(v.(string)))
+	}
+}
+
+// Convert implements Type interface. As in MySQL, CHAR values don't keep
+// their trailing spaces and BINARY values are padded with zero bytes to the
+// length of the type. Lengths are not checked.
+func (t stringT) Convert(v interface{}) (interface{}, error) {
+	if !sqltypes.IsBinary(t.t) {
+		s, err := cast.ToStringE(v)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.t == sqltypes.Char {
+			s = strings.TrimRight(s, " ")
+		}
+
+		return s, nil
+	}
+
+	var b []byte
+	switch value := v.(type) {
+	case []byte:
+		b = value
+	case string:
+		b = []byte(value)
+	case fmt.Stringer:
+		b = []byte(value.String())
+	default:
+		return nil, ErrInvalidType
+	}
+
+	if t.t == sqltypes.Binary && int64(len(b)) < t.length {
+		padded := make([]byte, t.length)
+		copy(padded, b)
+		b = padded
+	}
+
+	return b, nil
+}
+
+// Compare implements Type interface. Character strings are compared with
+// the collation of the type.
+func (t stringT) Compare(a interface{}, b interface{}) int {
+	if sqltypes.IsBinary(t.t) {
+		return bytes.Compare(a.([]byte), b.([]byte))
+	}
+
+	return CompareStrings(t.collation, a.(string), b.(string))
+}
+
+// TruncateString truncates a value of a string type to the maximum length
+// of the type, and returns whether it was truncated. Text values are never
+// truncated in the middle of a character.
+func TruncateString(t StringType, v interface{}) (interface{}, bool) {
+	max := t.MaxLength()
+	switch v := v.(type) {
+	case []byte:
+		if int64(len(v)) <= max {
+			return v, false
+		}
+		return v[:max], true
+	case string:
+		if t.Type() != sqltypes.Char && t.Type() != sqltypes.VarChar {
+			if int64(len(v)) <= max {
+				return v, false
+			}
+
+			n := int(max)
+			for n > 0 && !utf8.RuneStart(v[n]) {
+				n--
+			}
+			return v[:n], true
+		}
+
+		var n int64
+		for i := range v {
+			if n == max {
+				return v[:i], true
+			}
+			n++
+		}
+		return v, false
+	default:
+		return v, false
+	}
+}
+
+// MaxCharacterBytes returns the maximum number of bytes of the characters
+// of a character set.
+func MaxCharacterBytes(charset string) int64 {
+	switch charset {
+	case "utf8mb4", "utf16", "utf32":
+		return 4
+	case "utf8", "utf8mb3":
+		return 3
+	case "ucs2":
+		return 2
+	default:
+		return 1
+	}
+}
+
+const (
+	// MaxEnumValues is the maximum number of members of an ENUM type.
+	MaxEnumValues = 65535
+	// MaxSetValues is the maximum number of members of a SET type.
+	MaxSetValues = 64
+)
+
+// EnumType is the type of values that are one of a list of strings. Values
+// are stored as the uint16 index of their member, starting at 1, and they
+// are ordered by that index, as in MySQL.
+type EnumType interface {
+	Type
+	// Values returns the members of the type, in order.
+	Values() []string
+	// Member returns the member with the given index, starting at 1.
+	Member(index uint16) string
+}
+
+// SetType is the type of values that are sets of members of a list of
+// strings. Values are stored as a uint64 bitmask with a bit for each
+// member, in the order of the list, and they are ordered by that number.
+type SetType interface {
+	Type
+	// Values returns the members of the type, in order.
+	Values() []string
+	// Members returns the members of the set with the given bitmask, in
+	// order.
+	Members(bits uint64) []string
+}
+
+// members are the members of an ENUM or SET type, which are found without
+// regard to case like in the default collation.
+type members struct {
+	values  []string
+	indexes map[string]int
+}
+
+func newMembers(kind string, values []string, max int) (*members, error) {
+	if len(values) == 0 {
+		return nil, fmt.Errorf("%s types must have at least one member", kind)
+	}
+
+	if len(values) > max {
+		return nil, fmt.Errorf("%s types can't have more than %d members", kind, max)
+	}
+
+	m := &members{indexes: make(map[string]int, len(values))}
+	for _, v := range values {
+		// Like MySQL, trailing spaces of the members are removed.
+		v = strings.TrimRight(v, " ")
+		key := strings.ToLower(v)
+		if _, ok := m.indexes[key]; ok {
+			return nil, fmt.Errorf("duplicate member %q of %s type", v, kind)
+		}
+
+		m.values = append(m.values, v)
+		m.indexes[key] = len(m.values)
+	}
+
+	return m, nil
+}
+
+// Values implements the EnumType and SetType interfaces.
+func (m *members) Values() []string {
+	return append([]string(nil), m.values...)
+}
+
+// index returns the index of a member, starting at 1, or 0 if it's not a
+// member.
+func (m *members) index(s string) int {
+	return m.indexes[strings.ToLower(strings.TrimRight(s, " "))]
+}
+
+// typeName returns the definition of the type with its members, such as
+// enum('a','b').
+func (m *members) typeName(kind string) string {
+	var buf bytes.Buffer
+	buf.WriteString(kind + "(")
+	for i, v := range m.values {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		buf.WriteString("'" + strings.Replace(v, "'", "''", -1) + "'")
+	}
+	buf.WriteByte(')')
+	return buf.String()
+}
+
+// CreateEnum returns an ENUM type with the given members. It returns an
+// error if there are no members, too many of them or duplicates.
+func CreateEnum(values []string) (EnumType, error) {
+	m, err := newMembers("enum", values, MaxEnumValues)
+	if err != nil {
+		return nil, err
+	}
+
+	return enumT{m}, nil
+}
+
+type enumT struct {
+	*members
+}
+
+// Type implements Type interface.
+func (t enumT) Type() query.Type {
+	return sqltypes.Enum
+}
+
+// Member implements the EnumType interface.
+func (t enumT) Member(index uint16) string {
+	return t.values[index-1]
+}
+
+// SQL implements Type interface. Values are sent as the names of their
+// members.
+func (t enumT) SQL(v interface{}) sqltypes.Value {
+	i := MustConvert(t, v).(uint16)
+	return sqltypes.MakeTrusted(sqltypes.Enum, []byte(t.Member(i)))
+}
+
+// Convert implements Type interface. Strings are converted to the index of
+// their member and numbers are indexes themselves, but strings of numbers
+// that are not members are also taken as indexes, like in MySQL.
+func (t enumT) Convert(v interface{}) (interface{}, error) {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		n, err := toUint64(v)
+		if err == nil && n >= 1 && n <= uint64(len(t.values)) {
+			return uint16(n), nil
+		}
+		return nil, fmt.Errorf("value %v is not a member of %s", v, MySQLTypeName(t))
+	}
+
+	if i := t.index(s); i > 0 {
+		return uint16(i), nil
+	}
+
+	if n, err := strconv.ParseUint(strings.TrimSpace(s), 10, 16); err == nil &&
+		n >= 1 && n <= uint64(len(t.values)) {
+		return uint16(n), nil
+	}
+
+	return nil, fmt.Errorf("value %q is not a member of %s", s, MySQLTypeName(t))
+}
+
+// Compare implements Type interface. Values are compared by their indexes,
+// and values that are not members are compared as strings.
+func (t enumT) Compare(a interface{}, b interface{}) int {
+	x, errx := t.Convert(a)
+	y, erry := t.Convert(b)
+	if errx != nil || erry != nil {
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+
+	switch {
+	case x.(uint16) < y.(uint16):
+		return -1
+	case x.(uint16) > y.(uint16):
+		return +1
+	default:
+		return 0
+	}
+}
+
+// CreateSet returns a SET type with the given members. It returns an error
+// if there are no members, too many of them or duplicates, or if a member
+// has a comma, which separates the members of the values.
+func CreateSet(values []string) (SetType, error) {
+	for _, v := range values {
+		if strings.Contains(v, ",") {
+			return nil, fmt.Errorf("member %q of set type can't have commas", v)
+		}
+	}
+
+	m, err := newMembers("set", values, MaxSetValues)
+	if err != nil {
+		return nil, err
+	}
+
+	return setT{m}, nil
+}
+
+type setT struct {
+	*members
+}
+
+// Type implements Type interface.
+func (t setT) Type() query.Type {
+	return sqltypes.Set
+}
+
+// Members implements the SetType interface.
+func (t setT) Members(bits uint64) []string {
+	var names []string
+	for i, name := range t.values {
+		if bits&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	return names
+}
+
+// SQL implements Type interface. Values are sent as their members separated
+// by commas, in the order of the type.
+func (t setT) SQL(v interface{}) sqltypes.Value {
+	names := t.Members(MustConvert(t, v).(uint64))
+	return sqltypes.MakeTrusted(sqltypes.Set, []byte(strings.Join(names, ",")))
+}
+
+// Convert implements Type interface. Strings are lists of members separated
+// by commas, and numbers are the bitmasks of the values.
+func (t setT) Convert(v interface{}) (interface{}, error) {
+	var s string
+	switch v := v.(type) {
+	case string:
+		s = v
+	case []byte:
+		s = string(v)
+	default:
+		n, err := toUint64(v)
+		if err != nil || len(t.values) < 64 && n >= 1<<uint(len(t.values)) {
+			return nil, fmt.Errorf("value %v is not valid for %s", v, MySQLTypeName(t))
+		}
+		return n, nil
+	}
+
+	var bits uint64
+	if s == "" {
+		return bits, nil
+	}
+
+	for _, name := range strings.Split(s, ",") {
+		i := t.index(name)
+		if i == 0 {
+			return nil, fmt.Errorf("value %q is not valid for %s", s, MySQLTypeName(t))
+		}
+		bits |= 1 << uint(i-1)
+	}
+
+	return bits, nil
+}
+
+// Compare implements Type interface. Values are compared by their bitmasks,
+// and values that are not valid are compared as strings.
+func (t setT) Compare(a interface{}, b interface{}) int {
+	x, errx := t.Convert(a)
+	y, erry := t.Convert(b)
+	if errx != nil || erry != nil {
+		return strings.Compare(fmt.Sprint(a), fmt.Sprint(b))
+	}
+
+	switch {
+	case x.(uint64) < y.(uint64):
+		return -1
+	case x.(uint64) > y.(uint64):
+		return +1
+	default:
+		return 0
+	}
+}
+
+// Boolean is the type of boolean values. Like in MySQL, booleans are
+// TINYINT(1) values, so clients read them as 0 and 1.
+var Boolean Type = booleanT{}
+
+type booleanT struct{}
+
+// Type implements Type interface.
+func (t booleanT) Type() query.Type {
+	return sqltypes.Int8
+}
+
+// SQL implements Type interface.
+func (t booleanT) SQL(v interface{}) sqltypes.Value {
+	b := []byte{'0'}
+	if cast.ToBool(v) {
+		b[0] = '1'
+	}
+
+	return sqltypes.MakeTrusted(sqltypes.Int8, b)
+}
+
+// Convert implements Type interface. Numbers are true if they are not zero,
+// and strings are either booleans such as "true" or numbers.
+func (t booleanT) Convert(v interface{}) (interface{}, error) {
+	switch v := v.(type) {
+	case bool:
+		return v, nil
+	case float32:
+		return v != 0, nil
+	case float64:
+		return v != 0, nil
+	case decimal.Decimal:
+		return v.Sign() != 0, nil
+	case []byte:
+		return t.Convert(string(v))
+	case string:
+		s := strings.TrimSpace(v)
+		if b, err := strconv.ParseBool(s); err == nil {
+			return b, nil
+		}
+
+		f, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid boolean value %q", v)
+		}
+		return f != 0, nil
+	case uint, uint8, uint16, uint32, uint64:
+		n, err := toUint64(v)
+		return n != 0, err
+	default:
+		n, err := toInt64(v)
+		return n != 0, err
+	}
+}
+
+// Compare implements Type interface.
+func (t booleanT) Compare(a interface{}, b interface{}) int {
+	if a == b {
+		return 0
+	}
+
+	if a.(bool) == false {
+		return -1
+	}
+
+	return +1
+}
+
+// JSON is the type of JSON documents. Values of the type are JSONDocument
+// values, and they are compared as MySQL compares JSON values.
+var JSON = jsonT{}
+
+type jsonT struct{}
+
+// Type implements Type interface.
+func (t jsonT) Type() query.Type {
+	return sqltypes.TypeJSON
+}
+
+// SQL implements Type interface.
+func (t jsonT) SQL(v interface{}) sqltypes.Value {
+	doc, err := jsonDocument(v)
+	if err != nil {
+		panic(err)
+	}
+
+	b, err := marshalJSON(doc.Val)
+	if err != nil {
+		panic(err)
+	}
+
+	return sqltypes.MakeTrusted(sqltypes.TypeJSON, b)
+}
+
+// Convert implements Type interface. Strings are read as JSON text, as
+// MySQL does when it converts them to JSON, documents are returned as they
+// are and other values are converted to their documents.
+func (t jsonT) Convert(v interface{}) (interface{}, error) {
+	if v == nil {
+		return nil, nil
+	}
+
+	return jsonDocument(v)
+}
+
+// Compare implements Type interface. Values of other types than JSON are
+// compared as the documents they are converted to, and numbers and times
+// as JSON scalars.
 func (t jsonT) Compare(a interface{}, b interface{}) int {
-	return bytes.Compare(a.([]byte), b.([]byte))
+	return compareJSON(jsonComparable(a), jsonComparable(b))
 }
 
 // MustConvert calls the Convert function from a given Type, it err panics.
@@ -367,3 +1818,85 @@ func MustConvert(t Type, v interface{}) interface{} {
 
 	return c
 }
+
+// MySQLTypeName returns the name of a type as MySQL shows it in the
+// definition of a column, such as bigint unsigned or text.
+func MySQLTypeName(t Type) string {
+	if t == Boolean {
+		return "tinyint(1)"
+	}
+
+	switch t.Type() {
+	case query.Type_INT8:
+		return "tinyint"
+	case query.Type_INT16:
+		return "smallint"
+	case query.Type_INT24:
+		return "mediumint"
+	case query.Type_INT32:
+		return "int"
+	case query.Type_INT64:
+		return "bigint"
+	case query.Type_UINT8:
+		return "tinyint unsigned"
+	case query.Type_UINT16:
+		return "smallint unsigned"
+	case query.Type_UINT24:
+		return "mediumint unsigned"
+	case query.Type_UINT32:
+		return "int unsigned"
+	case query.Type_UINT64:
+		return "bigint unsigned"
+	case query.Type_FLOAT32:
+		return "float"
+	case query.Type_FLOAT64:
+		return "double"
+	case query.Type_BIT:
+		if b, ok := t.(BitType); ok {
+			return fmt.Sprintf("bit(%d)", b.Length())
+		}
+		return "bit"
+	case query.Type_NULL_TYPE:
+		return "null"
+	case query.Type_CHAR, query.Type_VARCHAR, query.Type_BINARY, query.Type_VARBINARY:
+		if st, ok := t.(StringType); ok {
+			return fmt.Sprintf("%s(%d)", strings.ToLower(t.Type().String()), st.MaxLength())
+		}
+		return strings.ToLower(t.Type().String())
+	case query.Type_TEXT, query.Type_BLOB:
+		name := strings.ToLower(t.Type().String())
+		if st, ok := t.(StringType); ok {
+			switch st.MaxLength() {
+			case TinyTextLength:
+				return "tiny" + name
+			case MediumTextLength:
+				return "medium" + name
+			case LongTextLength:
+				return "long" + name
+			}
+		}
+		return name
+	case query.Type_DATETIME:
+		if d, ok := t.(DatetimeType); ok && d.Precision() > 0 {
+			return fmt.Sprintf("datetime(%d)", d.Precision())
+		}
+		return "datetime"
+	case query.Type_ENUM:
+		if e, ok := t.(enumT); ok {
+			return e.typeName("enum")
+		}
+		return "enum"
+	case query.Type_SET:
+		if s, ok := t.(setT); ok {
+			return s.typeName("set")
+		}
+		return "set"
+	case query.Type_DECIMAL:
+		if d, ok := t.(DecimalType); ok {
+			return fmt.Sprintf("decimal(%d,%d)", d.Precision(), d.Scale())
+		}
+		return "decimal"
+	default:
+		return strings.ToLower(t.Type().String())
+	}
+}
@@ -1,9 +1,13 @@
 package sql
 
 import (
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/src-d/go-mysql-server/sql/decimal"
+
+	"github.com/src-d/go-vitess/sqltypes"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -70,6 +74,174 @@ func TestType_Int64(t *testing.T) {
 	assert.Equal(1, Int64.Compare(int64(2), int64(1)))
 }
 
+func TestType_IntegerRanges(t *testing.T) {
+	testCases := []struct {
+		typ      Type
+		input    interface{}
+		expected interface{}
+	}{
+		{Int8, int64(-128), int8(-128)},
+		{Int8, "127", int8(127)},
+		{Int8, 1.5, int8(2)},
+		{Int8, -1.5, int8(-2)},
+		{Int16, uint8(200), int16(200)},
+		{Int24, "-8388608", int32(-8388608)},
+		{Int64, decimal.MustParse("12.50"), int64(13)},
+		{Uint8, "255", uint8(255)},
+		{Uint16, true, uint16(1)},
+		{Uint24, 16777215, uint32(16777215)},
+		{Uint32, " 42 ", uint32(42)},
+		{Uint64, "18446744073709551615", uint64(18446744073709551615)},
+	}
+
+	for _, tt := range testCases {
+		v, err := tt.typ.Convert(tt.input)
+		assert.Nil(t, err, "%s %v", MySQLTypeName(tt.typ), tt.input)
+		assert.Equal(t, tt.expected, v, "%s %v", MySQLTypeName(tt.typ), tt.input)
+	}
+
+	for _, tt := range []struct {
+		typ   Type
+		input interface{}
+	}{
+		{Int8, 128},
+		{Int8, "-129"},
+		{Int16, 40000.0},
+		{Int24, 8388608},
+		{Int32, int64(2147483648)},
+		{Int64, uint64(9223372036854775808)},
+		{Int64, "9223372036854775808"},
+		{Uint8, -1},
+		{Uint8, "256"},
+		{Uint24, 16777216},
+		{Uint32, int64(4294967296)},
+		{Uint64, "-1"},
+		{Uint64, 1e20},
+	} {
+		_, err := tt.typ.Convert(tt.input)
+		assert.NotNil(t, err, "%s %v", MySQLTypeName(tt.typ), tt.input)
+	}
+}
+
+func TestType_NumberSQL(t *testing.T) {
+	testCases := []struct {
+		typ      Type
+		value    interface{}
+		expected string
+	}{
+		{Int8, int8(-128), "-128"},
+		{Int64, int64(-9223372036854775808), "-9223372036854775808"},
+		{Uint32, uint32(4294967295), "4294967295"},
+		{Uint64, uint64(18446744073709551615), "18446744073709551615"},
+		{Float32, float32(0.1), "0.1"},
+		{Float32, float32(16777216), "1.6777216e+07"},
+		{Float64, 0.1, "0.1"},
+		{Float64, 1.5, "1.5"},
+		{Float64, -2.0000000000000004, "-2.0000000000000004"},
+		{Float64, 1e100, "1e+100"},
+	}
+
+	for _, tt := range testCases {
+		v := tt.typ.SQL(tt.value)
+		assert.Equal(t, tt.typ.Type(), v.Type())
+		assert.Equal(t, tt.expected, v.ToString())
+	}
+}
+
+func TestType_Bit(t *testing.T) {
+	assert := assert.New(t)
+	typ := Bit(10)
+
+	v, err := typ.Convert(1023)
+	assert.Nil(err)
+	assert.Equal(uint64(1023), v)
+	v, err = typ.Convert([]byte{0x01, 0x02})
+	assert.Nil(err)
+	assert.Equal(uint64(0x0102), v)
+	_, err = typ.Convert(1024)
+	assert.NotNil(err)
+	_, err = typ.Convert(-1)
+	assert.NotNil(err)
+
+	assert.Equal([]byte{0x03, 0xff}, typ.SQL(uint64(1023)).Raw())
+	assert.Equal([]byte{0x05}, Bit(3).SQL(uint64(5)).Raw())
+	assert.Equal(-1, typ.Compare(uint64(1), uint64(2)))
+	assert.Equal(0, typ.Compare(uint64(2), uint64(2)))
+}
+
+func TestType_Boolean(t *testing.T) {
+	assert := assert.New(t)
+
+	for input, expected := range map[interface{}]bool{
+		true: true, int8(0): false, int64(5): true, uint64(1): true,
+		0.5: true, "false": false, "1": true, "0": false,
+	} {
+		v, err := Boolean.Convert(input)
+		assert.Nil(err)
+		assert.Equal(expected, v, "%v", input)
+	}
+
+	_, err := Boolean.Convert("foo")
+	assert.NotNil(err)
+
+	assert.Equal(sqltypes.Int8, Boolean.Type())
+	assert.Equal("1", Boolean.SQL(true).ToString())
+	assert.Equal("0", Boolean.SQL(false).ToString())
+}
+
+func TestType_Decimal(t *testing.T) {
+	assert := assert.New(t)
+	typ := Decimal(5, 2)
+
+	for _, v := range []interface{}{
+		"12.5", []byte("12.50"), 12.5, float32(12.5), decimal.MustParse("12.499"),
+	} {
+		d, err := typ.Convert(v)
+		assert.Nil(err)
+		assert.Equal(decimal.MustParse("12.50"), d)
+	}
+
+	v, err := typ.Convert(int64(-3))
+	assert.Nil(err)
+	assert.Equal(decimal.MustParse("-3.00"), v)
+	v, err = typ.Convert(uint64(999))
+	assert.Nil(err)
+	assert.Equal(decimal.MustParse("999.00"), v)
+	v, err = typ.Convert(0.005)
+	assert.Nil(err)
+	assert.Equal(decimal.MustParse("0.01"), v)
+	v, err = typ.Convert(19.99)
+	assert.Nil(err)
+	assert.Equal(decimal.MustParse("19.99"), v)
+
+	_, err = typ.Convert(999.995)
+	assert.NotNil(err)
+	_, err = typ.Convert(1000)
+	assert.NotNil(err)
+	_, err = typ.Convert("foo")
+	assert.NotNil(err)
+	_, err = typ.Convert(time.Now())
+	assert.Equal(ErrInvalidType, err)
+
+	assert.Equal([]byte("-0.50"), typ.SQL("-.5").Raw())
+	assert.Equal([]byte("7.00"), typ.SQL(7).Raw())
+
+	assert.Equal(-1, typ.Compare(decimal.MustParse("1.5"), decimal.MustParse("2")))
+	assert.Equal(0, typ.Compare(decimal.MustParse("1.5"), decimal.MustParse("1.50")))
+	assert.Equal(1, typ.Compare(decimal.MustParse("2"), decimal.MustParse("-2")))
+
+	f, err := Float64.Convert(decimal.MustParse("1.50"))
+	assert.Nil(err)
+	assert.Equal(1.5, f)
+	f, err = Float32.Convert(decimal.MustParse("-2.25"))
+	assert.Nil(err)
+	assert.Equal(float32(-2.25), f)
+
+	assert.Panics(func() { Decimal(0, 0) })
+	assert.Panics(func() { Decimal(5, 6) })
+	assert.Panics(func() { Decimal(66, 2) })
+}
+
 func TestType_Timestamp(t *testing.T) {
 	assert := assert.New(t)
 
@@ -85,12 +257,15 @@ func TestType_Timestamp(t *testing.T) {
 		v.(time.Time).Format(TimestampLayout),
 	)
 
-	v, err = Timestamp.Convert(now.Unix())
+	// Integers are dates and times such as 20200102030405, not Unix times.
+	v, err = Timestamp.Convert(int64(20200102030405))
 	assert.Nil(err)
-	assert.Equal(
-		now.Format(TimestampLayout),
-		v.(time.Time).Format(TimestampLayout),
-	)
+	assert.Equal(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC), v)
+	v, err = Timestamp.Convert(20200102)
+	assert.Nil(err)
+	assert.Equal(time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC), v)
+	_, err = Timestamp.Convert(now.Unix())
+	assert.NotNil(err)
 
 	sql := Timestamp.SQL(now)
 	assert.Equal([]byte(now.Format(TimestampLayout)), sql.Raw())
@@ -101,6 +276,150 @@ func TestType_Timestamp(t *testing.T) {
 	assert.Equal(1, Timestamp.Compare(after, now))
 }
 
+func TestType_TimestampZeroDate(t *testing.T) {
+	assert := assert.New(t)
+
+	v, err := Timestamp.Convert("0000-00-00 00:00:00")
+	assert.Nil(err)
+	assert.Equal(time.Time{}, v)
+	assert.Equal([]byte("0000-00-00 00:00:00"), Timestamp.SQL(time.Time{}).Raw())
+
+	v, err = Timestamp.Convert("2018-01-02 03:04:05.678")
+	assert.Nil(err)
+	assert.Equal(time.Date(2018, 1, 2, 3, 4, 5, 678000000, time.UTC), v)
+
+	_, err = Timestamp.Convert("2018-02-30")
+	assert.NotNil(err)
+}
+
+func TestType_Date(t *testing.T) {
+	assert := assert.New(t)
+	date := time.Date(2018, 1, 2, 0, 0, 0, 0, time.UTC)
+
+	for _, v := range []interface{}{
+		"2018-01-02",
+		"2018-01-02 23:59:59",
+		[]byte("2018-01-02"),
+		20180102,
+		time.Date(2018, 1, 2, 23, 0, 0, 0, time.FixedZone("", 3600)),
+	} {
+		d, err := Date.Convert(v)
+		assert.Nil(err)
+		assert.Equal(date, d)
+	}
+
+	v, err := Date.Convert("0000-00-00")
+	assert.Nil(err)
+	assert.Equal(time.Time{}, v)
+	_, err = Date.Convert("foo")
+	assert.NotNil(err)
+	_, err = Date.Convert(1.5)
+	assert.Equal(ErrInvalidType, err)
+
+	assert.Equal([]byte("2018-01-02"), Date.SQL(date).Raw())
+	assert.Equal([]byte("0000-00-00"), Date.SQL(time.Time{}).Raw())
+
+	assert.Equal(-1, Date.Compare(date, date.AddDate(0, 0, 1)))
+	assert.Equal(0, Date.Compare(date, date))
+}
+
+func TestType_Datetime(t *testing.T) {
+	assert := assert.New(t)
+
+	v, err := Datetime(0).Convert("2018-01-02 03:04:05.5")
+	assert.Nil(err)
+	assert.Equal(time.Date(2018, 1, 2, 3, 4, 6, 0, time.UTC), v)
+
+	v, err = Datetime(3).Convert("2018-01-02T03:04:05.123456")
+	assert.Nil(err)
+	assert.Equal(time.Date(2018, 1, 2, 3, 4, 5, 123000000, time.UTC), v)
+
+	v, err = Datetime(0).Convert(time.Date(2018, 1, 2, 3, 4, 5, 0, time.FixedZone("", 7200)))
+	assert.Nil(err)
+	assert.Equal(time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC), v)
+
+	v, err = Datetime(0).Convert(int64(20180102030405))
+	assert.Nil(err)
+	assert.Equal(time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC), v)
+
+	d := time.Date(2018, 1, 2, 3, 4, 5, 120000000, time.UTC)
+	assert.Equal([]byte("2018-01-02 03:04:05"), Datetime(0).SQL(d).Raw())
+	assert.Equal([]byte("2018-01-02 03:04:05.120"), Datetime(3).SQL(d).Raw())
+	assert.Equal([]byte("0000-00-00 00:00:00.00"), Datetime(2).SQL("0000-00-00").Raw())
+
+	assert.Panics(func() { Datetime(7) })
+}
+
+func TestType_Time(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := []struct {
+		input    interface{}
+		expected time.Duration
+		sql      string
+	}{
+		{"12:30:45", 12*time.Hour + 30*time.Minute + 45*time.Second, "12:30:45"},
+		{"-838:59:59", -MaxTime, "-838:59:59"},
+		{"1 02:00", 26 * time.Hour, "26:00:00"},
+		{"12:30", 12*time.Hour + 30*time.Minute, "12:30:00"},
+		{"123045", 12*time.Hour + 30*time.Minute + 45*time.Second, "12:30:45"},
+		{"45.6", 46 * time.Second, "00:00:46"},
+		{-1130, -11*time.Minute - 30*time.Second, "-00:11:30"},
+		{time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC), 3*time.Hour + 4*time.Minute + 5*time.Second, "03:04:05"},
+		{100 * time.Hour, 100 * time.Hour, "100:00:00"},
+	}
+
+	for _, tt := range testCases {
+		v, err := Time.Convert(tt.input)
+		assert.Nil(err, "%v", tt.input)
+		assert.Equal(tt.expected, v, "%v", tt.input)
+		assert.Equal(tt.sql, Time.SQL(v).ToString())
+	}
+
+	for _, v := range []interface{}{"839:00:00", "12:60:00", "foo", 1.5} {
+		_, err := Time.Convert(v)
+		assert.NotNil(err, "%v", v)
+	}
+
+	assert.Equal(-1, Time.Compare(-time.Hour, time.Second))
+	assert.Equal(0, Time.Compare(time.Hour, time.Hour))
+	assert.Equal(1, Time.Compare(time.Hour, time.Second))
+}
+
+func TestType_Year(t *testing.T) {
+	assert := assert.New(t)
+
+	testCases := []struct {
+		input    interface{}
+		expected int16
+	}{
+		{2018, 2018},
+		{"2018", 2018},
+		{5, 2005},
+		{"69", 2069},
+		{70, 1970},
+		{0, 0},
+		{"0000", 0},
+		{"0", 2000},
+		{time.Date(1999, 1, 2, 0, 0, 0, 0, time.UTC), 1999},
+	}
+
+	for _, tt := range testCases {
+		v, err := Year.Convert(tt.input)
+		assert.Nil(err, "%v", tt.input)
+		assert.Equal(tt.expected, v, "%v", tt.input)
+	}
+
+	for _, v := range []interface{}{1900, "2156", "foo", 1.5} {
+		_, err := Year.Convert(v)
+		assert.NotNil(err, "%v", v)
+	}
+
+	assert.Equal([]byte("2018"), Year.SQL(2018).Raw())
+	assert.Equal([]byte("0000"), Year.SQL(0).Raw())
+	assert.Equal(-1, Year.Compare(int16(1999), int16(2000)))
+}
+
 func TestType_Blob(t *testing.T) {
 	assert := assert.New(t)
 
@@ -116,17 +435,243 @@ func TestType_Blob(t *testing.T) {
 	assert.Equal(1, Blob.Compare([]byte{'B'}, []byte{'A'}))
 }
 
+func TestType_Char(t *testing.T) {
+	assert := assert.New(t)
+
+	typ := Char(3)
+	v, err := typ.Convert("ab  ")
+	assert.Nil(err)
+	assert.Equal("ab", v)
+	assert.Equal(int64(3), typ.MaxLength())
+	assert.Equal(DefaultCollation, typ.Collation())
+	assert.True(IsText(typ))
+	assert.False(IsBinary(typ))
+}
+
+func TestType_Binary(t *testing.T) {
+	assert := assert.New(t)
+
+	v, err := Binary(3).Convert("a")
+	assert.Nil(err)
+	assert.Equal([]byte{'a', 0, 0}, v)
+	v, err = Varbinary(3).Convert("a")
+	assert.Nil(err)
+	assert.Equal([]byte{'a'}, v)
+	assert.True(IsBinary(Varbinary(3)))
+	assert.Equal(BinaryCharacterSet, Blob.CharacterSet())
+}
+
+func TestCreateString(t *testing.T) {
+	assert := assert.New(t)
+
+	typ, err := CreateString(sqltypes.Text, 300, "utf8mb4", "")
+	assert.Nil(err)
+	assert.Equal(int64(TextLength), typ.MaxLength())
+	assert.Equal("utf8mb4_general_ci", typ.Collation())
+
+	typ, err = CreateString(sqltypes.VarChar, 10, "utf8mb4", "UTF8MB4_0900_AI_CI")
+	assert.Nil(err)
+	assert.Equal("utf8mb4_0900_ai_ci", typ.Collation())
+
+	typ, err = CreateString(sqltypes.Text, 100, "", "")
+	assert.Nil(err)
+	assert.Equal(TinyText, typ)
+
+	typ, err = CreateString(sqltypes.Text, TextLength, "", "")
+	assert.Nil(err)
+	assert.True(typ == Text)
+
+	_, err = CreateString(sqltypes.VarChar, MaxVarcharLength+1, "", "")
+	assert.NotNil(err)
+	_, err = CreateString(sqltypes.Char, 10, "utf8", "latin1_bin")
+	assert.NotNil(err)
+	_, err = CreateString(sqltypes.Char, 10, "utf8", "utf8_unknown_ci")
+	assert.NotNil(err)
+	_, err = CreateString(sqltypes.Char, 10, "klingon", "")
+	assert.NotNil(err)
+	_, err = CreateBinary(sqltypes.Binary, MaxBinaryLength+1)
+	assert.NotNil(err)
+}
+
+func TestTruncateString(t *testing.T) {
+	assert := assert.New(t)
+
+	v, ok := TruncateString(Varchar(3), "héllo")
+	assert.True(ok)
+	assert.Equal("hél", v)
+
+	v, ok = TruncateString(Varchar(5), "héllo")
+	assert.False(ok)
+	assert.Equal("héllo", v)
+
+	v, ok = TruncateString(Varbinary(2), []byte("abc"))
+	assert.True(ok)
+	assert.Equal([]byte("ab"), v)
+
+	// TINYTEXT lengths are in bytes, and values are cut at the last
+	// character that fits.
+	v, ok = TruncateString(TinyText, strings.Repeat("a", 254)+"é")
+	assert.True(ok)
+	assert.Equal(strings.Repeat("a", 254), v)
+}
+
+func TestType_Enum(t *testing.T) {
+	assert := assert.New(t)
+
+	typ, err := CreateEnum([]string{"small", "medium ", "large"})
+	assert.Nil(err)
+	assert.Equal([]string{"small", "medium", "large"}, typ.Values())
+
+	v, err := typ.Convert("Medium")
+	assert.Nil(err)
+	assert.Equal(uint16(2), v)
+	v, err = typ.Convert(3)
+	assert.Nil(err)
+	assert.Equal(uint16(3), v)
+	v, err = typ.Convert("1")
+	assert.Nil(err)
+	assert.Equal(uint16(1), v)
+	_, err = typ.Convert("huge")
+	assert.NotNil(err)
+	_, err = typ.Convert(0)
+	assert.NotNil(err)
+	_, err = typ.Convert(4)
+	assert.NotNil(err)
+
+	// Values are ordered by their index, not alphabetically.
+	assert.Equal(1, typ.Compare(uint16(3), uint16(2)))
+	assert.Equal(-1, typ.Compare("small", "large"))
+	assert.Equal(0, typ.Compare(uint16(2), "medium"))
+
+	assert.Equal("large", typ.SQL(uint16(3)).ToString())
+	assert.Equal("enum('small','medium','large')", MySQLTypeName(typ))
+
+	_, err = CreateEnum(nil)
+	assert.NotNil(err)
+	_, err = CreateEnum([]string{"a", "A"})
+	assert.NotNil(err)
+}
+
+func TestType_Set(t *testing.T) {
+	assert := assert.New(t)
+
+	typ, err := CreateSet([]string{"read", "write", "admin"})
+	assert.Nil(err)
+
+	v, err := typ.Convert("admin,read")
+	assert.Nil(err)
+	assert.Equal(uint64(5), v)
+	v, err = typ.Convert("")
+	assert.Nil(err)
+	assert.Equal(uint64(0), v)
+	v, err = typ.Convert(7)
+	assert.Nil(err)
+	assert.Equal(uint64(7), v)
+	_, err = typ.Convert("read,delete")
+	assert.NotNil(err)
+	_, err = typ.Convert(8)
+	assert.NotNil(err)
+
+	assert.Equal("read,admin", typ.SQL(uint64(5)).ToString())
+	assert.Equal(-1, typ.Compare("write", "admin"))
+	assert.Equal("set('read','write','admin')", MySQLTypeName(typ))
+
+	_, err = CreateSet([]string{"a,b"})
+	assert.NotNil(err)
+}
+
 func TestType_JSON(t *testing.T) {
 	assert := assert.New(t)
 
-	v, err := JSON.Convert("")
+	v, err := JSON.Convert(`{"a": [1, "b"]}`)
 	assert.Nil(err)
-	assert.Equal([]byte(`""`), v)
+	assert.Equal(JSONDocument{map[string]interface{}{"a": []interface{}{1.0, "b"}}}, v)
 	v, err = JSON.Convert([]int{1, 2})
 	assert.Nil(err)
-	assert.Equal([]byte("[1,2]"), v.([]byte))
+	assert.Equal(JSONDocument{[]interface{}{1.0, 2.0}}, v)
+	_, err = JSON.Convert("")
+	assert.NotNil(err)
+
+	// Documents are not read again as JSON text.
+	v, err = JSON.Convert(`"a"`)
+	assert.Nil(err)
+	assert.Equal(JSONDocument{"a"}, v)
+	v, err = JSON.Convert(v)
+	assert.Nil(err)
+	assert.Equal(JSONDocument{"a"}, v)
+	v, err = JSON.Convert("null")
+	assert.Nil(err)
+	assert.Equal(JSONDocument{}, v)
+	v, err = JSON.Convert(nil)
+	assert.Nil(err)
+	assert.Nil(v)
+
+	assert.Equal(`{"a":[1,"<b>"]}`, JSON.SQL(map[string]interface{}{
+		"a": []interface{}{1, "<b>"},
+	}).ToString())
+	assert.Equal(`"a"`, JSON.SQL(JSONDocument{"a"}).ToString())
+	assert.Equal("null", JSON.SQL(JSONDocument{}).ToString())
+	assert.Equal("[1,2]", JSON.SQL([]byte("[1, 2]")).ToString())
+
+	values := []interface{}{
+		JSONDocument{},
+		-1.5,
+		int64(2),
+		JSONDocument{"A"},
+		JSONDocument{"a"},
+		map[string]interface{}{"b": 1.0},
+		map[string]interface{}{"a": 1.0, "b": 1.0},
+		[]interface{}{1.0},
+		[]interface{}{1.0, 2.0},
+		[]interface{}{2.0},
+		false,
+		true,
+	}
+	for i, a := range values {
+		for j, b := range values {
+			expected := 0
+			if i < j {
+				expected = -1
+			} else if i > j {
+				expected = 1
+			}
+			assert.Equal(expected, JSON.Compare(a, b), "%v %v", a, b)
+		}
+	}
+
+	assert.Equal(0, JSON.Compare(1.0, int64(1)))
+	assert.Equal(0, JSON.Compare([]string{"a"}, []interface{}{"a"}))
+	assert.Equal(0, JSON.Compare(
+		map[string]interface{}{"a": 1.0, "b": []interface{}{true}},
+		map[string]interface{}{"b": []interface{}{true}, "a": 1.0},
+	))
+}
+
+func TestMySQLTypeName(t *testing.T) {
+	assert := assert.New(t)
 
-	assert.Equal(-1, JSON.Compare([]byte{'A'}, []byte{'B'}))
-	assert.Equal(0, JSON.Compare([]byte{'A'}, []byte{'A'}))
-	assert.Equal(1, JSON.Compare([]byte{'B'}, []byte{'A'}))
+	assert.Equal("int", MySQLTypeName(Int32))
+	assert.Equal("bigint unsigned", MySQLTypeName(Uint64))
+	assert.Equal("double", MySQLTypeName(Float64))
+	assert.Equal("decimal(10,2)", MySQLTypeName(Decimal(10, 2)))
+	assert.Equal("timestamp", MySQLTypeName(Timestamp))
+	assert.Equal("date", MySQLTypeName(Date))
+	assert.Equal("datetime", MySQLTypeName(Datetime(0)))
+	assert.Equal("datetime(6)", MySQLTypeName(Datetime(6)))
+	assert.Equal("time", MySQLTypeName(Time))
+	assert.Equal("year", MySQLTypeName(Year))
+	assert.Equal("text", MySQLTypeName(Text))
+	assert.Equal("longtext", MySQLTypeName(LongText))
+	assert.Equal("char(10)", MySQLTypeName(Char(10)))
+	assert.Equal("varchar(255)", MySQLTypeName(Varchar(255)))
+	assert.Equal("binary(16)", MySQLTypeName(Binary(16)))
+	assert.Equal("varbinary(8)", MySQLTypeName(Varbinary(8)))
+	assert.Equal("blob", MySQLTypeName(Blob))
+	assert.Equal("tinyint(1)", MySQLTypeName(Boolean))
+	assert.Equal("bit(8)", MySQLTypeName(Bit(8)))
+	assert.Equal("tinyint", MySQLTypeName(Int8))
+	assert.Equal("smallint unsigned", MySQLTypeName(Uint16))
+	assert.Equal("mediumint", MySQLTypeName(Int24))
+	assert.Equal("json", MySQLTypeName(JSON))
+	assert.Equal("null", MySQLTypeName(Null))
 }
@@ -1,9 +1,18 @@
 package sql
 
-type UnresolvedDatabase struct{}
+// UnresolvedDatabase is a database that has not been resolved yet. An empty
+// name refers to the current database.
+type UnresolvedDatabase struct {
+	name string
+}
+
+// NewUnresolvedDatabase creates an unresolved database with the given name.
+func NewUnresolvedDatabase(name string) *UnresolvedDatabase {
+	return &UnresolvedDatabase{name: name}
+}
 
 func (d *UnresolvedDatabase) Name() string {
-	return "unresolved_database"
+	return d.name
 }
 
 func (d *UnresolvedDatabase) Tables() map[string]Table {
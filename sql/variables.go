@@ -0,0 +1,302 @@
+package sql
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// SystemVariableScope is the scope in which a system variable is defined or
+// accessed.
+type SystemVariableScope byte
+
+const (
+	// DefaultScope is used when the scope is not explicitly given. Reads use
+	// the session value if the variable has one and the global value
+	// otherwise. Writes go to the session value.
+	DefaultScope SystemVariableScope = 0
+	// GlobalScope refers to the server-wide value of a variable.
+	GlobalScope SystemVariableScope = 1 << 0
+	// SessionScope refers to the value of a variable for a single session.
+	SessionScope SystemVariableScope = 1 << 1
+	// BothScopes is used for variables that have both a global and a session
+	// value.
+	BothScopes = GlobalScope | SessionScope
+)
+
+func (s SystemVariableScope) has(scope SystemVariableScope) bool {
+	return s&scope == scope
+}
+
+// String returns the name of the scope as used in SQL.
+func (s SystemVariableScope) String() string {
+	switch s {
+	case GlobalScope:
+		return "global"
+	case SessionScope:
+		return "session"
+	default:
+		return ""
+	}
+}
+
+// SystemVariable is the definition of a system variable.
+type SystemVariable struct {
+	// Name of the variable.
+	Name string
+	// Scope in which the variable is defined.
+	Scope SystemVariableScope
+	// Type of the values of the variable.
+	Type Type
+	// Default value of the variable.
+	Default interface{}
+	// ReadOnly is true if the variable cannot be changed with SET.
+	ReadOnly bool
+}
+
+func (v SystemVariable) defaultScope() SystemVariableScope {
+	if v.Scope.has(SessionScope) {
+		return SessionScope
+	}
+
+	return GlobalScope
+}
+
+// convert converts the given value to the type of the variable. Boolean
+// variables are represented as 0 and 1 and accept ON and OFF as well, and
+// time zones must be known.
+func (v SystemVariable) convert(value interface{}) (interface{}, error) {
+	if value == nil {
+		return v.Default, nil
+	}
+
+	if v.Type == Int64 {
+		switch value := value.(type) {
+		case bool:
+			if value {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		case string:
+			switch strings.ToUpper(value) {
+			case "ON", "TRUE":
+				return int64(1), nil
+			case "OFF", "FALSE":
+				return int64(0), nil
+			}
+		}
+	}
+
+	cv, err := v.Type.Convert(value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid value for variable %s: %v", v.Name, value)
+	}
+
+	if v.Name == "time_zone" && !strings.EqualFold(cv.(string), "SYSTEM") {
+		if _, err := ParseTimeZone(cv.(string)); err != nil {
+			return nil, err
+		}
+	}
+
+	return cv, nil
+}
+
+func errUnknownSystemVariable(name string) error {
+	return fmt.Errorf("unknown system variable: %s", name)
+}
+
+func errGlobalOnlyVariable(name string) error {
+	return fmt.Errorf("variable %s is a GLOBAL variable", name)
+}
+
+func errReadOnlyVariable(name string) error {
+	return fmt.Errorf("variable %s is a read only variable", name)
+}
+
+// normalizeVariableName returns the name of a variable in its canonical
+// form, which is lowercase, as variable names are case insensitive.
+func normalizeVariableName(name string) string {
+	return strings.ToLower(name)
+}
+
+// SystemVariables is a registry of system variable definitions which also
+// holds the global values of those variables. It is safe for concurrent use.
+type SystemVariables struct {
+	mu          sync.RWMutex
+	definitions map[string]SystemVariable
+	values      map[string]interface{}
+}
+
+// NewSystemVariables creates a new registry with the default system
+// variables.
+func NewSystemVariables() *SystemVariables {
+	v := &SystemVariables{
+		definitions: make(map[string]SystemVariable),
+		values:      make(map[string]interface{}),
+	}
+
+	for _, def := range defaultSystemVariables {
+		v.Register(def)
+	}
+
+	return v
+}
+
+// Register adds a new system variable definition to the registry, replacing
+// any previous one with the same name. Its global value is set to the
+// default value.
+func (v *SystemVariables) Register(def SystemVariable) {
+	def.Name = normalizeVariableName(def.Name)
+	if def.Scope == DefaultScope {
+		def.Scope = BothScopes
+	}
+
+	v.mu.Lock()
+	v.definitions[def.Name] = def
+	v.values[def.Name] = def.Default
+	v.mu.Unlock()
+}
+
+// Definition returns the definition of the variable with the given name.
+func (v *SystemVariables) Definition(name string) (SystemVariable, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	def, ok := v.definitions[normalizeVariableName(name)]
+	return def, ok
+}
+
+func (v *SystemVariables) definition(name string) (SystemVariable, error) {
+	def, ok := v.Definition(name)
+	if !ok {
+		return SystemVariable{}, errUnknownSystemVariable(name)
+	}
+
+	return def, nil
+}
+
+// Get returns the global value of the variable with the given name.
+func (v *SystemVariables) Get(name string) (interface{}, error) {
+	name = normalizeVariableName(name)
+
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+	if _, ok := v.definitions[name]; !ok {
+		return nil, errUnknownSystemVariable(name)
+	}
+
+	return v.values[name], nil
+}
+
+// Set changes the global value of the variable with the given name. Sessions
+// that already exist keep their own values.
+func (v *SystemVariables) Set(name string, value interface{}) error {
+	def, err := v.definition(name)
+	if err != nil {
+		return err
+	}
+
+	if def.ReadOnly {
+		return errReadOnlyVariable(def.Name)
+	}
+
+	if !def.Scope.has(GlobalScope) {
+		return fmt.Errorf("variable %s is a SESSION variable", def.Name)
+	}
+
+	cv, err := def.convert(value)
+	if err != nil {
+		return err
+	}
+
+	v.mu.Lock()
+	v.values[def.Name] = cv
+	v.mu.Unlock()
+	return nil
+}
+
+func (v *SystemVariables) globals() map[string]interface{} {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	values := make(map[string]interface{}, len(v.values))
+	for name, def := range v.definitions {
+		if def.Scope.has(GlobalScope) {
+			values[name] = v.values[name]
+		}
+	}
+
+	return values
+}
+
+func (v *SystemVariables) sessionDefaults() map[string]interface{} {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	values := make(map[string]interface{})
+	for name, def := range v.definitions {
+		if def.Scope.has(SessionScope) {
+			values[name] = v.values[name]
+		}
+	}
+
+	return values
+}
+
+var defaultSystemVariables = []SystemVariable{
+	{Name: "auto_increment_increment", Type: Int64, Default: int64(1)},
+	{Name: "autocommit", Type: Int64, Default: int64(1)},
+	{Name: "character_set_client", Type: Text, Default: "utf8mb4"},
+	{Name: "character_set_connection", Type: Text, Default: "utf8mb4"},
+	{Name: "character_set_database", Type: Text, Default: "utf8mb4"},
+	{Name: "character_set_results", Type: Text, Default: "utf8mb4"},
+	{Name: "character_set_server", Type: Text, Default: "utf8mb4"},
+	{Name: "character_set_system", Type: Text, Default: "utf8", Scope: GlobalScope, ReadOnly: true},
+	{Name: "collation_connection", Type: Text, Default: "utf8mb4_general_ci"},
+	{Name: "collation_database", Type: Text, Default: "utf8mb4_general_ci"},
+	{Name: "collation_server", Type: Text, Default: "utf8mb4_general_ci"},
+	{Name: "foreign_key_checks", Type: Int64, Default: int64(1)},
+	{Name: "init_connect", Type: Text, Default: "", Scope: GlobalScope},
+	{Name: "interactive_timeout", Type: Int64, Default: int64(28800)},
+	{Name: "license", Type: Text, Default: "MIT", Scope: GlobalScope, ReadOnly: true},
+	{Name: "lower_case_table_names", Type: Int64, Default: int64(0), Scope: GlobalScope, ReadOnly: true},
+	{Name: "max_allowed_packet", Type: Int64, Default: int64(4194304)},
+	{Name: "net_buffer_length", Type: Int64, Default: int64(16384)},
+	{Name: "net_read_timeout", Type: Int64, Default: int64(30)},
+	{Name: "net_write_timeout", Type: Int64, Default: int64(60)},
+	{Name: "performance_schema", Type: Int64, Default: int64(0), Scope: GlobalScope, ReadOnly: true},
+	{Name: "query_cache_size", Type: Int64, Default: int64(0), Scope: GlobalScope},
+	{Name: "query_cache_type", Type: Text, Default: "OFF"},
+	{Name: "sql_auto_is_null", Type: Int64, Default: int64(0)},
+	{Name: "sql_mode", Type: Text, Default: "STRICT_TRANS_TABLES,NO_ENGINE_SUBSTITUTION"},
+	{Name: "sql_select_limit", Type: Uint64, Default: uint64(math.MaxUint64)},
+	{Name: "system_time_zone", Type: Text, Default: "UTC", Scope: GlobalScope, ReadOnly: true},
+	{Name: "time_zone", Type: Text, Default: "SYSTEM"},
+	{Name: "transaction_isolation", Type: Text, Default: "REPEATABLE-READ"},
+	{Name: "transaction_read_only", Type: Int64, Default: int64(0)},
+	{Name: "tx_isolation", Type: Text, Default: "REPEATABLE-READ"},
+	{Name: "tx_read_only", Type: Int64, Default: int64(0)},
+	{Name: "unique_checks", Type: Int64, Default: int64(1)},
+	{Name: "version", Type: Text, Default: "5.7.9", Scope: GlobalScope, ReadOnly: true},
+	{Name: "version_comment", Type: Text, Default: "go-mysql-server", Scope: GlobalScope, ReadOnly: true},
+	{Name: "wait_timeout", Type: Int64, Default: int64(28800)},
+}
+
+// StrictMode returns whether the SQL mode of a session is strict, in which
+// case invalid values are rejected instead of being adjusted with a
+// warning.
+func StrictMode(s Session) bool {
+	_, v, err := s.SystemVariable(DefaultScope, "sql_mode")
+	if err != nil {
+		return false
+	}
+
+	mode, _ := v.(string)
+	for _, m := range strings.Split(strings.ToUpper(mode), ",") {
+		if m == "STRICT_TRANS_TABLES" || m == "STRICT_ALL_TABLES" {
+			return true
+		}
+	}
+
+	return false
+}
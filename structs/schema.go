@@ -0,0 +1,308 @@
+package structs
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// field is a column of a table and the struct field it's read from.
+type field struct {
+	// index is the index sequence of the field, as in reflect.FieldByIndex.
+	index []int
+	typ   sql.Type
+	// ptr is true if the struct field is a pointer to the value.
+	ptr bool
+}
+
+var (
+	timeType  = reflect.TypeOf(time.Time{})
+	bytesType = reflect.TypeOf([]byte(nil))
+)
+
+// types are the types that can be used in tags, by name.
+var types = map[string]sql.Type{
+	"int8":      sql.Int8,
+	"int16":     sql.Int16,
+	"int24":     sql.Int24,
+	"int32":     sql.Int32,
+	"int64":     sql.Int64,
+	"uint8":     sql.Uint8,
+	"uint16":    sql.Uint16,
+	"uint24":    sql.Uint24,
+	"uint32":    sql.Uint32,
+	"uint64":    sql.Uint64,
+	"float32":   sql.Float32,
+	"float64":   sql.Float64,
+	"timestamp": sql.Timestamp,
+	"date":      sql.Date,
+	"datetime":  sql.Datetime(0),
+	"time":      sql.Time,
+	"year":      sql.Year,
+	"text":      sql.Text,
+	"boolean":   sql.Boolean,
+	"blob":      sql.Blob,
+	"json":      sql.JSON,
+}
+
+// structType returns the struct type of the values of typ, which must be a
+// struct or a pointer to a struct.
+func structType(typ reflect.Type) (reflect.Type, bool, error) {
+	if typ.Kind() == reflect.Ptr && typ.Elem().Kind() == reflect.Struct {
+		return typ.Elem(), true, nil
+	}
+
+	if typ.Kind() != reflect.Struct {
+		return nil, false, fmt.Errorf("structs: %s is not a struct", typ)
+	}
+
+	return typ, false, nil
+}
+
+// schemaOf returns the schema of a table of structs of the given type and
+// the fields of its columns. Exported fields are columns, and the fields of
+// embedded structs are columns of the outer struct. A field can be
+// configured with a tag such as:
+//
+//	Email string `sql:"email_address,text,nullable"`
+//
+// where every part is optional. The name defaults to the name of the field,
+// the type is inferred from the type of the field, and pointers are
+// nullable. A field with the tag `sql:"-"` is not a column.
+func schemaOf(typ reflect.Type) (sql.Schema, []field, error) {
+	var schema sql.Schema
+	var fields []field
+	for i := 0; i < typ.NumField(); i++ {
+		f := typ.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+
+		tag := f.Tag.Get("sql")
+		if tag == "-" {
+			continue
+		}
+
+		if f.Anonymous && tag == "" && f.Type.Kind() == reflect.Struct {
+			s, fs, err := schemaOf(f.Type)
+			if err != nil {
+				return nil, nil, err
+			}
+
+			for _, ef := range fs {
+				ef.index = append([]int{i}, ef.index...)
+				fields = append(fields, ef)
+			}
+
+			schema = append(schema, s...)
+			continue
+		}
+
+		if f.PkgPath != "" {
+			continue
+		}
+
+		parts := strings.Split(tag, ",")
+		name := f.Name
+		if parts[0] != "" {
+			name = parts[0]
+		}
+
+		ft := f.Type
+		ptr := ft.Kind() == reflect.Ptr
+		if ptr {
+			ft = ft.Elem()
+		}
+
+		column := &sql.Column{Name: name, Nullable: ptr}
+		if len(parts) > 1 && parts[1] != "" {
+			t, ok := types[strings.ToLower(parts[1])]
+			if !ok {
+				return nil, nil, fmt.Errorf("structs: unknown type %s of field %s", parts[1], f.Name)
+			}
+			column.Type = t
+		} else {
+			column.Type = goType(ft)
+		}
+
+		for _, opt := range parts[min(len(parts), 2):] {
+			if opt != "nullable" {
+				return nil, nil, fmt.Errorf("structs: unknown option %s of field %s", opt, f.Name)
+			}
+			column.Nullable = true
+		}
+
+		schema = append(schema, column)
+		fields = append(fields, field{index: []int{i}, typ: column.Type, ptr: ptr})
+	}
+
+	return schema, fields, nil
+}
+
+func min(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
+
+// goType returns the SQL type of values of a Go type. Values of types that
+// have no SQL counterpart, such as slices, maps and structs, are JSON.
+func goType(t reflect.Type) sql.Type {
+	switch {
+	case t == timeType:
+		return sql.Timestamp
+	case t == bytesType:
+		return sql.Blob
+	}
+
+	switch t.Kind() {
+	case reflect.Int8:
+		return sql.Int8
+	case reflect.Int16:
+		return sql.Int16
+	case reflect.Int32:
+		return sql.Int32
+	case reflect.Int, reflect.Int64:
+		return sql.Int64
+	case reflect.Uint8:
+		return sql.Uint8
+	case reflect.Uint16:
+		return sql.Uint16
+	case reflect.Uint32:
+		return sql.Uint32
+	case reflect.Uint, reflect.Uint64:
+		return sql.Uint64
+	case reflect.Float32:
+		return sql.Float32
+	case reflect.Float64:
+		return sql.Float64
+	case reflect.Bool:
+		return sql.Boolean
+	case reflect.String:
+		return sql.Text
+	default:
+		return sql.JSON
+	}
+}
+
+// toRow returns the row of a struct value.
+func toRow(v reflect.Value, fields []field) (sql.Row, error) {
+	row := make(sql.Row, len(fields))
+	for i, f := range fields {
+		fv := v.FieldByIndex(f.index)
+		if f.ptr {
+			if fv.IsNil() {
+				continue
+			}
+			fv = fv.Elem()
+		}
+
+		if f.typ == sql.JSON {
+			row[i] = fv.Interface()
+			continue
+		}
+
+		converted, err := f.typ.Convert(basicValue(fv))
+		if err != nil {
+			return nil, fmt.Errorf("structs: can't convert field %s: %s", v.Type().FieldByIndex(f.index).Name, err)
+		}
+
+		row[i] = converted
+	}
+
+	return row, nil
+}
+
+// basicValue returns the value of v with the basic type of its kind, so
+// values of named types such as `type Level string` can be converted.
+func basicValue(v reflect.Value) interface{} {
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return v.Int()
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return v.Uint()
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Bool:
+		return v.Bool()
+	case reflect.String:
+		return v.String()
+	default:
+		return v.Interface()
+	}
+}
+
+// fromRow sets the fields of a struct value to the values of a row.
+func fromRow(v reflect.Value, schema sql.Schema, fields []field, row sql.Row) error {
+	if len(row) != len(fields) {
+		return fmt.Errorf("insert expected %d values, got %d", len(fields), len(row))
+	}
+
+	for i, f := range fields {
+		if row[i] == nil {
+			if !schema[i].Nullable {
+				return sql.ErrInvalidType
+			}
+			continue
+		}
+
+		fv := v.FieldByIndex(f.index)
+		if f.ptr {
+			fv.Set(reflect.New(fv.Type().Elem()))
+			fv = fv.Elem()
+		}
+
+		if err := setValue(fv, f.typ, row[i]); err != nil {
+			return sql.ErrInvalidType
+		}
+	}
+
+	return nil
+}
+
+func setValue(fv reflect.Value, typ sql.Type, value interface{}) error {
+	if typ == sql.JSON {
+		// Documents are decoded into the field, so they are not assigned
+		// to fields of interface types.
+		rv := reflect.ValueOf(value)
+		if _, ok := value.(sql.JSONDocument); !ok && rv.Type().AssignableTo(fv.Type()) {
+			fv.Set(rv)
+			return nil
+		}
+
+		var data []byte
+		switch value := value.(type) {
+		case string:
+			data = []byte(value)
+		case []byte:
+			data = value
+		default:
+			var err error
+			if data, err = json.Marshal(value); err != nil {
+				return err
+			}
+		}
+
+		return json.Unmarshal(data, fv.Addr().Interface())
+	}
+
+	converted, err := typ.Convert(value)
+	if err != nil {
+		return err
+	}
+
+	// Numbers can be converted to strings by reflect, but not the way SQL
+	// does it.
+	rv := reflect.ValueOf(converted)
+	if !rv.Type().ConvertibleTo(fv.Type()) || (fv.Kind() == reflect.String) != (rv.Kind() == reflect.String) {
+		return sql.ErrInvalidType
+	}
+
+	fv.Set(rv.Convert(fv.Type()))
+	return nil
+}
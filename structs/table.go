@@ -0,0 +1,269 @@
+// Package structs implements tables whose rows are Go structs.
+//
+// The columns of a table are the exported fields of the struct, configured
+// with `sql:"name,type,nullable"` tags. A table can read its rows from a
+// slice, which also accepts inserts, from an iterator, or from a channel.
+package structs
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sync"
+
+	"github.com/src-d/go-mysql-server/sql"
+)
+
+// Iterator returns the values of a table, which are structs or pointers to
+// structs.
+type Iterator interface {
+	// Next returns the next value or io.EOF when there are no more values.
+	Next() (interface{}, error)
+	// Close closes the iterator.
+	Close() error
+}
+
+// Table is a table whose rows are Go structs.
+type Table struct {
+	name   string
+	schema sql.Schema
+	fields []field
+	typ    reflect.Type
+	// ptr is true if the values are pointers to structs.
+	ptr bool
+
+	// mu guards the slice of a slice table.
+	mu    sync.RWMutex
+	slice reflect.Value
+	iter  func() (Iterator, error)
+	ch    reflect.Value
+}
+
+func newTable(name string, typ reflect.Type) (*Table, error) {
+	st, ptr, err := structType(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	schema, fields, err := schemaOf(st)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Table{name: name, schema: schema, fields: fields, typ: st, ptr: ptr}, nil
+}
+
+// NewSliceTable creates a table with the values of the slice pointed by
+// slice, which must be a pointer to a slice of structs or of pointers to
+// structs. The rows inserted in the table are appended to the slice.
+func NewSliceTable(name string, slice interface{}) (*Table, error) {
+	v := reflect.ValueOf(slice)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Slice {
+		return nil, fmt.Errorf("structs: expected a pointer to a slice, got %T", slice)
+	}
+
+	t, err := newTable(name, v.Elem().Type().Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	t.slice = v
+	return t, nil
+}
+
+// NewIteratorTable creates a table whose rows are the values of the
+// iterators returned by f, which are called every time the table is read.
+// Values must have the type of sample, which is a struct or a pointer to a
+// struct.
+func NewIteratorTable(name string, sample interface{}, f func() (Iterator, error)) (*Table, error) {
+	t, err := newTable(name, reflect.TypeOf(sample))
+	if err != nil {
+		return nil, err
+	}
+
+	t.iter = f
+	return t, nil
+}
+
+// NewChanTable creates a table whose rows are received from ch, which must
+// be a channel of structs or of pointers to structs. Every value is only
+// read once, so a value read by an iterator is not seen by the others. The
+// table has no more rows once the channel is closed.
+func NewChanTable(name string, ch interface{}) (*Table, error) {
+	v := reflect.ValueOf(ch)
+	if v.Kind() != reflect.Chan || v.Type().ChanDir()&reflect.RecvDir == 0 {
+		return nil, fmt.Errorf("structs: expected a receive channel, got %T", ch)
+	}
+
+	t, err := newTable(name, v.Type().Elem())
+	if err != nil {
+		return nil, err
+	}
+
+	t.ch = v
+	return t, nil
+}
+
+// Resolved implements the Resolvable interface.
+func (*Table) Resolved() bool {
+	return true
+}
+
+// Name implements the Nameable interface.
+func (t *Table) Name() string {
+	return t.name
+}
+
+// Schema implements the Node interface.
+func (t *Table) Schema() sql.Schema {
+	return t.schema
+}
+
+// Children implements the Node interface.
+func (t *Table) Children() []sql.Node {
+	return []sql.Node{}
+}
+
+// RowIter implements the Node interface.
+func (t *Table) RowIter(ctx *sql.Context) (sql.RowIter, error) {
+	switch {
+	case t.iter != nil:
+		iter, err := t.iter()
+		if err != nil {
+			return nil, err
+		}
+
+		return &iteratorIter{t: t, iter: iter}, nil
+	case t.ch.IsValid():
+		return &chanIter{t: t, ctx: ctx}, nil
+	default:
+		t.mu.RLock()
+		slice := reflect.ValueOf(t.slice.Elem().Interface())
+		t.mu.RUnlock()
+		return &sliceIter{t: t, slice: slice}, nil
+	}
+}
+
+// TransformUp implements the Transformable interface.
+func (t *Table) TransformUp(f func(sql.Node) sql.Node) sql.Node {
+	return f(t)
+}
+
+// TransformExpressionsUp implements the Transformable interface.
+func (t *Table) TransformExpressionsUp(f func(sql.Expression) sql.Expression) sql.Node {
+	return t
+}
+
+// Insert implements the sql.Inserter interface. The row is converted to a
+// struct, which is appended to the slice of the table. Only slice tables
+// accept inserts.
+func (t *Table) Insert(ctx *sql.Context, row sql.Row) error {
+	if !t.slice.IsValid() {
+		return fmt.Errorf("structs: can't insert in table %s", t.name)
+	}
+
+	v := reflect.New(t.typ)
+	if err := fromRow(v.Elem(), t.schema, t.fields, row); err != nil {
+		return err
+	}
+
+	if !t.ptr {
+		v = v.Elem()
+	}
+
+	t.mu.Lock()
+	t.slice.Elem().Set(reflect.Append(t.slice.Elem(), v))
+	t.mu.Unlock()
+	return nil
+}
+
+// row returns the row of a value of the table.
+func (t *Table) row(v reflect.Value) (sql.Row, error) {
+	if t.ptr {
+		if v.IsNil() {
+			return nil, fmt.Errorf("structs: nil value in table %s", t.name)
+		}
+		v = v.Elem()
+	}
+
+	return toRow(v, t.fields)
+}
+
+// valueType returns the type of the values of the table.
+func (t *Table) valueType() reflect.Type {
+	if t.ptr {
+		return reflect.PtrTo(t.typ)
+	}
+
+	return t.typ
+}
+
+type sliceIter struct {
+	t     *Table
+	slice reflect.Value
+	pos   int
+}
+
+func (i *sliceIter) Next() (sql.Row, error) {
+	if i.pos >= i.slice.Len() {
+		return nil, io.EOF
+	}
+
+	i.pos++
+	return i.t.row(i.slice.Index(i.pos - 1))
+}
+
+func (i *sliceIter) Close() error {
+	return nil
+}
+
+type iteratorIter struct {
+	t    *Table
+	iter Iterator
+}
+
+func (i *iteratorIter) Next() (sql.Row, error) {
+	value, err := i.iter.Next()
+	if err != nil {
+		return nil, err
+	}
+
+	v := reflect.ValueOf(value)
+	if !v.IsValid() || v.Type() != i.t.valueType() {
+		return nil, fmt.Errorf("structs: expected a value of type %s, got %T", i.t.valueType(), value)
+	}
+
+	return i.t.row(v)
+}
+
+func (i *iteratorIter) Close() error {
+	return i.iter.Close()
+}
+
+type chanIter struct {
+	t   *Table
+	ctx *sql.Context
+}
+
+// Next receives a value from the channel. It stops waiting if the context
+// is cancelled.
+func (i *chanIter) Next() (sql.Row, error) {
+	chosen, v, ok := reflect.Select([]reflect.SelectCase{
+		{Dir: reflect.SelectRecv, Chan: i.t.ch},
+		{Dir: reflect.SelectRecv, Chan: reflect.ValueOf(i.ctx.Done())},
+	})
+
+	if chosen == 1 {
+		return nil, i.ctx.Err()
+	}
+
+	if !ok {
+		return nil, io.EOF
+	}
+
+	return i.t.row(v)
+}
+
+func (i *chanIter) Close() error {
+	return nil
+}
@@ -0,0 +1,207 @@
+package structs
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/src-d/go-mysql-server/sql"
+
+	"github.com/stretchr/testify/require"
+)
+
+type base struct {
+	ID int
+}
+
+type level string
+
+type person struct {
+	base
+	Name     string `sql:"name"`
+	Email    *string
+	Age      uint8 `sql:",int64"`
+	Level    level
+	Phones   []string `sql:"phones,,nullable"`
+	Created  time.Time
+	Password string `sql:"-"`
+	internal int
+}
+
+var personSchema = sql.Schema{
+	{Name: "ID", Type: sql.Int64},
+	{Name: "name", Type: sql.Text},
+	{Name: "Email", Type: sql.Text, Nullable: true},
+	{Name: "Age", Type: sql.Int64},
+	{Name: "Level", Type: sql.Text},
+	{Name: "phones", Type: sql.JSON, Nullable: true},
+	{Name: "Created", Type: sql.Timestamp},
+}
+
+var created = time.Date(2018, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func testPeople() []person {
+	email := "john@doe.com"
+	return []person{
+		{base{1}, "John", &email, 30, "admin", []string{"555"}, created, "secret", 0},
+		{base{2}, "Jane", nil, 40, "user", nil, created, "", 0},
+	}
+}
+
+func testRows(people []person) []sql.Row {
+	var rows []sql.Row
+	for _, p := range people {
+		var email interface{}
+		if p.Email != nil {
+			email = *p.Email
+		}
+
+		rows = append(rows, sql.NewRow(
+			int64(p.ID), p.Name, email, int64(p.Age), string(p.Level), p.Phones, p.Created,
+		))
+	}
+
+	return rows
+}
+
+func TestSliceTable(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	people := testPeople()
+	table, err := NewSliceTable("people", &people)
+	require.NoError(err)
+	require.Equal("people", table.Name())
+	require.Equal(personSchema, table.Schema())
+
+	rows, err := sql.NodeToRows(ctx, table)
+	require.NoError(err)
+	require.Equal(testRows(people), rows)
+
+	require.NoError(table.Insert(ctx, sql.NewRow(
+		3, "Bob", nil, 50, "user", `["666", "777"]`, "2018-01-02 03:04:05",
+	)))
+	require.Error(table.Insert(ctx, sql.NewRow(4, nil, nil, 1, "user", nil, created)))
+	require.Error(table.Insert(ctx, sql.NewRow(4)))
+
+	require.Len(people, 3)
+	require.Equal(person{
+		base:    base{3},
+		Name:    "Bob",
+		Age:     50,
+		Level:   "user",
+		Phones:  []string{"666", "777"},
+		Created: created,
+	}, people[2])
+
+	pointers := []*person{&people[0]}
+	table, err = NewSliceTable("people", &pointers)
+	require.NoError(err)
+	require.NoError(table.Insert(ctx, testRows(people[1:2])[0]))
+	require.Len(pointers, 2)
+	require.Equal(people[1], *pointers[1])
+
+	_, err = NewSliceTable("people", people)
+	require.Error(err)
+
+	_, err = NewSliceTable("ints", &[]int{})
+	require.Error(err)
+}
+
+type sliceIterator struct {
+	values []interface{}
+	closed bool
+}
+
+func (i *sliceIterator) Next() (interface{}, error) {
+	if len(i.values) == 0 {
+		return nil, io.EOF
+	}
+
+	v := i.values[0]
+	i.values = i.values[1:]
+	return v, nil
+}
+
+func (i *sliceIterator) Close() error {
+	i.closed = true
+	return nil
+}
+
+func TestIteratorTable(t *testing.T) {
+	require := require.New(t)
+	ctx := sql.NewEmptyContext()
+
+	people := testPeople()
+	var iter *sliceIterator
+	table, err := NewIteratorTable("people", &person{}, func() (Iterator, error) {
+		iter = &sliceIterator{values: []interface{}{&people[0], &people[1]}}
+		return iter, nil
+	})
+	require.NoError(err)
+	require.Equal(personSchema, table.Schema())
+
+	for i := 0; i < 2; i++ {
+		rows, err := sql.NodeToRows(ctx, table)
+		require.NoError(err)
+		require.Equal(testRows(people), rows)
+		require.True(iter.closed)
+	}
+
+	table, err = NewIteratorTable("people", person{}, func() (Iterator, error) {
+		return &sliceIterator{values: []interface{}{&people[0]}}, nil
+	})
+	require.NoError(err)
+
+	_, err = sql.NodeToRows(ctx, table)
+	require.Error(err)
+	require.Error(table.Insert(ctx, testRows(people)[0]))
+}
+
+func TestChanTable(t *testing.T) {
+	require := require.New(t)
+
+	people := testPeople()
+	ch := make(chan person, len(people))
+	for _, p := range people {
+		ch <- p
+	}
+	close(ch)
+
+	table, err := NewChanTable("people", ch)
+	require.NoError(err)
+	require.Equal(personSchema, table.Schema())
+
+	rows, err := sql.NodeToRows(sql.NewEmptyContext(), table)
+	require.NoError(err)
+	require.Equal(testRows(people), rows)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	table, err = NewChanTable("people", make(chan person))
+	require.NoError(err)
+
+	iter, err := table.RowIter(sql.NewContext(ctx, nil))
+	require.NoError(err)
+
+	cancel()
+	_, err = iter.Next()
+	require.Equal(context.Canceled, err)
+
+	_, err = NewChanTable("people", make(chan<- person))
+	require.Error(err)
+}
+
+func TestSchemaOf_InvalidTag(t *testing.T) {
+	require := require.New(t)
+
+	_, err := NewSliceTable("t", &[]struct {
+		A int `sql:",foo"`
+	}{})
+	require.Error(err)
+
+	_, err = NewSliceTable("t", &[]struct {
+		A int `sql:",,foo"`
+	}{})
+	require.Error(err)
+}